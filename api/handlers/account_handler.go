@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountHandler interface {
+	Index(ctx *gin.Context)
+	Show(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type AccountHandlerImpl struct {
+	svc services.AccountService
+}
+
+func (u AccountHandlerImpl) Index(ctx *gin.Context) {
+	code, response := u.svc.Index(ParseUserFromContext(ctx))
+	ctx.JSON(code, response)
+}
+
+func (u AccountHandlerImpl) Show(ctx *gin.Context) {
+	accountID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Show(ParseUserFromContext(ctx), accountID)
+	ctx.JSON(code, response)
+}
+
+func (u AccountHandlerImpl) Create(ctx *gin.Context) {
+	var accountRequest dto.AccountRequest
+	validationError := ctx.ShouldBindJSON(&accountRequest)
+	if validationError != nil || invalidAccount(accountRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Create(ParseUserFromContext(ctx), accountRequest)
+	ctx.JSON(code, response)
+}
+
+func (u AccountHandlerImpl) Update(ctx *gin.Context) {
+	accountID, _ := strconv.Atoi(ctx.Param("id"))
+	var accountRequest dto.AccountRequest
+	validationError := ctx.ShouldBindJSON(&accountRequest)
+	if validationError != nil || invalidAccount(accountRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Update(ParseUserFromContext(ctx), accountRequest, accountID)
+	ctx.JSON(code, response)
+}
+
+func (u AccountHandlerImpl) Delete(ctx *gin.Context) {
+	accountID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Delete(ParseUserFromContext(ctx), accountID)
+	ctx.JSON(code, response)
+}
+
+func invalidAccount(request dto.AccountRequest) bool {
+	if strings.TrimSpace(request.Name) == "" {
+		return true
+	}
+	switch request.Type {
+	case "cash", "bank", "credit_card", "savings":
+	default:
+		return true
+	}
+	return request.Currency != "" && invalidCurrencyCode(request.Currency)
+}
+
+func AccountHandlerInit(accountService services.AccountService) *AccountHandlerImpl {
+	return &AccountHandlerImpl{
+		svc: accountService,
+	}
+}
@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockAccountService struct{}
+
+func (m *MockAccountService) Index(user dao.User) (int, []dto.TransformedAccount) {
+	return http.StatusOK, []dto.TransformedAccount{
+		{
+			ID:             1,
+			Name:           "Wallet",
+			Type:           "cash",
+			Currency:       "ARS",
+			OpeningBalance: money.FromFloat(500),
+			IsDefault:      true,
+		},
+	}
+}
+
+func (m *MockAccountService) Show(user dao.User, accountID int) (int, interface{}) {
+	if accountID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	_, response := m.Index(user)
+	return http.StatusOK, response[0]
+}
+
+func (m *MockAccountService) Create(user dao.User, accountRequest dto.AccountRequest) (int, interface{}) {
+	return http.StatusCreated, gin.H{"message": "Account successfully created."}
+}
+
+func (m *MockAccountService) Update(user dao.User, accountRequest dto.AccountRequest, accountID int) (int, interface{}) {
+	if accountID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Account successfully updated."}
+}
+
+func (m *MockAccountService) Delete(user dao.User, accountID int) (int, interface{}) {
+	if accountID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Account successfully deleted."}
+}
+
+func TestAccountHandlerImpl_Index(t *testing.T) {
+	accountHandler := AccountHandlerInit(&MockAccountService{})
+	serviceUri := "/api/accounts"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the user has accounts",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"name\":\"Wallet\",\"type\":\"cash\",\"currency\":\"ARS\",\"opening_balance\":\"500.00\",\"is_default\":true}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			accountHandler.Index(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestAccountHandlerImpl_Show(t *testing.T) {
+	accountHandler := AccountHandlerInit(&MockAccountService{})
+	serviceUri := "/api/accounts"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the account is found",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"name\":\"Wallet\",\"type\":\"cash\",\"currency\":\"ARS\",\"opening_balance\":\"500.00\",\"is_default\":true}",
+		},
+		{
+			Name:         "when the account is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			accountHandler.Show(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestAccountHandlerImpl_Create(t *testing.T) {
+	accountHandler := AccountHandlerInit(&MockAccountService{})
+	serviceUri := "/api/accounts"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the account is created successfully",
+			Params:       `{"name": "Bank", "type": "bank", "currency": "USD", "opening_balance": 1500}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Account successfully created.\"}",
+		},
+		{
+			Name:         "when the name is empty",
+			Params:       `{"name": " ", "type": "bank"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the type is invalid",
+			Params:       `{"name": "Bank", "type": "crypto"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the currency is invalid",
+			Params:       `{"name": "Bank", "type": "bank", "currency": "DOLLAR"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			accountHandler.Create(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestAccountHandlerImpl_Update(t *testing.T) {
+	accountHandler := AccountHandlerInit(&MockAccountService{})
+	serviceUri := "/api/accounts"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the account is updated successfully",
+			Params:       `{"name": "Pocket", "type": "cash"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Account successfully updated.\"}",
+		},
+		{
+			Name:         "when the account is not found",
+			Params:       `{"name": "Pocket", "type": "cash"}`,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the type is missing",
+			Params:       `{"name": "Pocket"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			account_id := 1
+
+			if tt.Name == "when the account is not found" {
+				account_id = 2
+			}
+
+			ctx, responseRecorder := testhelpers.MockPutRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{
+				{
+					Key:   "id",
+					Value: strconv.Itoa(account_id),
+				},
+			}
+
+			accountHandler.Update(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestAccountHandlerImpl_Delete(t *testing.T) {
+	accountHandler := AccountHandlerInit(&MockAccountService{})
+	serviceUri := "/api/accounts"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the account is found",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Account successfully deleted.\"}",
+		},
+		{
+			Name:         "when the account is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockDeleteRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			accountHandler.Delete(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
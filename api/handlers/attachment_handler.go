@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/services"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxAttachmentRequestSize leaves room for the multipart envelope around the file.
+const maxAttachmentRequestSize int64 = services.MAX_ATTACHMENT_SIZE + 1<<20
+
+type AttachmentHandler interface {
+	Index(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Download(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type AttachmentHandlerImpl struct {
+	svc services.AttachmentService
+}
+
+func (u AttachmentHandlerImpl) Index(ctx *gin.Context) {
+	operationID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Index(ParseUserFromContext(ctx), operationID)
+	ctx.JSON(code, response)
+}
+
+func (u AttachmentHandlerImpl) Create(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxAttachmentRequestSize)
+	fileHeader, formError := ctx.FormFile("file")
+	var maxBytesError *http.MaxBytesError
+	if errors.As(formError, &maxBytesError) || (formError == nil && fileHeader.Size > services.MAX_ATTACHMENT_SIZE) {
+		ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "The file exceeds the maximum size."})
+		return
+	}
+	if formError != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	file, openError := fileHeader.Open()
+	if openError != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	defer file.Close()
+
+	operationID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Create(ParseUserFromContext(ctx), operationID, fileHeader.Filename, file)
+	ctx.JSON(code, response)
+}
+
+func (u AttachmentHandlerImpl) Download(ctx *gin.Context) {
+	operationID, _ := strconv.Atoi(ctx.Param("id"))
+	attachmentID, _ := strconv.Atoi(ctx.Param("attachment_id"))
+	code, response, content := u.svc.Download(ParseUserFromContext(ctx), operationID, attachmentID)
+	if content == nil {
+		ctx.JSON(code, response)
+		return
+	}
+	defer content.Close()
+
+	attachment := response.(dto.TransformedAttachment)
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})
+	ctx.DataFromReader(code, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition": disposition,
+	})
+}
+
+func (u AttachmentHandlerImpl) Delete(ctx *gin.Context) {
+	operationID, _ := strconv.Atoi(ctx.Param("id"))
+	attachmentID, _ := strconv.Atoi(ctx.Param("attachment_id"))
+	code, response := u.svc.Delete(ParseUserFromContext(ctx), operationID, attachmentID)
+	ctx.JSON(code, response)
+}
+
+func AttachmentHandlerInit(attachmentService services.AttachmentService) *AttachmentHandlerImpl {
+	return &AttachmentHandlerImpl{
+		svc: attachmentService,
+	}
+}
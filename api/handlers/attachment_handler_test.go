@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type MockAttachmentService struct{}
+
+func (m *MockAttachmentService) Index(user dao.User, operationID int) (int, interface{}) {
+	if operationID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, []dto.TransformedAttachment{
+		{ID: 1, FileName: "receipt.pdf", ContentType: "application/pdf", Size: 7},
+	}
+}
+
+func (m *MockAttachmentService) Create(user dao.User, operationID int, fileName string, file io.Reader) (int, interface{}) {
+	if operationID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusCreated, gin.H{"message": "Attachment successfully created."}
+}
+
+func (m *MockAttachmentService) Download(user dao.User, operationID int, attachmentID int) (int, interface{}, io.ReadCloser) {
+	if attachmentID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}, nil
+	}
+	attachment := dto.TransformedAttachment{ID: 1, FileName: "receipt.pdf", ContentType: "application/pdf", Size: 7}
+	return http.StatusOK, attachment, io.NopCloser(strings.NewReader("%PDF-1."))
+}
+
+func (m *MockAttachmentService) Delete(user dao.User, operationID int, attachmentID int) (int, interface{}) {
+	if attachmentID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Attachment successfully deleted."}
+}
+
+func TestAttachmentHandlerImpl_Index(t *testing.T) {
+	attachmentHandler := AttachmentHandlerInit(&MockAttachmentService{})
+	serviceUri := "/api/operations/1/attachments"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operation has attachments",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"file_name\":\"receipt.pdf\",\"content_type\":\"application/pdf\",\"size\":7}]",
+		},
+		{
+			Name:         "when the operation is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			attachmentHandler.Index(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestAttachmentHandlerImpl_Create(t *testing.T) {
+	attachmentHandler := AttachmentHandlerInit(&MockAttachmentService{})
+	serviceUri := "/api/operations/1/attachments"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the attachment is created successfully",
+			Params:       "%PDF-1.",
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Attachment successfully created.\"}",
+		},
+		{
+			Name:         "when the file is missing",
+			Params:       "",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the file exceeds the maximum size",
+			Params:       strings.Repeat("a", int(maxAttachmentRequestSize)),
+			ExpectedCode: http.StatusRequestEntityTooLarge,
+			ExpectedBody: "{\"error\":\"The file exceeds the maximum size.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			if tt.Params != "" {
+				part, _ := writer.CreateFormFile("file", "receipt.pdf")
+				part.Write([]byte(tt.Params))
+			}
+			writer.Close()
+
+			responseRecorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(responseRecorder)
+			ctx.Request = httptest.NewRequest("POST", serviceUri, body)
+			ctx.Request.Header.Set("Content-Type", writer.FormDataContentType())
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+
+			attachmentHandler.Create(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestAttachmentHandlerImpl_Download(t *testing.T) {
+	attachmentHandler := AttachmentHandlerInit(&MockAttachmentService{})
+	serviceUri := "/api/operations/1/attachments"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the attachment is found",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "%PDF-1.",
+		},
+		{
+			Name:         "when the attachment is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri + "/" + tt.Params)
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{{Key: "id", Value: "1"}, {Key: "attachment_id", Value: tt.Params}}
+
+			attachmentHandler.Download(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+			if tt.ExpectedCode == http.StatusOK {
+				assert.Equal(t, "application/pdf", responseRecorder.Header().Get("Content-Type"))
+				assert.Equal(t, "attachment; filename=receipt.pdf", responseRecorder.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
+
+func TestAttachmentHandlerImpl_Delete(t *testing.T) {
+	attachmentHandler := AttachmentHandlerInit(&MockAttachmentService{})
+	serviceUri := "/api/operations/1/attachments"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the attachment is deleted successfully",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Attachment successfully deleted.\"}",
+		},
+		{
+			Name:         "when the attachment is not found",
+			Params:       "",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			attachment_id := 1
+
+			if tt.Name == "when the attachment is not found" {
+				attachment_id = 2
+			}
+
+			ctx, responseRecorder := testhelpers.MockDeleteRequest(serviceUri)
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{{Key: "id", Value: "1"}, {Key: "attachment_id", Value: strconv.Itoa(attachment_id)}}
+
+			attachmentHandler.Delete(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
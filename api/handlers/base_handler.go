@@ -2,7 +2,11 @@ package handlers
 
 import (
 	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/patch"
+	"GoGin-API-CuentasClaras/services"
 	"net/http"
+	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,3 +20,53 @@ func ParseUserFromContext(ctx *gin.Context) dao.User {
 	}
 	return userStruct
 }
+
+func invalidCurrencyCode(code string) bool {
+	return !services.ValidCurrencyCode(strings.ToUpper(code))
+}
+
+// readPatch reads the body of a PATCH request and its content type, a JSON
+// Merge Patch by default or a JSON Patch. It aborts the request and returns
+// false when the body cannot be used.
+func readPatch(ctx *gin.Context) (string, []byte, bool) {
+	contentType := ctx.ContentType()
+	if contentType != patch.MergePatchContentType && contentType != patch.JSONPatchContentType && contentType != gin.MIMEJSON {
+		ctx.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{"error": "Unsupported content type."})
+		return "", nil, false
+	}
+	body, readError := ctx.GetRawData()
+	if readError != nil || len(body) == 0 {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return "", nil, false
+	}
+	return contentType, body, true
+}
+
+// respondWithETag writes a successful response with its ETag. A GET whose
+// If-None-Match header matches it gets 304 Not Modified without a body.
+func respondWithETag(ctx *gin.Context, code int, response interface{}) {
+	if code != http.StatusOK {
+		ctx.JSON(code, response)
+		return
+	}
+	etag := services.ETag(response)
+	ctx.Header("ETag", etag)
+	ifNoneMatch := ctx.GetHeader("If-None-Match")
+	if ctx.Request.Method == http.MethodGet && ifNoneMatch != "" && services.ETagMatches(ifNoneMatch, etag, true) {
+		ctx.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+	ctx.JSON(code, response)
+}
+
+// ifMatchHeader returns the If-Match header of a change. With REQUIRE_IF_MATCH
+// set to true a change without it is rejected, the request is aborted and
+// false returned.
+func ifMatchHeader(ctx *gin.Context) (string, bool) {
+	ifMatch := ctx.GetHeader("If-Match")
+	if ifMatch == "" && os.Getenv("REQUIRE_IF_MATCH") == "true" {
+		ctx.AbortWithStatusJSON(http.StatusPreconditionRequired, gin.H{"error": "The If-Match header is required."})
+		return "", false
+	}
+	return ifMatch, true
+}
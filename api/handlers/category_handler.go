@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/patch"
 	"GoGin-API-CuentasClaras/services"
 	"net/http"
 	"strconv"
@@ -13,9 +14,13 @@ var categoryCreateRequest dto.CategoryRequest
 
 type CategoryHandler interface {
 	Index(c *gin.Context)
+	Show(ctx *gin.Context)
 	Create(ctx *gin.Context)
 	Update(ctx *gin.Context)
+	Patch(ctx *gin.Context)
 	Delete(ctx *gin.Context)
+	History(ctx *gin.Context)
+	Revert(ctx *gin.Context)
 }
 
 type CategoryHandlerImpl struct {
@@ -24,12 +29,18 @@ type CategoryHandlerImpl struct {
 
 func (u CategoryHandlerImpl) Index(ctx *gin.Context) {
 	code, response := u.svc.Index(ParseUserFromContext(ctx))
-	ctx.JSON(code, response)
+	respondWithETag(ctx, code, response)
+}
+
+func (u CategoryHandlerImpl) Show(ctx *gin.Context) {
+	categoryID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Show(ParseUserFromContext(ctx), categoryID)
+	respondWithETag(ctx, code, response)
 }
 
 func (u CategoryHandlerImpl) Create(ctx *gin.Context) {
 	validationError := ctx.ShouldBindJSON(&categoryCreateRequest)
-	if validationError != nil || invalidName() || invalidColor() {
+	if validationError != nil || invalidName(categoryCreateRequest) || invalidColor(categoryCreateRequest) {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
 		return
 	}
@@ -40,31 +51,93 @@ func (u CategoryHandlerImpl) Create(ctx *gin.Context) {
 func (u CategoryHandlerImpl) Update(ctx *gin.Context) {
 	categoryID, _ := strconv.Atoi(ctx.Param("id"))
 	validationError := ctx.ShouldBindJSON(&categoryCreateRequest)
-	if validationError != nil || invalidName() || invalidColor() {
+	if validationError != nil || invalidName(categoryCreateRequest) || invalidColor(categoryCreateRequest) {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
 		return
 	}
-	code, response := u.svc.Update(ParseUserFromContext(ctx), categoryCreateRequest, categoryID)
+	ifMatch, valid := ifMatchHeader(ctx)
+	if !valid {
+		return
+	}
+	code, response := u.svc.Update(ParseUserFromContext(ctx), categoryCreateRequest, categoryID, ifMatch)
 	ctx.JSON(code, response)
 }
 
+func (u CategoryHandlerImpl) Patch(ctx *gin.Context) {
+	categoryID, _ := strconv.Atoi(ctx.Param("id"))
+	contentType, body, valid := readPatch(ctx)
+	if !valid {
+		return
+	}
+	ifMatch, valid := ifMatchHeader(ctx)
+	if !valid {
+		return
+	}
+	code, response := u.svc.Patch(ParseUserFromContext(ctx), categoryID, ifMatch, func(storedRequest dto.CategoryRequest) (dto.CategoryRequest, error) {
+		var categoryRequest dto.CategoryRequest
+		fields, patchError := patch.Apply(contentType, storedRequest, body, &categoryRequest)
+		if patchError != nil {
+			return categoryRequest, patchError
+		}
+		if invalidCategoryFields(categoryRequest, fields) || invalidName(categoryRequest) || invalidColor(categoryRequest) {
+			return categoryRequest, patch.ErrInvalidPatch
+		}
+		return categoryRequest, nil
+	})
+	respondWithETag(ctx, code, response)
+}
+
 func (u CategoryHandlerImpl) Delete(ctx *gin.Context) {
 	categoryID, _ := strconv.Atoi(ctx.Param("id"))
-	code, response := u.svc.Delete(ParseUserFromContext(ctx), categoryID)
+	ifMatch, valid := ifMatchHeader(ctx)
+	if !valid {
+		return
+	}
+	code, response := u.svc.Delete(ParseUserFromContext(ctx), categoryID, ifMatch)
+	ctx.JSON(code, response)
+}
+
+func (u CategoryHandlerImpl) History(ctx *gin.Context) {
+	categoryID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.History(ParseUserFromContext(ctx), categoryID)
+	ctx.JSON(code, response)
+}
+
+func (u CategoryHandlerImpl) Revert(ctx *gin.Context) {
+	categoryID, _ := strconv.Atoi(ctx.Param("id"))
+	entryID, _ := strconv.Atoi(ctx.Param("entry_id"))
+	code, response := u.svc.Revert(ParseUserFromContext(ctx), categoryID, entryID)
 	ctx.JSON(code, response)
 }
 
-func invalidName() bool {
-	return categoryCreateRequest.Name == ""
+func invalidName(categoryRequest dto.CategoryRequest) bool {
+	return categoryRequest.Name == ""
 }
 
-func invalidColor() bool {
-	if len(categoryCreateRequest.Color) != 7 || categoryCreateRequest.Color[0] != '#' {
+func invalidColor(categoryRequest dto.CategoryRequest) bool {
+	if len(categoryRequest.Color) != 7 || categoryRequest.Color[0] != '#' {
 		return true
 	}
 	return false
 }
 
+// invalidCategoryFields validates only the given fields of the request, the
+// ones a patch changes.
+func invalidCategoryFields(categoryRequest dto.CategoryRequest, fields []string) bool {
+	validators := map[string]func(dto.CategoryRequest) bool{
+		"name":        invalidName,
+		"color":       invalidColor,
+		"description": nil,
+	}
+	for _, field := range fields {
+		validator, found := validators[field]
+		if !found || (validator != nil && validator(categoryRequest)) {
+			return true
+		}
+	}
+	return false
+}
+
 func CategoryHandlerInit(categoryService services.CategoryService) *CategoryHandlerImpl {
 	return &CategoryHandlerImpl{
 		svc: categoryService,
@@ -3,10 +3,14 @@ package handlers
 import (
 	"GoGin-API-CuentasClaras/dao"
 	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/patch"
+	"GoGin-API-CuentasClaras/services"
 	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"errors"
 	"net/http"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -36,11 +40,22 @@ func (m *MockCategoryService) Create(user dao.User, categoryCreateRequest dto.Ca
 	return http.StatusCreated, gin.H{"message": "Category successfully created."}
 }
 
-func (m *MockCategoryService) Update(user dao.User, categoryRequest dto.CategoryRequest, categoryID int) (int, interface{}) {
+func (m *MockCategoryService) Show(user dao.User, categoryID int) (int, interface{}) {
+	if categoryID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, dto.TransformedIndexCategory{Id: categoryID, Name: "Work", Color: "#fdg123", Description: "Work", IsDefault: true}
+}
+
+func (m *MockCategoryService) Update(user dao.User, categoryRequest dto.CategoryRequest, categoryID int, ifMatch string) (int, interface{}) {
 	if categoryID == 2 {
 		return http.StatusNotFound, gin.H{"error": "Not found."}
 	}
 
+	if ifMatch == `"stale"` {
+		return http.StatusPreconditionFailed, gin.H{"error": "The category has been modified."}
+	}
+
 	if categoryID == 3 {
 		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the category."}
 	}
@@ -48,7 +63,26 @@ func (m *MockCategoryService) Update(user dao.User, categoryRequest dto.Category
 	return http.StatusOK, gin.H{"message": "Category successfully updated."}
 }
 
-func (m *MockCategoryService) Delete(user dao.User, categoryID int) (int, interface{}) {
+func (m *MockCategoryService) Patch(user dao.User, categoryID int, ifMatch string, applyPatch func(categoryRequest dto.CategoryRequest) (dto.CategoryRequest, error)) (int, interface{}) {
+	if categoryID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	categoryRequest, patchError := applyPatch(dto.CategoryRequest{Name: "Custom", Color: "#6495ed", Description: "Custom"})
+	if errors.Is(patchError, patch.ErrTestFailed) {
+		return http.StatusConflict, gin.H{"error": "The patch test failed."}
+	}
+	if patchError != nil {
+		return http.StatusBadRequest, gin.H{"error": "Invalid parameters."}
+	}
+	return http.StatusOK, dto.TransformedIndexCategory{
+		Id:          categoryID,
+		Name:        categoryRequest.Name,
+		Color:       categoryRequest.Color,
+		Description: categoryRequest.Description,
+	}
+}
+
+func (m *MockCategoryService) Delete(user dao.User, categoryID int, ifMatch string) (int, interface{}) {
 	if categoryID == 2 {
 		return http.StatusNotFound, gin.H{"error": "Not found."}
 	}
@@ -60,6 +94,22 @@ func (m *MockCategoryService) Delete(user dao.User, categoryID int) (int, interf
 	return http.StatusOK, gin.H{"message": "Category successfully deleted."}
 }
 
+func (m *MockCategoryService) History(user dao.User, categoryID int) (int, interface{}) {
+	if categoryID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, []dto.TransformedAuditEntry{
+		{ID: 1, Action: "update", ActorID: 1, Actor: "pedro.fuentes", CreatedAt: time.Date(2023, 10, 24, 10, 0, 0, 0, time.UTC), Changes: []dto.AuditChange{{Field: "name", Before: "Work", After: "Job"}}},
+	}
+}
+
+func (m *MockCategoryService) Revert(user dao.User, categoryID int, entryID int) (int, interface{}) {
+	if categoryID == 2 || entryID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Category successfully reverted."}
+}
+
 func TestCategoryHandlerImpl_Index(t *testing.T) {
 	categoryService := &MockCategoryService{}
 	categoryHandler := CategoryHandlerInit(categoryService)
@@ -72,6 +122,12 @@ func TestCategoryHandlerImpl_Index(t *testing.T) {
 			ExpectedCode: http.StatusOK,
 			ExpectedBody: "[{\"id\":1,\"name\":\"Work\",\"color\":\"#fdg123\",\"description\":\"Work\",\"is_default\":true}]",
 		},
+		{
+			Name:         "when the categories did not change since the ETag",
+			Params:       "",
+			ExpectedCode: http.StatusNotModified,
+			ExpectedBody: "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -79,6 +135,11 @@ func TestCategoryHandlerImpl_Index(t *testing.T) {
 
 			ctx.Set("user", dao.User{ID: 1})
 
+			if tt.Name == "when the categories did not change since the ETag" {
+				_, categories := categoryService.Index(dao.User{ID: 1})
+				ctx.Request.Header.Set("If-None-Match", services.ETag(categories))
+			}
+
 			categoryHandler.Index(ctx)
 
 			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
@@ -86,6 +147,47 @@ func TestCategoryHandlerImpl_Index(t *testing.T) {
 	}
 }
 
+func TestCategoryHandlerImpl_Show(t *testing.T) {
+	categoryHandler := CategoryHandlerInit(&MockCategoryService{})
+	serviceUri := "/api/categories/1"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the category is found",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"name\":\"Work\",\"color\":\"#fdg123\",\"description\":\"Work\",\"is_default\":true}",
+		},
+		{
+			Name:         "when the category is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{
+				{
+					Key:   "id",
+					Value: tt.Params,
+				},
+			}
+
+			categoryHandler.Show(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+			if tt.ExpectedCode == http.StatusOK && responseRecorder.Header().Get("ETag") == "" {
+				t.Errorf("expected an ETag header")
+			}
+		})
+	}
+}
+
 func TestCategoryHandlerImpl_Create(t *testing.T) {
 	categoryService := &MockCategoryService{}
 	categoryHandler := CategoryHandlerInit(categoryService)
@@ -166,6 +268,18 @@ func TestCategoryHandlerImpl_Update(t *testing.T) {
 			ExpectedCode: http.StatusUnprocessableEntity,
 			ExpectedBody: "{\"error\":\"An error occurred in the update of the category.\"}",
 		},
+		{
+			Name:         "when the category was modified since the ETag",
+			Params:       `{"name": "Custom", "color": "#6495ed", "description": "Custom"}`,
+			ExpectedCode: http.StatusPreconditionFailed,
+			ExpectedBody: "{\"error\":\"The category has been modified.\"}",
+		},
+		{
+			Name:         "when the If-Match header is required and missing",
+			Params:       `{"name": "Custom", "color": "#6495ed", "description": "Custom"}`,
+			ExpectedCode: http.StatusPreconditionRequired,
+			ExpectedBody: "{\"error\":\"The If-Match header is required.\"}",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -181,6 +295,12 @@ func TestCategoryHandlerImpl_Update(t *testing.T) {
 
 			ctx.Set("user", dao.User{ID: 1})
 
+			if tt.Name == "when the category was modified since the ETag" {
+				ctx.Request.Header.Set("If-Match", `"stale"`)
+			} else if tt.Name == "when the If-Match header is required and missing" {
+				t.Setenv("REQUIRE_IF_MATCH", "true")
+			}
+
 			ctx.Params = []gin.Param{
 				{
 					Key:   "id",
@@ -195,6 +315,84 @@ func TestCategoryHandlerImpl_Update(t *testing.T) {
 	}
 }
 
+func TestCategoryHandlerImpl_Patch(t *testing.T) {
+	categoryService := &MockCategoryService{}
+	categoryHandler := CategoryHandlerInit(categoryService)
+	serviceUri := "/api/categories/1"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the category is patched with a merge patch",
+			Params:       `{"name": "Job"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"name\":\"Job\",\"color\":\"#6495ed\",\"description\":\"Custom\",\"is_default\":false}",
+		},
+		{
+			Name:         "when the category is patched with a JSON patch",
+			Params:       `[{"op": "replace", "path": "/color", "value": "#000000"}, {"op": "remove", "path": "/description"}]`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"name\":\"Custom\",\"color\":\"#000000\",\"description\":\"\",\"is_default\":false}",
+		},
+		{
+			Name:         "when the patch sets an invalid color",
+			Params:       `{"color": "193zge"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the patch replaces the whole category",
+			Params:       `[{"op": "replace", "path": "", "value": {"name": "", "color": "red"}}]`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the patch is not an object",
+			Params:       `"Job"`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the patch test fails",
+			Params:       `[{"op": "test", "path": "/name", "value": "Work"}]`,
+			ExpectedCode: http.StatusConflict,
+			ExpectedBody: "{\"error\":\"The patch test failed.\"}",
+		},
+		{
+			Name:         "when the category is not found",
+			Params:       `{"name": "Job"}`,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			categoryId := 1
+			contentType := patch.MergePatchContentType
+
+			if tt.Name == "when the category is not found" {
+				categoryId = 2
+			} else if tt.Params[0] == '[' {
+				contentType = patch.JSONPatchContentType
+			}
+
+			ctx, responseRecorder := testhelpers.MockPatchRequest(tt.Params, contentType, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{
+				{
+					Key:   "id",
+					Value: strconv.Itoa(categoryId),
+				},
+			}
+
+			categoryHandler.Patch(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
 func TestCategoryHandlerImpl_Delete(t *testing.T) {
 	categoryService := &MockCategoryService{}
 	categoryHandler := CategoryHandlerInit(categoryService)
@@ -247,3 +445,67 @@ func TestCategoryHandlerImpl_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestCategoryHandlerImpl_History(t *testing.T) {
+	categoryHandler := CategoryHandlerInit(&MockCategoryService{})
+	serviceUri := "/api/categories/1/history"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the category has changes",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"action\":\"update\",\"actor_id\":1,\"actor\":\"pedro.fuentes\",\"created_at\":\"2023-10-24T10:00:00Z\",\"changes\":[{\"field\":\"name\",\"before\":\"Work\",\"after\":\"Job\"}]}]",
+		},
+		{
+			Name:         "when the category has no history",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			categoryHandler.History(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestCategoryHandlerImpl_Revert(t *testing.T) {
+	categoryHandler := CategoryHandlerInit(&MockCategoryService{})
+	serviceUri := "/api/categories/1/history/1/revert"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the category is reverted",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Category successfully reverted.\"}",
+		},
+		{
+			Name:         "when the change is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest("", serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{{Key: "id", Value: "1"}, {Key: "entry_id", Value: tt.Params}}
+
+			categoryHandler.Revert(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
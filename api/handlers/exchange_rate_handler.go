@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/services"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExchangeRateHandler interface {
+	Index(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Import(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type ExchangeRateHandlerImpl struct {
+	svc services.ExchangeRateService
+}
+
+func (u ExchangeRateHandlerImpl) Index(ctx *gin.Context) {
+	code, response := u.svc.Index(ParseUserFromContext(ctx))
+	ctx.JSON(code, response)
+}
+
+func (u ExchangeRateHandlerImpl) Create(ctx *gin.Context) {
+	var exchangeRateRequest dto.ExchangeRateRequest
+	validationError := ctx.ShouldBindJSON(&exchangeRateRequest)
+	if validationError != nil || invalidExchangeRate(exchangeRateRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Create(ParseUserFromContext(ctx), exchangeRateRequest)
+	ctx.JSON(code, response)
+}
+
+func (u ExchangeRateHandlerImpl) Import(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportRequestSize)
+	fileHeader, formError := ctx.FormFile("file")
+	var maxBytesError *http.MaxBytesError
+	if errors.As(formError, &maxBytesError) || (formError == nil && fileHeader.Size > services.MAX_IMPORT_SIZE) {
+		ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "The file exceeds the maximum size."})
+		return
+	}
+	if formError != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	file, openError := fileHeader.Open()
+	if openError != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	defer file.Close()
+
+	code, response := u.svc.Import(ParseUserFromContext(ctx), file)
+	ctx.JSON(code, response)
+}
+
+func (u ExchangeRateHandlerImpl) Delete(ctx *gin.Context) {
+	exchangeRateID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Delete(ParseUserFromContext(ctx), exchangeRateID)
+	ctx.JSON(code, response)
+}
+
+func invalidExchangeRate(request dto.ExchangeRateRequest) bool {
+	if invalidCurrencyCode(request.FromCurrency) || invalidCurrencyCode(request.ToCurrency) {
+		return true
+	}
+	if strings.EqualFold(request.FromCurrency, request.ToCurrency) || request.Rate <= 0.0 {
+		return true
+	}
+	_, err := time.Parse("2006-01-02", request.Date)
+	return err != nil
+}
+
+func ExchangeRateHandlerInit(exchangeRateService services.ExchangeRateService) *ExchangeRateHandlerImpl {
+	return &ExchangeRateHandlerImpl{
+		svc: exchangeRateService,
+	}
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockExchangeRateService struct{}
+
+func (m *MockExchangeRateService) Index(user dao.User) (int, []dto.TransformedExchangeRate) {
+	return http.StatusOK, []dto.TransformedExchangeRate{
+		{ID: 1, FromCurrency: "USD", ToCurrency: "ARS", Date: "2023-10-24", Rate: 350},
+	}
+}
+
+func (m *MockExchangeRateService) Create(user dao.User, exchangeRateRequest dto.ExchangeRateRequest) (int, interface{}) {
+	return http.StatusCreated, gin.H{"message": "Exchange rate successfully created."}
+}
+
+func (m *MockExchangeRateService) Import(user dao.User, file io.Reader) (int, interface{}) {
+	content, _ := io.ReadAll(file)
+	if len(content) == 0 {
+		return http.StatusBadRequest, gin.H{"error": "Invalid CSV file."}
+	}
+	return http.StatusCreated, gin.H{"message": "Exchange rates successfully imported.", "imported": 1}
+}
+
+func (m *MockExchangeRateService) Delete(user dao.User, exchangeRateID int) (int, interface{}) {
+	if exchangeRateID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Exchange rate successfully deleted."}
+}
+
+func TestExchangeRateHandlerImpl_Index(t *testing.T) {
+	exchangeRateHandler := ExchangeRateHandlerInit(&MockExchangeRateService{})
+	serviceUri := "/api/exchange_rates"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the user has exchange rates",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"from_currency\":\"USD\",\"to_currency\":\"ARS\",\"date\":\"2023-10-24\",\"rate\":350}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+			ctx.Set("user", dao.User{ID: 1})
+
+			exchangeRateHandler.Index(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestExchangeRateHandlerImpl_Create(t *testing.T) {
+	exchangeRateHandler := ExchangeRateHandlerInit(&MockExchangeRateService{})
+	serviceUri := "/api/exchange_rates"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the exchange rate is created successfully",
+			Params:       `{"from_currency": "USD", "to_currency": "ARS", "date": "2023-10-24", "rate": 350}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Exchange rate successfully created.\"}",
+		},
+		{
+			Name:         "when the currencies are the same",
+			Params:       `{"from_currency": "USD", "to_currency": "usd", "date": "2023-10-24", "rate": 1}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the rate is invalid",
+			Params:       `{"from_currency": "USD", "to_currency": "ARS", "date": "2023-10-24", "rate": 0}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the date is invalid",
+			Params:       `{"from_currency": "USD", "to_currency": "ARS", "date": "24/10/2023", "rate": 350}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest(tt.Params, serviceUri)
+			ctx.Set("user", dao.User{ID: 1})
+
+			exchangeRateHandler.Create(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestExchangeRateHandlerImpl_Import(t *testing.T) {
+	exchangeRateHandler := ExchangeRateHandlerInit(&MockExchangeRateService{})
+	serviceUri := "/api/exchange_rates/import"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the file is imported successfully",
+			Params:       "date,from_currency,to_currency,rate\n2023-10-24,USD,ARS,350\n",
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"imported\":1,\"message\":\"Exchange rates successfully imported.\"}",
+		},
+		{
+			Name:         "when the file is missing",
+			Params:       "",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the file exceeds the maximum size",
+			Params:       strings.Repeat("2023-10-24,USD,ARS,350\n", int(maxImportRequestSize)/23),
+			ExpectedCode: http.StatusRequestEntityTooLarge,
+			ExpectedBody: "{\"error\":\"The file exceeds the maximum size.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			if tt.Params != "" {
+				part, _ := writer.CreateFormFile("file", "rates.csv")
+				part.Write([]byte(tt.Params))
+			}
+			writer.Close()
+
+			responseRecorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(responseRecorder)
+			ctx.Request = httptest.NewRequest("POST", serviceUri, body)
+			ctx.Request.Header.Set("Content-Type", writer.FormDataContentType())
+			ctx.Set("user", dao.User{ID: 1})
+
+			exchangeRateHandler.Import(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestExchangeRateHandlerImpl_Delete(t *testing.T) {
+	exchangeRateHandler := ExchangeRateHandlerInit(&MockExchangeRateService{})
+	serviceUri := "/api/exchange_rates"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the exchange rate is found",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Exchange rate successfully deleted.\"}",
+		},
+		{
+			Name:         "when the exchange rate is not found",
+			Params:       "",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			exchange_rate_id := 1
+
+			if tt.Name == "when the exchange rate is not found" {
+				exchange_rate_id = 2
+			}
+
+			ctx, responseRecorder := testhelpers.MockDeleteRequest(serviceUri)
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{
+				{
+					Key:   "id",
+					Value: strconv.Itoa(exchange_rate_id),
+				},
+			}
+
+			exchangeRateHandler.Delete(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
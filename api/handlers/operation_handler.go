@@ -2,22 +2,41 @@ package handlers
 
 import (
 	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"GoGin-API-CuentasClaras/patch"
 	"GoGin-API-CuentasClaras/services"
+	"errors"
+	"mime"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 )
 
-var operationRequest dto.OperationRequest
+// maxImportRequestSize leaves room for the multipart envelope and the mapping
+// fields around the file.
+const maxImportRequestSize int64 = services.MAX_IMPORT_SIZE + 1<<20
 
 type OperationHandler interface {
 	Index(c *gin.Context)
+	Search(ctx *gin.Context)
 	Show(c *gin.Context)
 	Create(c *gin.Context)
 	Update(ctx *gin.Context)
+	Patch(ctx *gin.Context)
 	Delete(ctx *gin.Context)
+	Import(ctx *gin.Context)
+	Export(ctx *gin.Context)
+	Batch(ctx *gin.Context)
+	History(ctx *gin.Context)
+	Revert(ctx *gin.Context)
+	ApplyRules(ctx *gin.Context)
+	Duplicates(ctx *gin.Context)
+	Merge(ctx *gin.Context)
 }
 
 type OperationHandlerImpl struct {
@@ -25,19 +44,41 @@ type OperationHandlerImpl struct {
 }
 
 func (u OperationHandlerImpl) Index(ctx *gin.Context) {
-	code, response := u.svc.Index(ParseUserFromContext(ctx))
+	var operationFilter dto.OperationFilter
+	validationError := ctx.ShouldBindQuery(&operationFilter)
+	if validationError == nil {
+		validationError = bindAmountFilter(ctx, &operationFilter)
+	}
+	operationFilter.Tags = splitFilterTags(operationFilter.Tags)
+	if validationError != nil || invalidOperationFilter(operationFilter) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Index(ParseUserFromContext(ctx), operationFilter)
+	respondWithETag(ctx, code, response)
+}
+
+func (u OperationHandlerImpl) Search(ctx *gin.Context) {
+	var searchRequest dto.OperationSearchRequest
+	validationError := ctx.ShouldBindQuery(&searchRequest)
+	if validationError != nil || invalidOperationSearch(searchRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Search(ParseUserFromContext(ctx), searchRequest)
 	ctx.JSON(code, response)
 }
 
 func (u OperationHandlerImpl) Show(ctx *gin.Context) {
 	operationID, _ := strconv.Atoi(ctx.Param("id"))
 	code, response := u.svc.Show(ParseUserFromContext(ctx), operationID)
-	ctx.JSON(code, response)
+	respondWithETag(ctx, code, response)
 }
 
 func (u OperationHandlerImpl) Create(ctx *gin.Context) {
+	var operationRequest dto.OperationRequest
 	validationError := ctx.ShouldBindJSON(&operationRequest)
-	if validationError != nil || invalidType() || invalidAmount() || invalidDate() {
+	if validationError != nil || invalidOperationRequest(operationRequest) {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
 		return
 	}
@@ -47,33 +88,291 @@ func (u OperationHandlerImpl) Create(ctx *gin.Context) {
 
 func (u OperationHandlerImpl) Update(ctx *gin.Context) {
 	operationID, _ := strconv.Atoi(ctx.Param("id"))
+	var operationRequest dto.OperationRequest
 	validationError := ctx.ShouldBindJSON(&operationRequest)
-	if validationError != nil || invalidType() || invalidAmount() || invalidDate() {
+	if validationError != nil || invalidOperationRequest(operationRequest) {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
 		return
 	}
-	code, response := u.svc.Update(ParseUserFromContext(ctx), operationRequest, operationID)
+	ifMatch, valid := ifMatchHeader(ctx)
+	if !valid {
+		return
+	}
+	code, response := u.svc.Update(ParseUserFromContext(ctx), operationRequest, operationID, ifMatch)
 	ctx.JSON(code, response)
 }
 
+func (u OperationHandlerImpl) Patch(ctx *gin.Context) {
+	operationID, _ := strconv.Atoi(ctx.Param("id"))
+	contentType, body, valid := readPatch(ctx)
+	if !valid {
+		return
+	}
+	ifMatch, valid := ifMatchHeader(ctx)
+	if !valid {
+		return
+	}
+	code, response := u.svc.Patch(ParseUserFromContext(ctx), operationID, ifMatch, func(storedRequest dto.OperationRequest) (dto.OperationRequest, error) {
+		var operationRequest dto.OperationRequest
+		fields, patchError := patch.Apply(contentType, storedRequest, body, &operationRequest)
+		if patchError != nil {
+			return operationRequest, patchError
+		}
+		if invalidOperationFields(operationRequest, fields) || invalidOperationRequest(operationRequest) {
+			return operationRequest, patch.ErrInvalidPatch
+		}
+		return operationRequest, nil
+	})
+	respondWithETag(ctx, code, response)
+}
+
 func (u OperationHandlerImpl) Delete(ctx *gin.Context) {
 	operationID, _ := strconv.Atoi(ctx.Param("id"))
-	code, response := u.svc.Delete(ParseUserFromContext(ctx), operationID)
+	ifMatch, valid := ifMatchHeader(ctx)
+	if !valid {
+		return
+	}
+	code, response := u.svc.Delete(ParseUserFromContext(ctx), operationID, ifMatch)
+	ctx.JSON(code, response)
+}
+
+func (u OperationHandlerImpl) History(ctx *gin.Context) {
+	operationID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.History(ParseUserFromContext(ctx), operationID)
+	ctx.JSON(code, response)
+}
+
+func (u OperationHandlerImpl) Revert(ctx *gin.Context) {
+	operationID, _ := strconv.Atoi(ctx.Param("id"))
+	entryID, _ := strconv.Atoi(ctx.Param("entry_id"))
+	code, response := u.svc.Revert(ParseUserFromContext(ctx), operationID, entryID)
+	ctx.JSON(code, response)
+}
+
+func (u OperationHandlerImpl) ApplyRules(ctx *gin.Context) {
+	var applyRequest dto.RuleApplyRequest
+	if validationError := ctx.ShouldBindQuery(&applyRequest); validationError != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.ApplyRules(ParseUserFromContext(ctx), applyRequest)
+	ctx.JSON(code, response)
+}
+
+func (u OperationHandlerImpl) Duplicates(ctx *gin.Context) {
+	var duplicateFilter dto.OperationDuplicateFilter
+	validationError := ctx.ShouldBindQuery(&duplicateFilter)
+	if validationError != nil || duplicateFilter.Days < 0 || duplicateFilter.Days > services.MAX_DUPLICATE_DAYS {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Duplicates(ParseUserFromContext(ctx), duplicateFilter)
+	ctx.JSON(code, response)
+}
+
+func (u OperationHandlerImpl) Merge(ctx *gin.Context) {
+	operationID, _ := strconv.Atoi(ctx.Param("id"))
+	var mergeRequest dto.OperationMergeRequest
+	validationError := ctx.ShouldBindJSON(&mergeRequest)
+	duplicateID, conversionError := strconv.Atoi(mergeRequest.DuplicateID)
+	if validationError != nil || conversionError != nil || duplicateID == operationID {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Merge(ParseUserFromContext(ctx), operationID, mergeRequest)
+	ctx.JSON(code, response)
+}
+
+func (u OperationHandlerImpl) Import(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportRequestSize)
+	var importRequest dto.OperationImportRequest
+	validationError := ctx.ShouldBind(&importRequest)
+	fileHeader, formError := ctx.FormFile("file")
+	var maxBytesError *http.MaxBytesError
+	if errors.As(validationError, &maxBytesError) || errors.As(formError, &maxBytesError) || (formError == nil && fileHeader.Size > services.MAX_IMPORT_SIZE) {
+		ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "The file exceeds the maximum size."})
+		return
+	}
+	if validationError != nil || formError != nil || invalidOperationImport(importRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	file, openError := fileHeader.Open()
+	if openError != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	defer file.Close()
+	if importRequest.Format == "" {
+		importRequest.Format = importFormatFromFileName(fileHeader.Filename)
+	}
+
+	code, response := u.svc.Import(ParseUserFromContext(ctx), importRequest, file)
+	ctx.JSON(code, response)
+}
+
+// Export streams the filtered operations as a file. The headers are sent with
+// the first bytes of the file, so a failure before them is answered with JSON.
+func (u OperationHandlerImpl) Export(ctx *gin.Context) {
+	var exportRequest dto.OperationExportRequest
+	validationError := ctx.ShouldBindQuery(&exportRequest)
+	if validationError == nil {
+		validationError = bindAmountFilter(ctx, &exportRequest.OperationFilter)
+	}
+	exportRequest.Tags = splitFilterTags(exportRequest.Tags)
+	if validationError != nil || invalidOperationFilter(exportRequest.OperationFilter) || invalidOperationExport(exportRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	if exportRequest.Format == "" {
+		exportRequest.Format = dto.ExportFormatCSV
+	}
+
+	writer := &exportResponseWriter{ctx: ctx, format: exportRequest.Format}
+	err := u.svc.Export(ParseUserFromContext(ctx), exportRequest, writer)
+	if err != nil && !writer.started {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the export of the operations."})
+	} else if err != nil {
+		log.Error("Operations export interrupted. Error: ", err)
+	}
+}
+
+var exportContentTypes = map[string]string{
+	dto.ExportFormatCSV:       "text/csv; charset=utf-8",
+	dto.ExportFormatJSONLines: "application/x-ndjson",
+	dto.ExportFormatOFX:       "application/x-ofx",
+}
+
+type exportResponseWriter struct {
+	ctx     *gin.Context
+	format  string
+	started bool
+}
+
+func (w *exportResponseWriter) Write(data []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.ctx.Header("Content-Type", exportContentTypes[w.format])
+		w.ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "operations." + w.format}))
+		w.ctx.Status(http.StatusOK)
+	}
+	return w.ctx.Writer.Write(data)
+}
+
+// Batch validates every action on its own, the invalid ones get their result
+// with the rest instead of failing the whole request.
+func (u OperationHandlerImpl) Batch(ctx *gin.Context) {
+	var batchRequest dto.OperationBatchRequest
+	validationError := ctx.ShouldBindJSON(&batchRequest)
+	if validationError != nil || invalidOperationBatch(batchRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	for index, action := range batchRequest.Actions {
+		batchRequest.Actions[index].Invalid = invalidOperationBatchAction(action)
+	}
+	code, response := u.svc.Batch(ParseUserFromContext(ctx), batchRequest)
 	ctx.JSON(code, response)
 }
 
-func invalidType() bool {
+func invalidOperationRequest(operationRequest dto.OperationRequest) bool {
+	return invalidType(operationRequest) || invalidAmount(operationRequest) || invalidCurrency(operationRequest) ||
+		invalidDate(operationRequest) || invalidSplits(operationRequest) || invalidTags(operationRequest) ||
+		invalidStatus(operationRequest)
+}
+
+// invalidOperationFields validates only the given fields of the request, the
+// ones a patch changes.
+func invalidOperationFields(operationRequest dto.OperationRequest, fields []string) bool {
+	validators := map[string]func(dto.OperationRequest) bool{
+		"type":        invalidType,
+		"amount":      invalidAmount,
+		"currency":    invalidCurrency,
+		"date":        invalidDate,
+		"description": nil,
+		"category_id": nil,
+		"account_id":  nil,
+		"payee_id":    nil,
+		"splits":      invalidSplits,
+		"tags":        invalidTags,
+		"status":      invalidStatus,
+	}
+	for _, field := range fields {
+		validator, found := validators[field]
+		if !found || (validator != nil && validator(operationRequest)) {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidOperationBatch(batchRequest dto.OperationBatchRequest) bool {
+	if batchRequest.Mode != "" && batchRequest.Mode != dto.BatchModeAllOrNothing && batchRequest.Mode != dto.BatchModeBestEffort {
+		return true
+	}
+	return len(batchRequest.Actions) == 0 || len(batchRequest.Actions) > services.MAX_BATCH_ACTIONS
+}
+
+func invalidOperationBatchAction(action dto.OperationBatchAction) bool {
+	switch action.Action {
+	case dto.BatchActionCreate:
+		return invalidOperationRequest(action.Operation)
+	case dto.BatchActionUpdate:
+		return action.ID <= 0 || invalidOperationRequest(action.Operation)
+	case dto.BatchActionDelete:
+		return action.ID <= 0
+	}
+	return true
+}
+
+func invalidType(operationRequest dto.OperationRequest) bool {
 	if operationRequest.Type == "" {
 		return true
 	}
 	return operationRequest.Type != "income" && operationRequest.Type != "expense"
 }
 
-func invalidAmount() bool {
-	return operationRequest.Amount <= 0.0
+func invalidAmount(operationRequest dto.OperationRequest) bool {
+	return operationRequest.Amount <= 0
 }
 
-func invalidDate() bool {
+func invalidSplits(operationRequest dto.OperationRequest) bool {
+	for _, split := range operationRequest.Splits {
+		if split.Amount <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidStatus rejects the reconciled status, which only a finalized
+// reconciliation sets.
+func invalidStatus(operationRequest dto.OperationRequest) bool {
+	switch operationRequest.Status {
+	case "", dto.OperationStatusPending, dto.OperationStatusCleared:
+		return false
+	}
+	return true
+}
+
+func invalidTags(operationRequest dto.OperationRequest) bool {
+	if len(operationRequest.Tags) > services.MAX_TAGS {
+		return true
+	}
+	for _, tag := range operationRequest.Tags {
+		name := strings.TrimSpace(tag)
+		if name == "" || len(name) > services.MAX_TAG_LENGTH || strings.Contains(name, ",") {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidCurrency(operationRequest dto.OperationRequest) bool {
+	return operationRequest.Currency != "" && invalidCurrencyCode(operationRequest.Currency)
+}
+
+func invalidDate(operationRequest dto.OperationRequest) bool {
 	if operationRequest.Date == "" {
 		return true
 	}
@@ -81,6 +380,116 @@ func invalidDate() bool {
 	return err != nil || parsedDate.After(time.Now())
 }
 
+// bindAmountFilter reads min_amount and max_amount as money. The query binding
+// would read them as plain integers, refusing amounts with cents.
+func bindAmountFilter(ctx *gin.Context, filter *dto.OperationFilter) error {
+	for param, target := range map[string]**money.Amount{"min_amount": &filter.MinAmount, "max_amount": &filter.MaxAmount} {
+		value, ok := ctx.GetQuery(param)
+		if !ok {
+			continue
+		}
+		var amount money.Amount
+		if err := amount.UnmarshalParam(value); err != nil {
+			return err
+		}
+		*target = &amount
+	}
+	return nil
+}
+
+func invalidOperationFilter(filter dto.OperationFilter) bool {
+	if filter.Type != "" && filter.Type != "income" && filter.Type != "expense" && filter.Type != dto.TransferType {
+		return true
+	}
+	switch filter.Sort {
+	case "", dto.SortDateDesc, dto.SortDateAsc, dto.SortAmountDesc, dto.SortAmountAsc:
+	default:
+		return true
+	}
+	if filter.TagMode != "" && filter.TagMode != dto.TagModeAny && filter.TagMode != dto.TagModeAll {
+		return true
+	}
+	switch filter.Status {
+	case "", dto.OperationStatusPending, dto.OperationStatusCleared, dto.OperationStatusReconciled:
+	default:
+		return true
+	}
+	if filter.Limit < 0 {
+		return true
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return true
+	}
+	return filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount
+}
+
+const maxSearchQueryLength int = 200
+
+func invalidOperationSearch(request dto.OperationSearchRequest) bool {
+	query := strings.TrimSpace(request.Query)
+	return query == "" || len(query) > maxSearchQueryLength || request.Limit < 0
+}
+
+func invalidOperationImport(request dto.OperationImportRequest) bool {
+	switch request.Format {
+	case "", dto.ImportFormatCSV, dto.ImportFormatOFX, dto.ImportFormatQIF:
+	default:
+		return true
+	}
+	if _, found := services.ImportDateFormats[request.DateFormat]; request.DateFormat != "" && !found {
+		return true
+	}
+	if request.AmountSign != "" && request.AmountSign != dto.AmountSignNegativeExpense && request.AmountSign != dto.AmountSignPositiveExpense {
+		return true
+	}
+	if request.DecimalSeparator != "" && request.DecimalSeparator != "." && request.DecimalSeparator != "," {
+		return true
+	}
+	switch request.Delimiter {
+	case "", ",", ";", "\t", "|":
+	default:
+		return true
+	}
+	return false
+}
+
+func invalidOperationExport(request dto.OperationExportRequest) bool {
+	if _, found := exportContentTypes[request.Format]; request.Format != "" && !found {
+		return true
+	}
+	if request.DecimalSeparator != "" && request.DecimalSeparator != "." && request.DecimalSeparator != "," {
+		return true
+	}
+	switch request.Delimiter {
+	case "", ",", ";", "\t", "|":
+	default:
+		return true
+	}
+	return request.Delimiter != "" && request.Delimiter == request.DecimalSeparator
+}
+
+// importFormatFromFileName picks the format of an imported file from its
+// extension when the request does not name it, defaulting to CSV.
+func importFormatFromFileName(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".ofx", ".qfx":
+		return dto.ImportFormatOFX
+	case ".qif":
+		return dto.ImportFormatQIF
+	}
+	return dto.ImportFormatCSV
+}
+
+// splitFilterTags accepts the tags of the filter either repeated or separated
+// by commas.
+func splitFilterTags(tags []string) []string {
+	splitTags := []string{}
+	for _, tag := range tags {
+		splitTags = append(splitTags, strings.Split(tag, ",")...)
+	}
+	return services.NormalizeTags(splitTags)
+}
+
 func OperationHandlerInit(operationService services.OperationService) *OperationHandlerImpl {
 	return &OperationHandlerImpl{
 		svc: operationService,
@@ -3,38 +3,111 @@ package handlers
 import (
 	"GoGin-API-CuentasClaras/dao"
 	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"GoGin-API-CuentasClaras/patch"
+	"GoGin-API-CuentasClaras/services"
 	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
 )
 
 type MockOperationService struct{}
 
-func (m *MockOperationService) Index(user dao.User) (int, []dto.TransformedOperation) {
+func (m *MockOperationService) Index(user dao.User, operationFilter dto.OperationFilter) (int, interface{}) {
 	date, _ := time.Parse(time.RFC3339, "2023-10-23T21:33:03.73297-03:00")
 
 	transformedResponse := []dto.TransformedOperation{}
 	transformed := dto.TransformedOperation{
-		ID:     1,
-		Type:   "income",
-		Amount: 1200.5,
-		Date:   date,
+		ID:       1,
+		Type:     "income",
+		Amount:   money.FromFloat(1200.5),
+		Currency: "ARS",
+		Date:     date,
 		Category: dto.TransformedCategory{
 			Name:  "Work",
 			Color: "#fdg123",
 		},
+		Tags: []string{"reimbursable"},
 	}
 
 	transformedResponse = append(transformedResponse, transformed)
 
+	if operationFilter.Cursor == "invalid" {
+		return http.StatusBadRequest, gin.H{"error": "Invalid cursor."}
+	}
+
 	if user.ID == 1 {
-		return http.StatusOK, transformedResponse
+		return http.StatusOK, dto.PaginatedOperations{Operations: transformedResponse, NextCursor: "next"}
 	} else {
-		return http.StatusOK, []dto.TransformedOperation{}
+		return http.StatusOK, dto.PaginatedOperations{Operations: []dto.TransformedOperation{}}
+	}
+}
+
+func (m *MockOperationService) ApplyRules(user dao.User, applyRequest dto.RuleApplyRequest) (int, interface{}) {
+	return http.StatusOK, dto.RuleApplyResult{
+		Preview: applyRequest.Preview,
+		Changed: 1,
+		Operations: []dto.RuleApplyOperation{
+			{ID: 10, Rules: []int{1}, Changes: []dto.AuditChange{{Field: "description", Before: "UBER TRIP 1234", After: "Uber"}}},
+		},
+	}
+}
+
+func (m *MockOperationService) Duplicates(user dao.User, duplicateFilter dto.OperationDuplicateFilter) (int, interface{}) {
+	if user.ID == 2 {
+		return http.StatusOK, []dto.TransformedDuplicate{}
+	}
+	date, _ := time.Parse(time.RFC3339, "2023-10-20T15:04:05Z")
+	return http.StatusOK, []dto.TransformedDuplicate{
+		{
+			Type:       "expense",
+			Amount:     money.FromFloat(50),
+			Currency:   "ARS",
+			Similarity: 0.69,
+			Operations: []dto.DuplicateOperation{
+				{ID: 7, Date: date, Description: "Coffee shop"},
+				{ID: 8, Date: date.AddDate(0, 0, 1), Description: "COFFEE SHOP downtown"},
+			},
+		},
+	}
+}
+
+func (m *MockOperationService) Merge(user dao.User, operationID int, mergeRequest dto.OperationMergeRequest) (int, interface{}) {
+	if mergeRequest.DuplicateID == "2" {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Operations successfully merged."}
+}
+
+func (m *MockOperationService) Search(user dao.User, searchRequest dto.OperationSearchRequest) (int, interface{}) {
+	if user.ID == 2 {
+		return http.StatusOK, []dto.TransformedSearchOperation{}
+	}
+	date, _ := time.Parse(time.RFC3339, "2023-10-20T15:04:05Z")
+	return http.StatusOK, []dto.TransformedSearchOperation{
+		{
+			ID:          9,
+			Type:        "expense",
+			Amount:      money.FromFloat(8500),
+			Currency:    "ARS",
+			Date:        date,
+			Category:    dto.TransformedCategory{Name: "Home", Color: "#6495ed"},
+			Description: "Pago al plomero",
+			Rank:        0.06,
+			Highlights:  dto.OperationHighlights{Description: "Pago al <mark>plomero</mark>", Category: "Home repairs"},
+		},
 	}
 }
 
@@ -43,10 +116,11 @@ func (m *MockOperationService) Show(user dao.User, operationID int) (int, interf
 
 	if operationID == 1 {
 		return http.StatusOK, dto.TransformedShowOperation{
-			ID:     1,
-			Type:   "income",
-			Amount: 1200.5,
-			Date:   date,
+			ID:       1,
+			Type:     "income",
+			Amount:   money.FromFloat(1200.5),
+			Currency: "ARS",
+			Date:     date,
 			Category: dto.TransformedShowCategory{
 				Name:        "Work",
 				Color:       "#fdg123",
@@ -54,6 +128,11 @@ func (m *MockOperationService) Show(user dao.User, operationID int) (int, interf
 				IsDefault:   true,
 			},
 			Description: "Salario",
+			Splits: []dto.TransformedSplit{
+				{Category: dto.TransformedCategory{Name: "Work", Color: "#fdg123"}, Amount: money.FromFloat(1000), Note: "Salary"},
+				{Category: dto.TransformedCategory{Name: "Bonus", Color: "#6495ed"}, Amount: money.FromFloat(200.5), Note: "Bonus"},
+			},
+			Tags: []string{"reimbursable", "vacation-2026"},
 		}
 	} else {
 		return http.StatusNotFound, gin.H{"error": "Not found."}
@@ -72,7 +151,7 @@ func (m *MockOperationService) Create(user dao.User, operationRequest dto.Operat
 	return http.StatusCreated, gin.H{"message": "Operation successfully created."}
 }
 
-func (m *MockOperationService) Update(user dao.User, operationRequest dto.OperationRequest, operationID int) (int, interface{}) {
+func (m *MockOperationService) Update(user dao.User, operationRequest dto.OperationRequest, operationID int, ifMatch string) (int, interface{}) {
 	if operationID == 2 {
 		return http.StatusNotFound, gin.H{"error": "Not found."}
 	}
@@ -88,11 +167,15 @@ func (m *MockOperationService) Update(user dao.User, operationRequest dto.Operat
 	return http.StatusOK, gin.H{"message": "Operation successfully updated."}
 }
 
-func (m *MockOperationService) Delete(user dao.User, operationID int) (int, interface{}) {
+func (m *MockOperationService) Delete(user dao.User, operationID int, ifMatch string) (int, interface{}) {
 	if operationID == 2 {
 		return http.StatusNotFound, gin.H{"error": "Not found."}
 	}
 
+	if ifMatch == `"stale"` {
+		return http.StatusPreconditionFailed, gin.H{"error": "The operation has been modified."}
+	}
+
 	if operationID == 3 {
 		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while deleting the operation."}
 	}
@@ -100,6 +183,83 @@ func (m *MockOperationService) Delete(user dao.User, operationID int) (int, inte
 	return http.StatusOK, gin.H{"message": "Operation successfully deleted."}
 }
 
+func (m *MockOperationService) History(user dao.User, operationID int) (int, interface{}) {
+	if operationID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, []dto.TransformedAuditEntry{
+		{ID: 1, Action: "update", ActorID: 1, Actor: "pedro.fuentes", CreatedAt: time.Date(2023, 10, 24, 10, 0, 0, 0, time.UTC), Changes: []dto.AuditChange{{Field: "amount", Before: "1200.50", After: "1500.00"}}},
+	}
+}
+
+func (m *MockOperationService) Patch(user dao.User, operationID int, ifMatch string, applyPatch func(operationRequest dto.OperationRequest) (dto.OperationRequest, error)) (int, interface{}) {
+	if operationID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	operationRequest, patchError := applyPatch(dto.OperationRequest{
+		Type:        "income",
+		Amount:      money.FromFloat(1200.5),
+		Currency:    "ARS",
+		Date:        "2023-10-23T21:33:03Z",
+		Description: "Salario",
+		CategoryID:  "1",
+		AccountID:   "1",
+		Splits:      []dto.SplitRequest{},
+		Tags:        []string{},
+	})
+	if errors.Is(patchError, patch.ErrTestFailed) {
+		return http.StatusConflict, gin.H{"error": "The patch test failed."}
+	}
+	if patchError != nil {
+		return http.StatusBadRequest, gin.H{"error": "Invalid parameters."}
+	}
+	return http.StatusOK, operationRequest
+}
+
+func (m *MockOperationService) Revert(user dao.User, operationID int, entryID int) (int, interface{}) {
+	if operationID == 2 || entryID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Operation successfully reverted."}
+}
+
+func (m *MockOperationService) Batch(user dao.User, batchRequest dto.OperationBatchRequest) (int, interface{}) {
+	result := dto.OperationBatchResult{Mode: batchRequest.Mode, Committed: true, Results: []dto.OperationBatchActionResult{}}
+	for index, action := range batchRequest.Actions {
+		actionResult := dto.OperationBatchActionResult{Index: index, Action: action.Action, ID: action.ID, Status: http.StatusOK}
+		if action.Invalid {
+			actionResult.Status = http.StatusBadRequest
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+		result.Results = append(result.Results, actionResult)
+	}
+	return http.StatusOK, result
+}
+
+func (m *MockOperationService) Export(user dao.User, exportRequest dto.OperationExportRequest, writer io.Writer) error {
+	if exportRequest.Description == "error" {
+		return errors.New("Database error.")
+	}
+	_, err := io.WriteString(writer, exportRequest.Format+":"+exportRequest.DecimalSeparator+"\n")
+	return err
+}
+
+func (m *MockOperationService) Import(user dao.User, importRequest dto.OperationImportRequest, file io.Reader) (int, interface{}) {
+	content, _ := io.ReadAll(file)
+	if len(content) == 0 {
+		return http.StatusBadRequest, gin.H{"error": "Invalid CSV file."}
+	}
+	if importRequest.DryRun {
+		return http.StatusOK, dto.OperationImportResult{DryRun: true, Rows: []dto.OperationImportRow{}}
+	}
+	if importRequest.Format == dto.ImportFormatOFX {
+		return http.StatusCreated, dto.OperationImportResult{Imported: 2, Rows: []dto.OperationImportRow{}}
+	}
+	return http.StatusCreated, dto.OperationImportResult{Imported: 1, Rows: []dto.OperationImportRow{}}
+}
+
 func TestOperationHandlerImpl_Index(t *testing.T) {
 	operationService := &MockOperationService{}
 	operationHandler := OperationHandlerInit(operationService)
@@ -110,26 +270,264 @@ func TestOperationHandlerImpl_Index(t *testing.T) {
 			Name:         "when the user has operations",
 			Params:       "",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "[{\"id\":1,\"type\":\"income\",\"amount\":1200.5,\"date\":\"2023-10-23T21:33:03.73297-03:00\",\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"}}]",
+			ExpectedBody: "{\"operations\":[{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297-03:00\",\"account_id\":null,\"transfer_id\":null,\"status\":\"\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"tags\":[\"reimbursable\"]}],\"next_cursor\":\"next\"}",
 		},
 		{
 			Name:         "when the user has no operations",
 			Params:       "",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "[]",
+			ExpectedBody: "{\"operations\":[],\"next_cursor\":\"\"}",
+		},
+		{
+			Name:         "when the filters are valid",
+			Params:       "?from=2023-10-01T00:00:00Z&to=2023-10-31T23:59:59Z&type=income&category_id=1&min_amount=100&max_amount=2000&description=sal&sort=amount_desc&limit=10",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297-03:00\",\"account_id\":null,\"transfer_id\":null,\"status\":\"\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"tags\":[\"reimbursable\"]}],\"next_cursor\":\"next\"}",
+		},
+		{
+			Name:         "when the transfers of an account are filtered",
+			Params:       "?type=transfer&account_id=1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297-03:00\",\"account_id\":null,\"transfer_id\":null,\"status\":\"\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"tags\":[\"reimbursable\"]}],\"next_cursor\":\"next\"}",
+		},
+		{
+			Name:         "when the operations are filtered by all of a set of tags",
+			Params:       "?tags=vacation-2026,reimbursable&tag_mode=all",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297-03:00\",\"account_id\":null,\"transfer_id\":null,\"status\":\"\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"tags\":[\"reimbursable\"]}],\"next_cursor\":\"next\"}",
+		},
+		{
+			Name:         "when the tag mode is invalid",
+			Params:       "?tags=shared&tag_mode=some",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the type filter is invalid",
+			Params:       "?type=invalid",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the sort is invalid",
+			Params:       "?sort=name",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the date is malformed",
+			Params:       "?from=yesterday",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the amount range is inverted",
+			Params:       "?min_amount=500&max_amount=100",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the amount range has cents",
+			Params:       "?min_amount=10.50&max_amount=10.75",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297-03:00\",\"account_id\":null,\"transfer_id\":null,\"status\":\"\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"tags\":[\"reimbursable\"]}],\"next_cursor\":\"next\"}",
+		},
+		{
+			Name:         "when the amount is malformed",
+			Params:       "?min_amount=ten",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the cursor is invalid",
+			Params:       "?cursor=invalid",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid cursor.\"}",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
-			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri + tt.Params)
 
-			if tt.Name == "when the user has operations" {
+			if tt.Name == "when the user has no operations" {
+				ctx.Set("user", dao.User{ID: 2})
+			} else {
 				ctx.Set("user", dao.User{ID: 1})
+			}
+
+			operationHandler.Index(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestOperationHandlerImpl_Search(t *testing.T) {
+	operationHandler := OperationHandlerInit(&MockOperationService{})
+	serviceUri := "/api/operations/search"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when operations match the search",
+			Params:       "?q=plomero&limit=10",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":9,\"type\":\"expense\",\"amount\":\"8500.00\",\"currency\":\"ARS\",\"date\":\"2023-10-20T15:04:05Z\",\"account_id\":null,\"transfer_id\":null,\"category\":{\"name\":\"Home\",\"color\":\"#6495ed\"},\"description\":\"Pago al plomero\",\"rank\":0.06,\"highlights\":{\"description\":\"Pago al \\u003cmark\\u003eplomero\\u003c/mark\\u003e\",\"category\":\"Home repairs\"}}]",
+		},
+		{
+			Name:         "when no operation matches the search",
+			Params:       "?q=plumber",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+		{
+			Name:         "when the search is empty",
+			Params:       "?q=%20",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the limit is invalid",
+			Params:       "?q=plomero&limit=-1",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri + tt.Params)
+
+			if tt.Name == "when no operation matches the search" {
+				ctx.Set("user", dao.User{ID: 2})
 			} else {
+				ctx.Set("user", dao.User{ID: 1})
+			}
+
+			operationHandler.Search(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestOperationHandlerImpl_ApplyRules(t *testing.T) {
+	operationHandler := OperationHandlerInit(&MockOperationService{})
+	serviceUri := "/api/rules/apply"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the rules are previewed",
+			Params:       "?preview=true",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"preview\":true,\"changed\":1,\"operations\":[{\"id\":10,\"rules\":[1],\"changes\":[{\"field\":\"description\",\"before\":\"UBER TRIP 1234\",\"after\":\"Uber\"}]}]}",
+		},
+		{
+			Name:         "when the rules are applied",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"preview\":false,\"changed\":1,\"operations\":[{\"id\":10,\"rules\":[1],\"changes\":[{\"field\":\"description\",\"before\":\"UBER TRIP 1234\",\"after\":\"Uber\"}]}]}",
+		},
+		{
+			Name:         "when the preview flag is invalid",
+			Params:       "?preview=maybe",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest("", serviceUri+tt.Params)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			operationHandler.ApplyRules(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestOperationHandlerImpl_Duplicates(t *testing.T) {
+	operationHandler := OperationHandlerInit(&MockOperationService{})
+	serviceUri := "/api/operations/duplicates"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the user has duplicated operations",
+			Params:       "?days=3",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"type\":\"expense\",\"amount\":\"50.00\",\"currency\":\"ARS\",\"similarity\":0.69,\"operations\":[" +
+				"{\"id\":7,\"date\":\"2023-10-20T15:04:05Z\",\"description\":\"Coffee shop\"}," +
+				"{\"id\":8,\"date\":\"2023-10-21T15:04:05Z\",\"description\":\"COFFEE SHOP downtown\"}]}]",
+		},
+		{
+			Name:         "when the user has no duplicated operations",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+		{
+			Name:         "when the days are out of range",
+			Params:       "?days=90",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri + tt.Params)
+
+			if tt.Name == "when the user has no duplicated operations" {
 				ctx.Set("user", dao.User{ID: 2})
+			} else {
+				ctx.Set("user", dao.User{ID: 1})
 			}
 
-			operationHandler.Index(ctx)
+			operationHandler.Duplicates(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestOperationHandlerImpl_Merge(t *testing.T) {
+	operationHandler := OperationHandlerInit(&MockOperationService{})
+	serviceUri := "/api/operations/1/merge"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operations are merged",
+			Params:       `{"duplicate_id": "3"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operations successfully merged.\"}",
+		},
+		{
+			Name:         "when the duplicate is not found",
+			Params:       `{"duplicate_id": "2"}`,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the operation is merged with itself",
+			Params:       `{"duplicate_id": "1"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the duplicate is missing",
+			Params:       `{}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+
+			operationHandler.Merge(ctx)
 
 			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
 		})
@@ -146,7 +544,7 @@ func TestOperationHandlerImpl_Show(t *testing.T) {
 			Name:         "when the operation is found",
 			Params:       "",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "{\"id\":1,\"type\":\"income\",\"amount\":1200.5,\"date\":\"2023-10-23T21:33:03.73297-03:00\",\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\",\"description\":\"Work\",\"is_default\":true},\"description\":\"Salario\"}",
+			ExpectedBody: "{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297-03:00\",\"account_id\":null,\"transfer_id\":null,\"status\":\"\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\",\"description\":\"Work\",\"is_default\":true},\"description\":\"Salario\",\"splits\":[{\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"amount\":\"1000.00\",\"note\":\"Salary\"},{\"category\":{\"name\":\"Bonus\",\"color\":\"#6495ed\"},\"amount\":\"200.50\",\"note\":\"Bonus\"}],\"tags\":[\"reimbursable\",\"vacation-2026\"]}",
 		},
 		{
 			Name:         "when the operation is not found",
@@ -154,6 +552,12 @@ func TestOperationHandlerImpl_Show(t *testing.T) {
 			ExpectedCode: http.StatusNotFound,
 			ExpectedBody: "{\"error\":\"Not found.\"}",
 		},
+		{
+			Name:         "when the operation did not change since the ETag",
+			Params:       "",
+			ExpectedCode: http.StatusNotModified,
+			ExpectedBody: "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -167,6 +571,11 @@ func TestOperationHandlerImpl_Show(t *testing.T) {
 
 			ctx.Set("user", dao.User{ID: 1})
 
+			if tt.Name == "when the operation did not change since the ETag" {
+				_, operation := operationService.Show(dao.User{ID: 1}, operation_id)
+				ctx.Request.Header.Set("If-None-Match", services.ETag(operation))
+			}
+
 			ctx.Params = []gin.Param{
 				{
 					Key:   "id",
@@ -211,6 +620,24 @@ func TestOperationHandlerImpl_Create(t *testing.T) {
 			ExpectedCode: http.StatusBadRequest,
 			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
 		},
+		{
+			Name:         "when the operation is marked as reconciled",
+			Params:       `{"type": "income", "amount": 200.50, "date": "2023-11-02T23:07:00Z", "description": "Payment for services", "category_id": "1", "status": "reconciled"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when a tag is empty",
+			Params:       `{"type": "expense", "amount": 200.50, "date": "2023-11-02T23:07:00Z", "description": "Hotel", "category_id": "1", "tags": ["vacation-2026", " "]}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when a split has an invalid amount",
+			Params:       `{"type": "expense", "amount": 200.50, "date": "2023-11-02T23:07:00Z", "description": "Supermarket", "category_id": "1", "splits": [{"category_id": "1", "amount": 200.50}, {"category_id": "1", "amount": 0}]}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
 		{
 			Name:         "when there is an error in the creation of the operation",
 			Params:       `{"type": "expense", "amount": 200.50, "date": "2023-11-02T23:07:00Z", "description": "Payment for work", "category_id": "1"}`,
@@ -314,6 +741,104 @@ func TestOperationHandlerImpl_Update(t *testing.T) {
 	}
 }
 
+func TestOperationHandlerImpl_Patch(t *testing.T) {
+	operationService := &MockOperationService{}
+	operationHandler := OperationHandlerInit(operationService)
+	serviceUri := "/api/operations/1"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operation is patched with a merge patch",
+			Params:       `{"amount": "1500", "tags": ["salary"]}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"type\":\"income\",\"amount\":\"1500.00\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03Z\",\"description\":\"Salario\",\"category_id\":\"1\",\"account_id\":\"1\",\"splits\":[],\"tags\":[\"salary\"]}",
+		},
+		{
+			Name:         "when the operation is patched with a JSON patch",
+			Params:       `[{"op": "test", "path": "/type", "value": "income"}, {"op": "replace", "path": "/description", "value": "Bonus"}]`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03Z\",\"description\":\"Bonus\",\"category_id\":\"1\",\"account_id\":\"1\",\"splits\":[],\"tags\":[]}",
+		},
+		{
+			Name:         "when the patch sets an invalid amount",
+			Params:       `{"amount": "-10"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the patch removes the type",
+			Params:       `{"type": null}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the patch sets an unknown field",
+			Params:       `{"user_id": 2}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the patch replaces the whole operation",
+			Params:       `[{"op": "replace", "path": "", "value": {"type": "bogus", "amount": "-5", "date": "2999-01-01T00:00:00Z", "status": "reconciled"}}]`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the patch reconciles the operation",
+			Params:       `{"status": "reconciled"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the patch test fails",
+			Params:       `[{"op": "test", "path": "/type", "value": "expense"}]`,
+			ExpectedCode: http.StatusConflict,
+			ExpectedBody: "{\"error\":\"The patch test failed.\"}",
+		},
+		{
+			Name:         "when the content type is not supported",
+			Params:       `amount=1500`,
+			ExpectedCode: http.StatusUnsupportedMediaType,
+			ExpectedBody: "{\"error\":\"Unsupported content type.\"}",
+		},
+		{
+			Name:         "when the operation is not found",
+			Params:       `{"amount": "1500"}`,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			operation_id := 1
+			contentType := patch.MergePatchContentType
+
+			if tt.Name == "when the operation is not found" {
+				operation_id = 2
+			} else if tt.Name == "when the content type is not supported" {
+				contentType = "application/x-www-form-urlencoded"
+			} else if tt.Params[0] == '[' {
+				contentType = patch.JSONPatchContentType
+			}
+
+			ctx, responseRecorder := testhelpers.MockPatchRequest(tt.Params, contentType, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{
+				{
+					Key:   "id",
+					Value: strconv.Itoa(operation_id),
+				},
+			}
+
+			operationHandler.Patch(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
 func TestOperationHandlerImpl_Delete(t *testing.T) {
 	operationService := &MockOperationService{}
 	operationHandler := OperationHandlerInit(operationService)
@@ -338,6 +863,18 @@ func TestOperationHandlerImpl_Delete(t *testing.T) {
 			ExpectedCode: http.StatusUnprocessableEntity,
 			ExpectedBody: "{\"error\":\"An error occurred while deleting the operation.\"}",
 		},
+		{
+			Name:         "when the operation was modified since the ETag",
+			Params:       "",
+			ExpectedCode: http.StatusPreconditionFailed,
+			ExpectedBody: "{\"error\":\"The operation has been modified.\"}",
+		},
+		{
+			Name:         "when the If-Match header is required and missing",
+			Params:       "",
+			ExpectedCode: http.StatusPreconditionRequired,
+			ExpectedBody: "{\"error\":\"The If-Match header is required.\"}",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -353,6 +890,12 @@ func TestOperationHandlerImpl_Delete(t *testing.T) {
 
 			ctx.Set("user", dao.User{ID: 1})
 
+			if tt.Name == "when the operation was modified since the ETag" {
+				ctx.Request.Header.Set("If-Match", `"stale"`)
+			} else if tt.Name == "when the If-Match header is required and missing" {
+				t.Setenv("REQUIRE_IF_MATCH", "true")
+			}
+
 			ctx.Params = []gin.Param{
 				{
 					Key:   "id",
@@ -366,3 +909,272 @@ func TestOperationHandlerImpl_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestOperationHandlerImpl_Import(t *testing.T) {
+	operationHandler := OperationHandlerInit(&MockOperationService{})
+	serviceUri := "/api/operations/import"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the file is imported successfully",
+			Params:       "date_format=DD/MM/YYYY&decimal_separator=,&delimiter=;&amount_sign=positive_expense",
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"dry_run\":false,\"imported\":1,\"duplicates\":0,\"invalid\":0,\"rows\":[]}",
+		},
+		{
+			Name:         "when it is a dry run",
+			Params:       "dry_run=true",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"dry_run\":true,\"imported\":0,\"duplicates\":0,\"invalid\":0,\"rows\":[]}",
+		},
+		{
+			Name:         "when the format is taken from the file extension",
+			Params:       "",
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"dry_run\":false,\"imported\":2,\"duplicates\":0,\"invalid\":0,\"rows\":[]}",
+		},
+		{
+			Name:         "when the format is not supported",
+			Params:       "format=xls",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the date format is not supported",
+			Params:       "date_format=YY-M-D",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the amount sign is invalid",
+			Params:       "amount_sign=always_expense",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the decimal separator is invalid",
+			Params:       "decimal_separator=_",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the file is missing",
+			Params:       "",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the file exceeds the maximum size",
+			Params:       "",
+			ExpectedCode: http.StatusRequestEntityTooLarge,
+			ExpectedBody: "{\"error\":\"The file exceeds the maximum size.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			fields, _ := url.ParseQuery(tt.Params)
+			for name, values := range fields {
+				writer.WriteField(name, values[0])
+			}
+			if tt.Name != "when the file is missing" {
+				fileName := "operations.csv"
+				if tt.Name == "when the format is taken from the file extension" {
+					fileName = "statement.ofx"
+				}
+				part, _ := writer.CreateFormFile("file", fileName)
+				part.Write([]byte("date,amount\n2023-10-21,10\n"))
+				if tt.Name == "when the file exceeds the maximum size" {
+					part.Write([]byte(strings.Repeat("2023-10-21,10\n", int(maxImportRequestSize)/14)))
+				}
+			}
+			writer.Close()
+
+			responseRecorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(responseRecorder)
+			ctx.Request = httptest.NewRequest("POST", serviceUri, body)
+			ctx.Request.Header.Set("Content-Type", writer.FormDataContentType())
+			ctx.Set("user", dao.User{ID: 1})
+
+			operationHandler.Import(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestOperationHandlerImpl_Export(t *testing.T) {
+	operationHandler := OperationHandlerInit(&MockOperationService{})
+	serviceUri := "/api/operations/export"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operations are exported as CSV",
+			Params:       "?decimal_separator=,",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "csv:,\n",
+		},
+		{
+			Name:         "when the operations are exported as JSON Lines",
+			Params:       "?format=jsonl&type=expense",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "jsonl:\n",
+		},
+		{
+			Name:         "when the format is not supported",
+			Params:       "?format=xlsx",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the delimiter is the decimal separator",
+			Params:       "?decimal_separator=,&delimiter=,",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the filter is invalid",
+			Params:       "?type=refund",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the operations cannot be read",
+			Params:       "?description=error",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the export of the operations.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri + tt.Params)
+			ctx.Set("user", dao.User{ID: 1})
+
+			operationHandler.Export(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+			if tt.ExpectedCode == http.StatusOK {
+				assert.Contains(t, responseRecorder.Header().Get("Content-Disposition"), "attachment")
+			}
+		})
+	}
+}
+
+func TestOperationHandlerImpl_Batch(t *testing.T) {
+	operationHandler := OperationHandlerInit(&MockOperationService{})
+	serviceUri := "/api/operations/batch"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name: "when every action is valid",
+			Params: `{"mode": "best_effort", "actions": [` +
+				`{"action": "create", "operation": {"type": "income", "amount": 200.50, "date": "2023-11-02T23:07:00Z", "category_id": "1"}},` +
+				`{"action": "delete", "id": 1}]}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"mode\":\"best_effort\",\"committed\":true,\"succeeded\":2,\"failed\":0,\"results\":[" +
+				"{\"index\":0,\"action\":\"create\",\"id\":0,\"status\":200,\"response\":null}," +
+				"{\"index\":1,\"action\":\"delete\",\"id\":1,\"status\":200,\"response\":null}]}",
+		},
+		{
+			Name: "when some actions are invalid",
+			Params: `{"actions": [` +
+				`{"action": "create", "operation": {"type": "refund", "amount": 200.50, "date": "2023-11-02T23:07:00Z"}},` +
+				`{"action": "update", "operation": {"type": "income", "amount": 200.50, "date": "2023-11-02T23:07:00Z"}},` +
+				`{"action": "update", "id": 1, "operation": {"type": "income", "amount": 0, "date": "2023-11-02T23:07:00Z"}},` +
+				`{"action": "archive", "id": 1}]}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"mode\":\"\",\"committed\":true,\"succeeded\":0,\"failed\":4,\"results\":[" +
+				"{\"index\":0,\"action\":\"create\",\"id\":0,\"status\":400,\"response\":null}," +
+				"{\"index\":1,\"action\":\"update\",\"id\":0,\"status\":400,\"response\":null}," +
+				"{\"index\":2,\"action\":\"update\",\"id\":1,\"status\":400,\"response\":null}," +
+				"{\"index\":3,\"action\":\"archive\",\"id\":1,\"status\":400,\"response\":null}]}",
+		},
+		{
+			Name:         "when the mode is invalid",
+			Params:       `{"mode": "partial", "actions": [{"action": "delete", "id": 1}]}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when there are no actions",
+			Params:       `{"actions": []}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest(tt.Params, serviceUri)
+			ctx.Set("user", dao.User{ID: 1})
+
+			operationHandler.Batch(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestOperationHandlerImpl_History(t *testing.T) {
+	operationHandler := OperationHandlerInit(&MockOperationService{})
+	serviceUri := "/api/operations/1/history"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operation has changes",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"action\":\"update\",\"actor_id\":1,\"actor\":\"pedro.fuentes\",\"created_at\":\"2023-10-24T10:00:00Z\",\"changes\":[{\"field\":\"amount\",\"before\":\"1200.50\",\"after\":\"1500.00\"}]}]",
+		},
+		{
+			Name:         "when the operation has no history",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			operationHandler.History(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestOperationHandlerImpl_Revert(t *testing.T) {
+	operationHandler := OperationHandlerInit(&MockOperationService{})
+	serviceUri := "/api/operations/1/history/1/revert"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operation is reverted",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully reverted.\"}",
+		},
+		{
+			Name:         "when the change is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest("", serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{{Key: "id", Value: "1"}, {Key: "entry_id", Value: tt.Params}}
+
+			operationHandler.Revert(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PayeeHandler interface {
+	Index(ctx *gin.Context)
+	Show(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	Merge(ctx *gin.Context)
+	Totals(ctx *gin.Context)
+}
+
+type PayeeHandlerImpl struct {
+	svc services.PayeeService
+}
+
+func (u PayeeHandlerImpl) Index(ctx *gin.Context) {
+	code, response := u.svc.Index(ParseUserFromContext(ctx))
+	ctx.JSON(code, response)
+}
+
+func (u PayeeHandlerImpl) Show(ctx *gin.Context) {
+	payeeID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Show(ParseUserFromContext(ctx), payeeID)
+	ctx.JSON(code, response)
+}
+
+func (u PayeeHandlerImpl) Create(ctx *gin.Context) {
+	var payeeRequest dto.PayeeRequest
+	validationError := ctx.ShouldBindJSON(&payeeRequest)
+	if validationError != nil || invalidPayee(payeeRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Create(ParseUserFromContext(ctx), payeeRequest)
+	ctx.JSON(code, response)
+}
+
+func (u PayeeHandlerImpl) Update(ctx *gin.Context) {
+	payeeID, _ := strconv.Atoi(ctx.Param("id"))
+	var payeeRequest dto.PayeeRequest
+	validationError := ctx.ShouldBindJSON(&payeeRequest)
+	if validationError != nil || invalidPayee(payeeRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Update(ParseUserFromContext(ctx), payeeRequest, payeeID)
+	ctx.JSON(code, response)
+}
+
+func (u PayeeHandlerImpl) Delete(ctx *gin.Context) {
+	payeeID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Delete(ParseUserFromContext(ctx), payeeID)
+	ctx.JSON(code, response)
+}
+
+func (u PayeeHandlerImpl) Merge(ctx *gin.Context) {
+	payeeID, _ := strconv.Atoi(ctx.Param("id"))
+	var mergeRequest dto.PayeeMergeRequest
+	validationError := ctx.ShouldBindJSON(&mergeRequest)
+	if validationError != nil || mergeRequest.PayeeID <= 0 || mergeRequest.PayeeID == payeeID {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Merge(ParseUserFromContext(ctx), payeeID, mergeRequest)
+	ctx.JSON(code, response)
+}
+
+func (u PayeeHandlerImpl) Totals(ctx *gin.Context) {
+	var totalsFilter dto.PayeeTotalsFilter
+	validationError := ctx.ShouldBindQuery(&totalsFilter)
+	if validationError != nil || (totalsFilter.From != nil && totalsFilter.To != nil && totalsFilter.From.After(*totalsFilter.To)) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Totals(ParseUserFromContext(ctx), totalsFilter)
+	ctx.JSON(code, response)
+}
+
+// invalidPayee checks the name and aliases of the payee. Aliases are stored
+// separated by commas, so they cannot hold one.
+func invalidPayee(request dto.PayeeRequest) bool {
+	name := strings.TrimSpace(request.Name)
+	if name == "" || len(name) > services.MAX_PAYEE_NAME_LENGTH || strings.Contains(name, ",") {
+		return true
+	}
+	if len(request.Aliases) > services.MAX_PAYEE_ALIASES {
+		return true
+	}
+	for _, alias := range request.Aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" || len(alias) > services.MAX_PAYEE_NAME_LENGTH || strings.Contains(alias, ",") {
+			return true
+		}
+	}
+	return false
+}
+
+func PayeeHandlerInit(payeeService services.PayeeService) *PayeeHandlerImpl {
+	return &PayeeHandlerImpl{
+		svc: payeeService,
+	}
+}
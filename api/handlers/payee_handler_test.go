@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockPayeeService struct{}
+
+func (m *MockPayeeService) Index(user dao.User) (int, interface{}) {
+	return http.StatusOK, []dto.TransformedPayee{
+		{ID: 1, Name: "Carrefour", Aliases: []string{"Carrefour Express"}},
+	}
+}
+
+func (m *MockPayeeService) Show(user dao.User, payeeID int) (int, interface{}) {
+	if payeeID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	_, response := m.Index(user)
+	return http.StatusOK, response.([]dto.TransformedPayee)[0]
+}
+
+func (m *MockPayeeService) Create(user dao.User, payeeRequest dto.PayeeRequest) (int, interface{}) {
+	return http.StatusCreated, gin.H{"message": "Payee successfully created."}
+}
+
+func (m *MockPayeeService) Update(user dao.User, payeeRequest dto.PayeeRequest, payeeID int) (int, interface{}) {
+	if payeeID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Payee successfully updated."}
+}
+
+func (m *MockPayeeService) Delete(user dao.User, payeeID int) (int, interface{}) {
+	if payeeID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Payee successfully deleted."}
+}
+
+func (m *MockPayeeService) Merge(user dao.User, payeeID int, mergeRequest dto.PayeeMergeRequest) (int, interface{}) {
+	if payeeID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Payees successfully merged."}
+}
+
+func (m *MockPayeeService) Totals(user dao.User, totalsFilter dto.PayeeTotalsFilter) (int, interface{}) {
+	return http.StatusOK, []dto.PayeeTotalRow{
+		{PayeeID: 1, Name: "Carrefour", Currency: "ARS", Total: money.FromFloat(3500), Count: 2},
+	}
+}
+
+func TestPayeeHandlerImpl_Index(t *testing.T) {
+	payeeHandler := PayeeHandlerInit(&MockPayeeService{})
+	serviceUri := "/api/payees"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the user has payees",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"name\":\"Carrefour\",\"aliases\":[\"Carrefour Express\"]}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			payeeHandler.Index(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestPayeeHandlerImpl_Show(t *testing.T) {
+	payeeHandler := PayeeHandlerInit(&MockPayeeService{})
+	serviceUri := "/api/payees"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the payee is found",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"name\":\"Carrefour\",\"aliases\":[\"Carrefour Express\"]}",
+		},
+		{
+			Name:         "when the payee is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			payeeHandler.Show(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestPayeeHandlerImpl_Create(t *testing.T) {
+	payeeHandler := PayeeHandlerInit(&MockPayeeService{})
+	serviceUri := "/api/payees"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the payee is created successfully",
+			Params:       `{"name": "Carrefour", "aliases": ["Carrefour Express", "CARREFOUR MARKET"]}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Payee successfully created.\"}",
+		},
+		{
+			Name:         "when the name is empty",
+			Params:       `{"name": " ", "aliases": ["Carrefour Express"]}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when an alias is empty",
+			Params:       `{"name": "Carrefour", "aliases": [" "]}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when an alias has a comma",
+			Params:       `{"name": "Carrefour", "aliases": ["Carrefour, Express"]}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			payeeHandler.Create(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestPayeeHandlerImpl_Update(t *testing.T) {
+	payeeHandler := PayeeHandlerInit(&MockPayeeService{})
+	serviceUri := "/api/payees"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the payee is updated successfully",
+			Params:       `{"name": "Carrefour", "aliases": ["Carrefour Express"]}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Payee successfully updated.\"}",
+		},
+		{
+			Name:         "when the payee is not found",
+			Params:       `{"name": "Carrefour", "aliases": ["Carrefour Express"]}`,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the name has a comma",
+			Params:       `{"name": "Carrefour, Express"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			payeeID := "1"
+
+			if tt.Name == "when the payee is not found" {
+				payeeID = "2"
+			}
+
+			ctx, responseRecorder := testhelpers.MockPutRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: payeeID}}
+
+			payeeHandler.Update(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestPayeeHandlerImpl_Delete(t *testing.T) {
+	payeeHandler := PayeeHandlerInit(&MockPayeeService{})
+	serviceUri := "/api/payees"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the payee is deleted successfully",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Payee successfully deleted.\"}",
+		},
+		{
+			Name:         "when the payee is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockDeleteRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			payeeHandler.Delete(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestPayeeHandlerImpl_Merge(t *testing.T) {
+	payeeHandler := PayeeHandlerInit(&MockPayeeService{})
+	serviceUri := "/api/payees/1/merge"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the payees are merged successfully",
+			Params:       `{"payee_id": 3}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Payees successfully merged.\"}",
+		},
+		{
+			Name:         "when the payee is merged into itself",
+			Params:       `{"payee_id": 1}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the merged payee is missing",
+			Params:       `{}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+
+			payeeHandler.Merge(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestPayeeHandlerImpl_Totals(t *testing.T) {
+	payeeHandler := PayeeHandlerInit(&MockPayeeService{})
+	serviceUri := "/api/payees/totals"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the totals are listed",
+			Params:       "?from=2023-10-01T00:00:00Z&to=2023-10-31T23:59:59Z",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"payee_id\":1,\"name\":\"Carrefour\",\"currency\":\"ARS\",\"total\":\"3500.00\",\"count\":2}]",
+		},
+		{
+			Name:         "when the period is reversed",
+			Params:       "?from=2023-10-31T00:00:00Z&to=2023-10-01T00:00:00Z",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the date is invalid",
+			Params:       "?from=yesterday",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri + tt.Params)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			payeeHandler.Totals(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReconciliationHandler interface {
+	Index(ctx *gin.Context)
+	Show(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Tick(ctx *gin.Context)
+	Untick(ctx *gin.Context)
+	Finalize(ctx *gin.Context)
+}
+
+type ReconciliationHandlerImpl struct {
+	svc services.ReconciliationService
+}
+
+func (u ReconciliationHandlerImpl) Index(ctx *gin.Context) {
+	code, response := u.svc.Index(ParseUserFromContext(ctx))
+	ctx.JSON(code, response)
+}
+
+func (u ReconciliationHandlerImpl) Show(ctx *gin.Context) {
+	reconciliationID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Show(ParseUserFromContext(ctx), reconciliationID)
+	ctx.JSON(code, response)
+}
+
+func (u ReconciliationHandlerImpl) Create(ctx *gin.Context) {
+	var reconciliationRequest dto.ReconciliationRequest
+	validationError := ctx.ShouldBindJSON(&reconciliationRequest)
+	if validationError != nil || invalidReconciliation(reconciliationRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Create(ParseUserFromContext(ctx), reconciliationRequest)
+	ctx.JSON(code, response)
+}
+
+func (u ReconciliationHandlerImpl) Tick(ctx *gin.Context) {
+	reconciliationID, _ := strconv.Atoi(ctx.Param("id"))
+	var tickRequest dto.ReconciliationTickRequest
+	validationError := ctx.ShouldBindJSON(&tickRequest)
+	if validationError != nil || invalidOperationIDs(tickRequest.OperationIDs) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Tick(ParseUserFromContext(ctx), reconciliationID, tickRequest)
+	ctx.JSON(code, response)
+}
+
+func (u ReconciliationHandlerImpl) Untick(ctx *gin.Context) {
+	reconciliationID, _ := strconv.Atoi(ctx.Param("id"))
+	operationID, err := strconv.Atoi(ctx.Param("operation_id"))
+	if err != nil || operationID <= 0 {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Untick(ParseUserFromContext(ctx), reconciliationID, operationID)
+	ctx.JSON(code, response)
+}
+
+func (u ReconciliationHandlerImpl) Finalize(ctx *gin.Context) {
+	reconciliationID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Finalize(ParseUserFromContext(ctx), reconciliationID)
+	ctx.JSON(code, response)
+}
+
+// invalidReconciliation checks that the reconciliation has an account and a
+// statement date.
+func invalidReconciliation(request dto.ReconciliationRequest) bool {
+	if request.AccountID <= 0 {
+		return true
+	}
+	_, err := time.Parse(services.DATE_LAYOUT, request.StatementDate)
+	return err != nil
+}
+
+func invalidOperationIDs(operationIDs []int) bool {
+	if len(operationIDs) == 0 {
+		return true
+	}
+	for _, operationID := range operationIDs {
+		if operationID <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func ReconciliationHandlerInit(reconciliationService services.ReconciliationService) *ReconciliationHandlerImpl {
+	return &ReconciliationHandlerImpl{
+		svc: reconciliationService,
+	}
+}
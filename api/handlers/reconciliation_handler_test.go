@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockReconciliationService struct{}
+
+func (m *MockReconciliationService) Index(user dao.User) (int, interface{}) {
+	return http.StatusOK, []dto.TransformedReconciliation{
+		{ID: 1, AccountID: 1, StatementDate: "2023-10-31", ClosingBalance: money.FromFloat(1350), Status: dto.ReconciliationStatusOpen},
+	}
+}
+
+func (m *MockReconciliationService) Show(user dao.User, reconciliationID int) (int, interface{}) {
+	if reconciliationID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, dto.TransformedReconciliationDetail{
+		ID: 1, AccountID: 1, StatementDate: "2023-10-31", ClosingBalance: money.FromFloat(1350), Status: dto.ReconciliationStatusOpen,
+		ClearedBalance: money.FromFloat(1000), Difference: money.FromFloat(350), Operations: []dto.ReconciliationOperation{},
+	}
+}
+
+func (m *MockReconciliationService) Create(user dao.User, reconciliationRequest dto.ReconciliationRequest) (int, interface{}) {
+	return http.StatusCreated, gin.H{"message": "Reconciliation successfully created."}
+}
+
+func (m *MockReconciliationService) Tick(user dao.User, reconciliationID int, tickRequest dto.ReconciliationTickRequest) (int, interface{}) {
+	return m.Show(user, reconciliationID)
+}
+
+func (m *MockReconciliationService) Untick(user dao.User, reconciliationID int, operationID int) (int, interface{}) {
+	return m.Show(user, reconciliationID)
+}
+
+func (m *MockReconciliationService) Finalize(user dao.User, reconciliationID int) (int, interface{}) {
+	if reconciliationID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Reconciliation successfully finalized."}
+}
+
+func TestReconciliationHandlerImpl_Index(t *testing.T) {
+	reconciliationHandler := ReconciliationHandlerInit(&MockReconciliationService{})
+	serviceUri := "/api/reconciliations"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the user has reconciliations",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"account_id\":1,\"statement_date\":\"2023-10-31\",\"closing_balance\":\"1350.00\",\"status\":\"open\",\"finalized_at\":null}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			reconciliationHandler.Index(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestReconciliationHandlerImpl_Show(t *testing.T) {
+	reconciliationHandler := ReconciliationHandlerInit(&MockReconciliationService{})
+	serviceUri := "/api/reconciliations"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the reconciliation is found",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"account_id\":1,\"statement_date\":\"2023-10-31\",\"closing_balance\":\"1350.00\",\"status\":\"open\",\"finalized_at\":null,\"cleared_balance\":\"1000.00\",\"difference\":\"350.00\",\"operations\":[]}",
+		},
+		{
+			Name:         "when the reconciliation is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			reconciliationHandler.Show(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestReconciliationHandlerImpl_Create(t *testing.T) {
+	reconciliationHandler := ReconciliationHandlerInit(&MockReconciliationService{})
+	serviceUri := "/api/reconciliations"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the reconciliation is created successfully",
+			Params:       `{"account_id": 1, "statement_date": "2023-10-31", "closing_balance": "1350"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Reconciliation successfully created.\"}",
+		},
+		{
+			Name:         "when the account is missing",
+			Params:       `{"statement_date": "2023-10-31", "closing_balance": "1350"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the statement date is invalid",
+			Params:       `{"account_id": 1, "statement_date": "31/10/2023", "closing_balance": "1350"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			reconciliationHandler.Create(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestReconciliationHandlerImpl_Tick(t *testing.T) {
+	reconciliationHandler := ReconciliationHandlerInit(&MockReconciliationService{})
+	serviceUri := "/api/reconciliations/1/operations"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operations are ticked off",
+			Params:       `{"operation_ids": [20, 21]}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"account_id\":1,\"statement_date\":\"2023-10-31\",\"closing_balance\":\"1350.00\",\"status\":\"open\",\"finalized_at\":null,\"cleared_balance\":\"1000.00\",\"difference\":\"350.00\",\"operations\":[]}",
+		},
+		{
+			Name:         "when there are no operations",
+			Params:       `{"operation_ids": []}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when an operation ID is invalid",
+			Params:       `{"operation_ids": [20, 0]}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+
+			reconciliationHandler.Tick(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestReconciliationHandlerImpl_Untick(t *testing.T) {
+	reconciliationHandler := ReconciliationHandlerInit(&MockReconciliationService{})
+	serviceUri := "/api/reconciliations/1/operations"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operation is unticked",
+			Params:       "20",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"account_id\":1,\"statement_date\":\"2023-10-31\",\"closing_balance\":\"1350.00\",\"status\":\"open\",\"finalized_at\":null,\"cleared_balance\":\"1000.00\",\"difference\":\"350.00\",\"operations\":[]}",
+		},
+		{
+			Name:         "when the operation ID is invalid",
+			Params:       "salary",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockDeleteRequest(serviceUri + "/" + tt.Params)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: "1"}, {Key: "operation_id", Value: tt.Params}}
+
+			reconciliationHandler.Untick(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestReconciliationHandlerImpl_Finalize(t *testing.T) {
+	reconciliationHandler := ReconciliationHandlerInit(&MockReconciliationService{})
+	serviceUri := "/api/reconciliations"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the reconciliation is finalized",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Reconciliation successfully finalized.\"}",
+		},
+		{
+			Name:         "when the reconciliation is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest("", serviceUri+"/"+tt.Params+"/finalize")
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			reconciliationHandler.Finalize(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
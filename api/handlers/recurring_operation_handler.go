@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecurringOperationHandler interface {
+	Index(ctx *gin.Context)
+	Show(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type RecurringOperationHandlerImpl struct {
+	svc services.RecurringOperationService
+}
+
+func (u RecurringOperationHandlerImpl) Index(ctx *gin.Context) {
+	code, response := u.svc.Index(ParseUserFromContext(ctx))
+	ctx.JSON(code, response)
+}
+
+func (u RecurringOperationHandlerImpl) Show(ctx *gin.Context) {
+	recurringOperationID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Show(ParseUserFromContext(ctx), recurringOperationID)
+	ctx.JSON(code, response)
+}
+
+func (u RecurringOperationHandlerImpl) Create(ctx *gin.Context) {
+	var recurringOperationRequest dto.RecurringOperationRequest
+	validationError := ctx.ShouldBindJSON(&recurringOperationRequest)
+	if validationError != nil || invalidRecurringOperation(recurringOperationRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Create(ParseUserFromContext(ctx), recurringOperationRequest)
+	ctx.JSON(code, response)
+}
+
+func (u RecurringOperationHandlerImpl) Update(ctx *gin.Context) {
+	recurringOperationID, _ := strconv.Atoi(ctx.Param("id"))
+	var recurringOperationRequest dto.RecurringOperationRequest
+	validationError := ctx.ShouldBindJSON(&recurringOperationRequest)
+	if validationError != nil || invalidRecurringOperation(recurringOperationRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Update(ParseUserFromContext(ctx), recurringOperationRequest, recurringOperationID)
+	ctx.JSON(code, response)
+}
+
+func (u RecurringOperationHandlerImpl) Delete(ctx *gin.Context) {
+	recurringOperationID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Delete(ParseUserFromContext(ctx), recurringOperationID)
+	ctx.JSON(code, response)
+}
+
+func invalidRecurringOperation(request dto.RecurringOperationRequest) bool {
+	if request.Type != "income" && request.Type != "expense" {
+		return true
+	}
+	if request.Amount <= 0 {
+		return true
+	}
+	if request.Currency != "" && invalidCurrencyCode(request.Currency) {
+		return true
+	}
+	switch request.Frequency {
+	case "daily", "weekly", "monthly", "yearly":
+	default:
+		return true
+	}
+	if request.DayOfMonth < 0 || request.DayOfMonth > 31 || request.Count < 0 {
+		return true
+	}
+	startDate, err := time.Parse(time.RFC3339, request.StartDate)
+	if err != nil {
+		return true
+	}
+	if request.EndDate != "" {
+		endDate, err := time.Parse(time.RFC3339, request.EndDate)
+		if err != nil || endDate.Before(startDate) {
+			return true
+		}
+	}
+	return false
+}
+
+func RecurringOperationHandlerInit(recurringOperationService services.RecurringOperationService) *RecurringOperationHandlerImpl {
+	return &RecurringOperationHandlerImpl{
+		svc: recurringOperationService,
+	}
+}
@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockRecurringOperationService struct{}
+
+func (m *MockRecurringOperationService) Index(user dao.User) (int, []dto.TransformedRecurringOperation) {
+	startDate, _ := time.Parse(time.RFC3339, "2023-10-01T10:00:00Z")
+	nextRunAt, _ := time.Parse(time.RFC3339, "2023-11-01T10:00:00Z")
+
+	return http.StatusOK, []dto.TransformedRecurringOperation{
+		{
+			ID:          1,
+			Type:        "expense",
+			Amount:      money.FromFloat(500),
+			Currency:    "ARS",
+			Description: "Rent",
+			Category: dto.TransformedCategory{
+				Name:  "Home",
+				Color: "#6495ed",
+			},
+			Frequency:          "monthly",
+			DayOfMonth:         1,
+			StartDate:          startDate,
+			OccurrencesCreated: 1,
+			NextRunAt:          &nextRunAt,
+		},
+	}
+}
+
+func (m *MockRecurringOperationService) Show(user dao.User, recurringOperationID int) (int, interface{}) {
+	if recurringOperationID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	_, response := m.Index(user)
+	return http.StatusOK, response[0]
+}
+
+func (m *MockRecurringOperationService) Create(user dao.User, recurringOperationRequest dto.RecurringOperationRequest) (int, interface{}) {
+	if recurringOperationRequest.CategoryID == "2" {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid category."}
+	}
+
+	return http.StatusCreated, gin.H{"message": "Recurring operation successfully created."}
+}
+
+func (m *MockRecurringOperationService) Update(user dao.User, recurringOperationRequest dto.RecurringOperationRequest, recurringOperationID int) (int, interface{}) {
+	if recurringOperationID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Recurring operation successfully updated."}
+}
+
+func (m *MockRecurringOperationService) Delete(user dao.User, recurringOperationID int) (int, interface{}) {
+	if recurringOperationID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Recurring operation successfully deleted."}
+}
+
+func TestRecurringOperationHandlerImpl_Index(t *testing.T) {
+	recurringOperationService := &MockRecurringOperationService{}
+	recurringOperationHandler := RecurringOperationHandlerInit(recurringOperationService)
+	serviceUri := "/api/recurring_operations"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the user has recurring operations",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"type\":\"expense\",\"amount\":\"500.00\",\"currency\":\"ARS\",\"description\":\"Rent\",\"category\":{\"name\":\"Home\",\"color\":\"#6495ed\"},\"account_id\":null,\"frequency\":\"monthly\",\"day_of_month\":1,\"start_date\":\"2023-10-01T10:00:00Z\",\"end_date\":null,\"count\":0,\"occurrences_created\":1,\"next_run_at\":\"2023-11-01T10:00:00Z\"}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			recurringOperationHandler.Index(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestRecurringOperationHandlerImpl_Show(t *testing.T) {
+	recurringOperationService := &MockRecurringOperationService{}
+	recurringOperationHandler := RecurringOperationHandlerInit(recurringOperationService)
+	serviceUri := "/api/recurring_operations"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the recurring operation is found",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"type\":\"expense\",\"amount\":\"500.00\",\"currency\":\"ARS\",\"description\":\"Rent\",\"category\":{\"name\":\"Home\",\"color\":\"#6495ed\"},\"account_id\":null,\"frequency\":\"monthly\",\"day_of_month\":1,\"start_date\":\"2023-10-01T10:00:00Z\",\"end_date\":null,\"count\":0,\"occurrences_created\":1,\"next_run_at\":\"2023-11-01T10:00:00Z\"}",
+		},
+		{
+			Name:         "when the recurring operation is not found",
+			Params:       "",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			recurring_operation_id := 1
+
+			if tt.Name == "when the recurring operation is not found" {
+				recurring_operation_id = 2
+			}
+
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{
+				{
+					Key:   "id",
+					Value: strconv.Itoa(recurring_operation_id),
+				},
+			}
+
+			recurringOperationHandler.Show(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestRecurringOperationHandlerImpl_Create(t *testing.T) {
+	recurringOperationService := &MockRecurringOperationService{}
+	recurringOperationHandler := RecurringOperationHandlerInit(recurringOperationService)
+	serviceUri := "/api/recurring_operations"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the recurring operation is created successfully",
+			Params:       `{"type": "expense", "amount": 500, "description": "Rent", "category_id": "1", "frequency": "monthly", "day_of_month": 1, "start_date": "2023-10-01T10:00:00Z", "count": 12}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Recurring operation successfully created.\"}",
+		},
+		{
+			Name:         "when the frequency is invalid",
+			Params:       `{"type": "expense", "amount": 500, "description": "Rent", "category_id": "1", "frequency": "hourly", "start_date": "2023-10-01T10:00:00Z"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the day of month is invalid",
+			Params:       `{"type": "expense", "amount": 500, "description": "Rent", "category_id": "1", "frequency": "monthly", "day_of_month": 32, "start_date": "2023-10-01T10:00:00Z"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the end date is before the start date",
+			Params:       `{"type": "expense", "amount": 500, "description": "Rent", "category_id": "1", "frequency": "monthly", "start_date": "2023-10-01T10:00:00Z", "end_date": "2023-09-01T10:00:00Z"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the amount is invalid",
+			Params:       `{"type": "expense", "amount": 0, "description": "Rent", "category_id": "1", "frequency": "monthly", "start_date": "2023-10-01T10:00:00Z"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the category is invalid",
+			Params:       `{"type": "expense", "amount": 500, "description": "Rent", "category_id": "2", "frequency": "monthly", "start_date": "2023-10-01T10:00:00Z"}`,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid category.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			recurringOperationHandler.Create(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestRecurringOperationHandlerImpl_Update(t *testing.T) {
+	recurringOperationService := &MockRecurringOperationService{}
+	recurringOperationHandler := RecurringOperationHandlerInit(recurringOperationService)
+	serviceUri := "/api/recurring_operations"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the recurring operation is updated successfully",
+			Params:       `{"type": "expense", "amount": 550, "description": "Rent", "category_id": "1", "frequency": "monthly", "start_date": "2023-10-01T10:00:00Z"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Recurring operation successfully updated.\"}",
+		},
+		{
+			Name:         "when the recurring operation is not found",
+			Params:       `{"type": "expense", "amount": 550, "description": "Rent", "category_id": "1", "frequency": "monthly", "start_date": "2023-10-01T10:00:00Z"}`,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the start date is invalid",
+			Params:       `{"type": "expense", "amount": 550, "description": "Rent", "category_id": "1", "frequency": "monthly", "start_date": ""}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			recurring_operation_id := 1
+
+			if tt.Name == "when the recurring operation is not found" {
+				recurring_operation_id = 2
+			}
+
+			ctx, responseRecorder := testhelpers.MockPutRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{
+				{
+					Key:   "id",
+					Value: strconv.Itoa(recurring_operation_id),
+				},
+			}
+
+			recurringOperationHandler.Update(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestRecurringOperationHandlerImpl_Delete(t *testing.T) {
+	recurringOperationService := &MockRecurringOperationService{}
+	recurringOperationHandler := RecurringOperationHandlerInit(recurringOperationService)
+	serviceUri := "/api/recurring_operations"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the recurring operation is found",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Recurring operation successfully deleted.\"}",
+		},
+		{
+			Name:         "when the recurring operation is not found",
+			Params:       "",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			recurring_operation_id := 1
+
+			if tt.Name == "when the recurring operation is not found" {
+				recurring_operation_id = 2
+			}
+
+			ctx, responseRecorder := testhelpers.MockDeleteRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{
+				{
+					Key:   "id",
+					Value: strconv.Itoa(recurring_operation_id),
+				},
+			}
+
+			recurringOperationHandler.Delete(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler interface {
+	Categories(ctx *gin.Context)
+}
+
+type ReportHandlerImpl struct {
+	svc services.ReportService
+}
+
+func (u ReportHandlerImpl) Categories(ctx *gin.Context) {
+	var reportFilter dto.CategoryReportFilter
+	validationError := ctx.ShouldBindQuery(&reportFilter)
+	if validationError != nil || invalidCategoryReportFilter(reportFilter) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Categories(ParseUserFromContext(ctx), reportFilter)
+	ctx.JSON(code, response)
+}
+
+func invalidCategoryReportFilter(reportFilter dto.CategoryReportFilter) bool {
+	if reportFilter.Type != "" && reportFilter.Type != services.INCOME_TYPE && reportFilter.Type != services.EXPENSE_TYPE {
+		return true
+	}
+	return reportFilter.From != nil && reportFilter.To != nil && reportFilter.From.After(*reportFilter.To)
+}
+
+func ReportHandlerInit(reportService services.ReportService) *ReportHandlerImpl {
+	return &ReportHandlerImpl{
+		svc: reportService,
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"testing"
+)
+
+type MockReportService struct{}
+
+func (m *MockReportService) Categories(user dao.User, reportFilter dto.CategoryReportFilter) (int, interface{}) {
+	return http.StatusOK, dto.CategoryReportResponse{
+		Type: reportFilter.Type,
+		Currencies: []dto.CategoryReportCurrency{
+			{
+				Currency:      "ARS",
+				Total:         money.FromFloat(3500),
+				Categories:    []dto.CategoryReportLine{{CategoryID: 1, Name: "Work", Color: "#fdg123", IsDefault: true, Total: money.FromFloat(3500), Count: 2, Percentage: 100}},
+				Uncategorized: dto.CategoryReportAmount{},
+			},
+		},
+	}
+}
+
+func TestReportHandlerImpl_Categories(t *testing.T) {
+	reportHandler := ReportHandlerInit(&MockReportService{})
+	serviceUri := "/api/reports/categories"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the report is listed",
+			Params:       "?from=2023-10-01T00:00:00Z&to=2023-10-31T23:59:59Z&type=expense",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"type\":\"expense\",\"currencies\":[{\"currency\":\"ARS\",\"total\":\"3500.00\",\"categories\":[{\"category_id\":1,\"name\":\"Work\",\"color\":\"#fdg123\",\"is_default\":true,\"total\":\"3500.00\",\"count\":2,\"percentage\":100}],\"uncategorized\":{\"total\":\"0.00\",\"count\":0,\"percentage\":0}}]}",
+		},
+		{
+			Name:         "when the type is invalid",
+			Params:       "?type=transfer",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the period is reversed",
+			Params:       "?from=2023-10-31T00:00:00Z&to=2023-10-01T00:00:00Z",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the date is invalid",
+			Params:       "?from=yesterday",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri + tt.Params)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			reportHandler.Categories(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/services"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RuleHandler interface {
+	Index(ctx *gin.Context)
+	Show(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type RuleHandlerImpl struct {
+	svc services.RuleService
+}
+
+func (u RuleHandlerImpl) Index(ctx *gin.Context) {
+	code, response := u.svc.Index(ParseUserFromContext(ctx))
+	ctx.JSON(code, response)
+}
+
+func (u RuleHandlerImpl) Show(ctx *gin.Context) {
+	ruleID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Show(ParseUserFromContext(ctx), ruleID)
+	ctx.JSON(code, response)
+}
+
+func (u RuleHandlerImpl) Create(ctx *gin.Context) {
+	var ruleRequest dto.RuleRequest
+	validationError := ctx.ShouldBindJSON(&ruleRequest)
+	if validationError != nil || invalidRule(ruleRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Create(ParseUserFromContext(ctx), ruleRequest)
+	ctx.JSON(code, response)
+}
+
+func (u RuleHandlerImpl) Update(ctx *gin.Context) {
+	ruleID, _ := strconv.Atoi(ctx.Param("id"))
+	var ruleRequest dto.RuleRequest
+	validationError := ctx.ShouldBindJSON(&ruleRequest)
+	if validationError != nil || invalidRule(ruleRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Update(ParseUserFromContext(ctx), ruleRequest, ruleID)
+	ctx.JSON(code, response)
+}
+
+func (u RuleHandlerImpl) Delete(ctx *gin.Context) {
+	ruleID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Delete(ParseUserFromContext(ctx), ruleID)
+	ctx.JSON(code, response)
+}
+
+// invalidRule checks that the rule has a name, at least one condition and at
+// least one change, and that its regular expression compiles.
+func invalidRule(request dto.RuleRequest) bool {
+	if strings.TrimSpace(request.Name) == "" {
+		return true
+	}
+	if request.DescriptionContains == "" && request.DescriptionRegex == "" && request.Type == "" &&
+		request.MinAmount == nil && request.MaxAmount == nil {
+		return true
+	}
+	if request.SetCategoryID == "" && len(request.AddTags) == 0 && request.SetDescription == "" {
+		return true
+	}
+	if request.Type != "" && request.Type != "income" && request.Type != "expense" {
+		return true
+	}
+	if request.DescriptionRegex != "" {
+		if _, err := regexp.Compile(request.DescriptionRegex); err != nil {
+			return true
+		}
+	}
+	if (request.MinAmount != nil && *request.MinAmount < 0) || (request.MaxAmount != nil && *request.MaxAmount < 0) {
+		return true
+	}
+	if request.MinAmount != nil && request.MaxAmount != nil && *request.MinAmount > *request.MaxAmount {
+		return true
+	}
+	if _, err := strconv.Atoi(request.SetCategoryID); request.SetCategoryID != "" && err != nil {
+		return true
+	}
+	return invalidTags(dto.OperationRequest{Tags: request.AddTags})
+}
+
+func RuleHandlerInit(ruleService services.RuleService) *RuleHandlerImpl {
+	return &RuleHandlerImpl{
+		svc: ruleService,
+	}
+}
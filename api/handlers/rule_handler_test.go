@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockRuleService struct{}
+
+func (m *MockRuleService) Index(user dao.User) (int, interface{}) {
+	categoryID := 1
+	return http.StatusOK, []dto.TransformedRule{
+		{ID: 1, Name: "Uber rides", DescriptionRegex: `(?i)^uber\b`, Type: "expense", SetCategoryID: &categoryID, AddTags: []string{"transport"}, SetDescription: "Uber"},
+	}
+}
+
+func (m *MockRuleService) Show(user dao.User, ruleID int) (int, interface{}) {
+	if ruleID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	_, response := m.Index(user)
+	return http.StatusOK, response.([]dto.TransformedRule)[0]
+}
+
+func (m *MockRuleService) Create(user dao.User, ruleRequest dto.RuleRequest) (int, interface{}) {
+	return http.StatusCreated, gin.H{"message": "Rule successfully created."}
+}
+
+func (m *MockRuleService) Update(user dao.User, ruleRequest dto.RuleRequest, ruleID int) (int, interface{}) {
+	if ruleID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Rule successfully updated."}
+}
+
+func (m *MockRuleService) Delete(user dao.User, ruleID int) (int, interface{}) {
+	if ruleID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Rule successfully deleted."}
+}
+
+func TestRuleHandlerImpl_Index(t *testing.T) {
+	ruleHandler := RuleHandlerInit(&MockRuleService{})
+	serviceUri := "/api/rules"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the user has rules",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"name\":\"Uber rides\",\"priority\":0,\"description_contains\":\"\",\"description_regex\":\"(?i)^uber\\\\b\",\"type\":\"expense\",\"min_amount\":null,\"max_amount\":null,\"set_category_id\":1,\"add_tags\":[\"transport\"],\"set_description\":\"Uber\"}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ruleHandler.Index(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestRuleHandlerImpl_Show(t *testing.T) {
+	ruleHandler := RuleHandlerInit(&MockRuleService{})
+	serviceUri := "/api/rules"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the rule is found",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"name\":\"Uber rides\",\"priority\":0,\"description_contains\":\"\",\"description_regex\":\"(?i)^uber\\\\b\",\"type\":\"expense\",\"min_amount\":null,\"max_amount\":null,\"set_category_id\":1,\"add_tags\":[\"transport\"],\"set_description\":\"Uber\"}",
+		},
+		{
+			Name:         "when the rule is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			ruleHandler.Show(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestRuleHandlerImpl_Create(t *testing.T) {
+	ruleHandler := RuleHandlerInit(&MockRuleService{})
+	serviceUri := "/api/rules"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the rule is created successfully",
+			Params:       `{"name": "Uber rides", "description_regex": "(?i)^uber\\b", "type": "expense", "max_amount": "100", "set_category_id": "1", "add_tags": ["transport"]}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Rule successfully created.\"}",
+		},
+		{
+			Name:         "when the name is empty",
+			Params:       `{"name": " ", "description_contains": "uber", "set_category_id": "1"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the rule has no conditions",
+			Params:       `{"name": "Everything", "set_category_id": "1"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the rule changes nothing",
+			Params:       `{"name": "Uber rides", "description_contains": "uber"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the regular expression is invalid",
+			Params:       `{"name": "Uber rides", "description_regex": "(uber", "set_category_id": "1"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the amount range is invalid",
+			Params:       `{"name": "Uber rides", "min_amount": "100", "max_amount": "10", "set_category_id": "1"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the type is invalid",
+			Params:       `{"name": "Uber rides", "type": "refund", "set_category_id": "1"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when a tag is invalid",
+			Params:       `{"name": "Uber rides", "description_contains": "uber", "add_tags": [" "]}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ruleHandler.Create(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestRuleHandlerImpl_Update(t *testing.T) {
+	ruleHandler := RuleHandlerInit(&MockRuleService{})
+	serviceUri := "/api/rules"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the rule is updated successfully",
+			Params:       `{"name": "Uber rides", "description_contains": "uber", "set_description": "Uber"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Rule successfully updated.\"}",
+		},
+		{
+			Name:         "when the rule is not found",
+			Params:       `{"name": "Uber rides", "description_contains": "uber", "set_description": "Uber"}`,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the category is not a number",
+			Params:       `{"name": "Uber rides", "description_contains": "uber", "set_category_id": "transport"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ruleID := "1"
+
+			if tt.Name == "when the rule is not found" {
+				ruleID = "2"
+			}
+
+			ctx, responseRecorder := testhelpers.MockPutRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: ruleID}}
+
+			ruleHandler.Update(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestRuleHandlerImpl_Delete(t *testing.T) {
+	ruleHandler := RuleHandlerInit(&MockRuleService{})
+	serviceUri := "/api/rules"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the rule is deleted successfully",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Rule successfully deleted.\"}",
+		},
+		{
+			Name:         "when the rule is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockDeleteRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			ruleHandler.Delete(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler interface {
+	Index(ctx *gin.Context)
+}
+
+type TagHandlerImpl struct {
+	svc services.TagService
+}
+
+func (u TagHandlerImpl) Index(ctx *gin.Context) {
+	code, response := u.svc.Index(ParseUserFromContext(ctx))
+	ctx.JSON(code, response)
+}
+
+func TagHandlerInit(tagService services.TagService) *TagHandlerImpl {
+	return &TagHandlerImpl{
+		svc: tagService,
+	}
+}
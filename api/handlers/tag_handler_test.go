@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"testing"
+)
+
+type MockTagService struct{}
+
+func (m *MockTagService) Index(user dao.User) (int, []dto.TransformedTag) {
+	return http.StatusOK, []dto.TransformedTag{
+		{ID: 1, Name: "reimbursable", OperationsCount: 3},
+	}
+}
+
+func TestTagHandlerImpl_Index(t *testing.T) {
+	tagHandler := TagHandlerInit(&MockTagService{})
+	serviceUri := "/api/tags"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the user has tags",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"name\":\"reimbursable\",\"operations_count\":3}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+			ctx.Set("user", dao.User{ID: 1})
+
+			tagHandler.Index(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferHandler interface {
+	Index(ctx *gin.Context)
+	Show(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type TransferHandlerImpl struct {
+	svc services.TransferService
+}
+
+func (u TransferHandlerImpl) Index(ctx *gin.Context) {
+	code, response := u.svc.Index(ParseUserFromContext(ctx))
+	ctx.JSON(code, response)
+}
+
+func (u TransferHandlerImpl) Show(ctx *gin.Context) {
+	transferID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Show(ParseUserFromContext(ctx), transferID)
+	ctx.JSON(code, response)
+}
+
+func (u TransferHandlerImpl) Create(ctx *gin.Context) {
+	var transferRequest dto.TransferRequest
+	validationError := ctx.ShouldBindJSON(&transferRequest)
+	if validationError != nil || invalidTransfer(transferRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Create(ParseUserFromContext(ctx), transferRequest)
+	ctx.JSON(code, response)
+}
+
+func (u TransferHandlerImpl) Update(ctx *gin.Context) {
+	transferID, _ := strconv.Atoi(ctx.Param("id"))
+	var transferRequest dto.TransferRequest
+	validationError := ctx.ShouldBindJSON(&transferRequest)
+	if validationError != nil || invalidTransfer(transferRequest) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.Update(ParseUserFromContext(ctx), transferRequest, transferID)
+	ctx.JSON(code, response)
+}
+
+func (u TransferHandlerImpl) Delete(ctx *gin.Context) {
+	transferID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.Delete(ParseUserFromContext(ctx), transferID)
+	ctx.JSON(code, response)
+}
+
+func invalidTransfer(request dto.TransferRequest) bool {
+	if request.FromAccountID <= 0 || request.ToAccountID <= 0 || request.FromAccountID == request.ToAccountID {
+		return true
+	}
+	if request.Amount <= 0 || request.ToAmount < 0 {
+		return true
+	}
+	parsedDate, err := time.Parse(time.RFC3339, request.Date)
+	return err != nil || parsedDate.After(time.Now())
+}
+
+func TransferHandlerInit(transferService services.TransferService) *TransferHandlerImpl {
+	return &TransferHandlerImpl{
+		svc: transferService,
+	}
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockTransferService struct{}
+
+func (m *MockTransferService) Index(user dao.User) (int, []dto.TransformedTransfer) {
+	date, _ := time.Parse(time.RFC3339, "2023-10-23T21:33:03Z")
+
+	return http.StatusOK, []dto.TransformedTransfer{
+		{
+			ID:            1,
+			FromAccountID: 1,
+			ToAccountID:   2,
+			Amount:        money.FromFloat(3500),
+			ToAmount:      money.FromFloat(10),
+			Date:          date,
+			Description:   "Savings",
+		},
+	}
+}
+
+func (m *MockTransferService) Show(user dao.User, transferID int) (int, interface{}) {
+	if transferID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	_, response := m.Index(user)
+	return http.StatusOK, response[0]
+}
+
+func (m *MockTransferService) Create(user dao.User, transferRequest dto.TransferRequest) (int, interface{}) {
+	return http.StatusCreated, gin.H{"message": "Transfer successfully created."}
+}
+
+func (m *MockTransferService) Update(user dao.User, transferRequest dto.TransferRequest, transferID int) (int, interface{}) {
+	if transferID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Transfer successfully updated."}
+}
+
+func (m *MockTransferService) Delete(user dao.User, transferID int) (int, interface{}) {
+	if transferID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Transfer successfully deleted."}
+}
+
+func TestTransferHandlerImpl_Index(t *testing.T) {
+	transferHandler := TransferHandlerInit(&MockTransferService{})
+	serviceUri := "/api/transfers"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the user has transfers",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"from_account_id\":1,\"to_account_id\":2,\"amount\":\"3500.00\",\"to_amount\":\"10.00\",\"date\":\"2023-10-23T21:33:03Z\",\"description\":\"Savings\"}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			transferHandler.Index(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestTransferHandlerImpl_Show(t *testing.T) {
+	transferHandler := TransferHandlerInit(&MockTransferService{})
+	serviceUri := "/api/transfers"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the transfer is found",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"from_account_id\":1,\"to_account_id\":2,\"amount\":\"3500.00\",\"to_amount\":\"10.00\",\"date\":\"2023-10-23T21:33:03Z\",\"description\":\"Savings\"}",
+		},
+		{
+			Name:         "when the transfer is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			transferHandler.Show(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestTransferHandlerImpl_Create(t *testing.T) {
+	transferHandler := TransferHandlerInit(&MockTransferService{})
+	serviceUri := "/api/transfers"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the transfer is created successfully",
+			Params:       `{"from_account_id": 1, "to_account_id": 2, "amount": 3500, "to_amount": 10, "date": "2023-10-23T21:33:03Z", "description": "Savings"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Transfer successfully created.\"}",
+		},
+		{
+			Name:         "when both accounts are the same",
+			Params:       `{"from_account_id": 1, "to_account_id": 1, "amount": 3500, "date": "2023-10-23T21:33:03Z"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the amount is invalid",
+			Params:       `{"from_account_id": 1, "to_account_id": 2, "amount": 0, "date": "2023-10-23T21:33:03Z"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the date is invalid",
+			Params:       `{"from_account_id": 1, "to_account_id": 2, "amount": 3500, "date": "2023-10-23"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			transferHandler.Create(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestTransferHandlerImpl_Update(t *testing.T) {
+	transferHandler := TransferHandlerInit(&MockTransferService{})
+	serviceUri := "/api/transfers"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the transfer is updated successfully",
+			Params:       `{"from_account_id": 1, "to_account_id": 2, "amount": 3500, "to_amount": 10, "date": "2023-10-23T21:33:03Z", "description": "Savings"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Transfer successfully updated.\"}",
+		},
+		{
+			Name:         "when the transfer is not found",
+			Params:       `{"from_account_id": 1, "to_account_id": 2, "amount": 3500, "to_amount": 10, "date": "2023-10-23T21:33:03Z", "description": "Savings"}`,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the origin account is missing",
+			Params:       `{"to_account_id": 2, "amount": 3500, "date": "2023-10-23T21:33:03Z"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			transfer_id := 1
+
+			if tt.Name == "when the transfer is not found" {
+				transfer_id = 2
+			}
+
+			ctx, responseRecorder := testhelpers.MockPutRequest(tt.Params, serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{
+				{
+					Key:   "id",
+					Value: strconv.Itoa(transfer_id),
+				},
+			}
+
+			transferHandler.Update(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestTransferHandlerImpl_Delete(t *testing.T) {
+	transferHandler := TransferHandlerInit(&MockTransferService{})
+	serviceUri := "/api/transfers"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the transfer is found",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Transfer successfully deleted.\"}",
+		},
+		{
+			Name:         "when the transfer is not found",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockDeleteRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			transferHandler.Delete(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
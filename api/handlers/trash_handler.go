@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler interface {
+	Index(ctx *gin.Context)
+	RestoreOperation(ctx *gin.Context)
+	RestoreCategory(ctx *gin.Context)
+	PurgeOperation(ctx *gin.Context)
+	PurgeCategory(ctx *gin.Context)
+}
+
+type TrashHandlerImpl struct {
+	svc services.TrashService
+}
+
+func (u TrashHandlerImpl) Index(ctx *gin.Context) {
+	code, response := u.svc.Index(ParseUserFromContext(ctx))
+	ctx.JSON(code, response)
+}
+
+func (u TrashHandlerImpl) RestoreOperation(ctx *gin.Context) {
+	operationID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.RestoreOperation(ParseUserFromContext(ctx), operationID)
+	ctx.JSON(code, response)
+}
+
+func (u TrashHandlerImpl) RestoreCategory(ctx *gin.Context) {
+	categoryID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.RestoreCategory(ParseUserFromContext(ctx), categoryID)
+	ctx.JSON(code, response)
+}
+
+func (u TrashHandlerImpl) PurgeOperation(ctx *gin.Context) {
+	operationID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.PurgeOperation(ParseUserFromContext(ctx), operationID)
+	ctx.JSON(code, response)
+}
+
+func (u TrashHandlerImpl) PurgeCategory(ctx *gin.Context) {
+	categoryID, _ := strconv.Atoi(ctx.Param("id"))
+	code, response := u.svc.PurgeCategory(ParseUserFromContext(ctx), categoryID)
+	ctx.JSON(code, response)
+}
+
+func TrashHandlerInit(trashService services.TrashService) *TrashHandlerImpl {
+	return &TrashHandlerImpl{
+		svc: trashService,
+	}
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockTrashService struct{}
+
+func (m *MockTrashService) Index(user dao.User) (int, interface{}) {
+	return http.StatusOK, dto.TransformedTrash{
+		Operations: []dto.TrashedOperation{},
+		Categories: []dto.TrashedCategory{
+			{ID: 2, Name: "Custom", Color: "#6495ed", Description: "Custom", DeletedAt: time.Date(2023, 10, 24, 10, 0, 0, 0, time.UTC)},
+		},
+	}
+}
+
+func (m *MockTrashService) RestoreOperation(user dao.User, operationID int) (int, interface{}) {
+	if operationID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	if operationID == 3 {
+		return http.StatusUnprocessableEntity, gin.H{"error": "The category of the operation is in the trash."}
+	}
+	return http.StatusOK, gin.H{"message": "Operation successfully restored."}
+}
+
+func (m *MockTrashService) RestoreCategory(user dao.User, categoryID int) (int, interface{}) {
+	if categoryID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Category successfully restored."}
+}
+
+func (m *MockTrashService) PurgeOperation(user dao.User, operationID int) (int, interface{}) {
+	if operationID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, gin.H{"message": "Operation successfully purged."}
+}
+
+func (m *MockTrashService) PurgeCategory(user dao.User, categoryID int) (int, interface{}) {
+	if categoryID == 2 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	if categoryID == 3 {
+		return http.StatusUnprocessableEntity, gin.H{"error": "The category is still used by operations."}
+	}
+	return http.StatusOK, gin.H{"message": "Category successfully purged."}
+}
+
+func TestTrashHandlerImpl_Index(t *testing.T) {
+	trashHandler := TrashHandlerInit(&MockTrashService{})
+	serviceUri := "/api/trash"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the user has items in the trash",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[],\"categories\":[{\"id\":2,\"name\":\"Custom\",\"color\":\"#6495ed\",\"description\":\"Custom\",\"deleted_at\":\"2023-10-24T10:00:00Z\"}]}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+
+			trashHandler.Index(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestTrashHandlerImpl_RestoreOperation(t *testing.T) {
+	trashHandler := TrashHandlerInit(&MockTrashService{})
+	serviceUri := "/api/trash/operations/1/restore"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operation is restored",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully restored.\"}",
+		},
+		{
+			Name:         "when the operation is not in the trash",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the category of the operation is in the trash",
+			Params:       "3",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The category of the operation is in the trash.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest("", serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			trashHandler.RestoreOperation(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestTrashHandlerImpl_RestoreCategory(t *testing.T) {
+	trashHandler := TrashHandlerInit(&MockTrashService{})
+	serviceUri := "/api/trash/categories/1/restore"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the category is restored",
+			Params:       "1",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Category successfully restored.\"}",
+		},
+		{
+			Name:         "when the category is not in the trash",
+			Params:       "2",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPostRequest("", serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{{Key: "id", Value: tt.Params}}
+
+			trashHandler.RestoreCategory(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestTrashHandlerImpl_PurgeOperation(t *testing.T) {
+	trashHandler := TrashHandlerInit(&MockTrashService{})
+	serviceUri := "/api/trash/operations/1"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operation is purged",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully purged.\"}",
+		},
+		{
+			Name:         "when the operation is not in the trash",
+			Params:       "",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for index, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockDeleteRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{{Key: "id", Value: strconv.Itoa(index + 1)}}
+
+			trashHandler.PurgeOperation(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestTrashHandlerImpl_PurgeCategory(t *testing.T) {
+	trashHandler := TrashHandlerInit(&MockTrashService{})
+	serviceUri := "/api/trash/categories/1"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the category is purged",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Category successfully purged.\"}",
+		},
+		{
+			Name:         "when the category is not in the trash",
+			Params:       "",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the category is still used by operations",
+			Params:       "",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The category is still used by operations.\"}",
+		},
+	}
+	for index, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockDeleteRequest(serviceUri)
+
+			ctx.Set("user", dao.User{ID: 1})
+			ctx.Params = []gin.Param{{Key: "id", Value: strconv.Itoa(index + 1)}}
+
+			trashHandler.PurgeCategory(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
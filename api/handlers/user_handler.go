@@ -4,6 +4,7 @@ import (
 	"GoGin-API-CuentasClaras/dto"
 	"GoGin-API-CuentasClaras/services"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,6 +14,8 @@ type UserHandler interface {
 	LoginUser(c *gin.Context)
 	CurrentUser(c *gin.Context)
 	BalanceUser(ctx *gin.Context)
+	UpdateBaseCurrency(ctx *gin.Context)
+	BalanceHistory(ctx *gin.Context)
 }
 
 type UserHandlerImpl struct {
@@ -46,10 +49,57 @@ func (u UserHandlerImpl) CurrentUser(ctx *gin.Context) {
 }
 
 func (u UserHandlerImpl) BalanceUser(ctx *gin.Context) {
-	code, response := u.svc.BalanceUser(ParseUserFromContext(ctx))
+	date, invalid := parseBalanceDate(ctx.Query("date"))
+	if invalid {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.BalanceUser(ParseUserFromContext(ctx), date)
+	ctx.JSON(code, response)
+}
+
+func (u UserHandlerImpl) UpdateBaseCurrency(ctx *gin.Context) {
+	var baseCurrencyRequest dto.BaseCurrencyRequest
+	validationError := ctx.ShouldBindJSON(&baseCurrencyRequest)
+	if validationError != nil || invalidCurrencyCode(baseCurrencyRequest.BaseCurrency) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.UpdateBaseCurrency(ParseUserFromContext(ctx), baseCurrencyRequest)
+	ctx.JSON(code, response)
+}
+
+func (u UserHandlerImpl) BalanceHistory(ctx *gin.Context) {
+	var historyFilter dto.BalanceHistoryFilter
+	validationError := ctx.ShouldBindQuery(&historyFilter)
+	if validationError != nil || invalidBalanceHistoryFilter(historyFilter) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+		return
+	}
+	code, response := u.svc.BalanceHistory(ParseUserFromContext(ctx), historyFilter)
 	ctx.JSON(code, response)
 }
 
+func invalidBalanceHistoryFilter(historyFilter dto.BalanceHistoryFilter) bool {
+	switch historyFilter.Interval {
+	case "", dto.IntervalDay, dto.IntervalWeek, dto.IntervalMonth:
+	default:
+		return true
+	}
+	return historyFilter.From != nil && historyFilter.To != nil && historyFilter.From.After(*historyFilter.To)
+}
+
+func parseBalanceDate(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Now().In(time.UTC), false
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		date, err = time.Parse(time.RFC3339, value)
+	}
+	return date, err != nil
+}
+
 func UserHandlerInit(userService services.UserService) *UserHandlerImpl {
 	return &UserHandlerImpl{
 		svc: userService,
@@ -3,9 +3,11 @@ package handlers
 import (
 	"GoGin-API-CuentasClaras/dao"
 	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
 	testhelpers "GoGin-API-CuentasClaras/test_helpers"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -40,12 +42,37 @@ func (m *MockUserService) CurrentUser(user dao.User) (int, map[string]any) {
 	return http.StatusOK, gin.H{"username": "test.user", "email": "test@example.com"}
 }
 
-func (m *MockUserService) BalanceUser(user dao.User) (int, interface{}) {
+func (m *MockUserService) BalanceUser(user dao.User, date time.Time) (int, interface{}) {
 	if user.ID != 1 {
 		return http.StatusUnauthorized, gin.H{"error": "Not authorized"}
 	}
 
-	return http.StatusOK, gin.H{"total_balance": "100.50"}
+	return http.StatusOK, dto.BalanceResponse{
+		TotalBalance: "100.50",
+		BaseCurrency: "ARS",
+		Date:         date.Format("2006-01-02"),
+		Balances:     []dto.CurrencyBalance{{Currency: "ARS", Balance: "100.50"}},
+		Accounts:     []dto.AccountBalance{{ID: 1, Name: "Default", Currency: "ARS", Balance: "100.50"}},
+	}
+}
+
+func (m *MockUserService) UpdateBaseCurrency(user dao.User, baseCurrencyRequest dto.BaseCurrencyRequest) (int, interface{}) {
+	if baseCurrencyRequest.BaseCurrency == "EUR" {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the base currency."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Base currency successfully updated."}
+}
+
+func (m *MockUserService) BalanceHistory(user dao.User, historyFilter dto.BalanceHistoryFilter) (int, interface{}) {
+	return http.StatusOK, dto.BalanceHistoryResponse{
+		Interval: historyFilter.Interval,
+		From:     historyFilter.From.Format("2006-01-02"),
+		To:       historyFilter.To.Format("2006-01-02"),
+		Series: []dto.BalanceHistorySeries{
+			{Currency: "ARS", Points: []dto.BalanceHistoryPoint{{Date: "2023-10-01", Income: money.FromFloat(1200.5), Expense: money.FromFloat(100), Balance: money.FromFloat(1100.5)}}},
+		},
+	}
 }
 
 func TestUserHandlerImpl_RegisterUser(t *testing.T) {
@@ -213,14 +240,20 @@ func TestUserHandlerImpl_BalanceUser(t *testing.T) {
 	var tests = []testhelpers.TestStructure{
 		{
 			Name:         "when the request is successful",
-			Params:       "",
+			Params:       "?date=2023-10-24",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "{\"total_balance\":\"100.50\"}",
+			ExpectedBody: "{\"total_balance\":\"100.50\",\"base_currency\":\"ARS\",\"date\":\"2023-10-24\",\"balances\":[{\"currency\":\"ARS\",\"balance\":\"100.50\"}],\"accounts\":[{\"id\":1,\"name\":\"Default\",\"currency\":\"ARS\",\"balance\":\"100.50\"}]}",
+		},
+		{
+			Name:         "when the date is invalid",
+			Params:       "?date=tomorrow",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
-			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri)
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri + tt.Params)
 			ctx.Set("user", dao.User{ID: 1})
 
 			userHandler.BalanceUser(ctx)
@@ -229,3 +262,83 @@ func TestUserHandlerImpl_BalanceUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandlerImpl_UpdateBaseCurrency(t *testing.T) {
+	userService := &MockUserService{}
+	userHandler := UserHandlerInit(userService)
+	serviceUri := "/api/users/base_currency"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the request is successful",
+			Params:       `{"base_currency": "USD"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Base currency successfully updated.\"}",
+		},
+		{
+			Name:         "when the currency is invalid",
+			Params:       `{"base_currency": "DOLLAR"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when there is an error in the update",
+			Params:       `{"base_currency": "EUR"}`,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the base currency.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockPutRequest(tt.Params, serviceUri)
+			ctx.Set("user", dao.User{ID: 1})
+
+			userHandler.UpdateBaseCurrency(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
+
+func TestUserHandlerImpl_BalanceHistory(t *testing.T) {
+	userService := &MockUserService{}
+	userHandler := UserHandlerInit(userService)
+	serviceUri := "/api/users/balance/history"
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the request is successful",
+			Params:       "?from=2023-10-01T00:00:00Z&to=2023-10-31T23:59:59Z&interval=month",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"interval\":\"month\",\"from\":\"2023-10-01\",\"to\":\"2023-10-31\",\"series\":[{\"currency\":\"ARS\",\"points\":[{\"date\":\"2023-10-01\",\"income\":\"1200.50\",\"expense\":\"100.00\",\"balance\":\"1100.50\"}]}]}",
+		},
+		{
+			Name:         "when the interval is invalid",
+			Params:       "?interval=year",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the period is reversed",
+			Params:       "?from=2023-10-31T00:00:00Z&to=2023-10-01T00:00:00Z",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the date is invalid",
+			Params:       "?from=yesterday",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx, responseRecorder := testhelpers.MockGetRequest(serviceUri + tt.Params)
+			ctx.Set("user", dao.User{ID: 1})
+
+			userHandler.BalanceHistory(ctx)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+}
@@ -46,6 +46,9 @@ func (u MockUserRepository) FindUserById(id int) (dao.User, error) {
 }
 func (u MockUserRepository) FindUserByEmail(email string) (dao.User, error) { return dao.User{}, nil }
 func (u MockUserRepository) Save(user *dao.User) (dao.User, error)          { return dao.User{}, nil }
+func (u MockUserRepository) UpdateBaseCurrency(user dao.User, baseCurrency string) error {
+	return nil
+}
 
 func TestAuthMiddleware(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"GoGin-API-CuentasClaras/config"
+	"GoGin-API-CuentasClaras/dao"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const DEFAULT_IDEMPOTENCY_KEY_TTL time.Duration = 24 * time.Hour
+const MAX_IDEMPOTENCY_KEY_LENGTH int = 255
+
+// MAX_IDEMPOTENT_REQUEST_SIZE bounds the body, in bytes, kept in memory to
+// identify a request. It leaves room for the largest upload the routes take.
+const MAX_IDEMPOTENT_REQUEST_SIZE int64 = 16 << 20
+
+// IdempotencyMiddleware makes requests with an Idempotency-Key header safe to
+// retry. The first response for a user's key is stored, and a request with the
+// same key and payload gets it back instead of being handled again. It goes
+// after AuthMiddleware, keys belong to the user.
+func IdempotencyMiddleware(initConfig *config.Initialization) gin.HandlerFunc {
+	ttl, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_KEY_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = DEFAULT_IDEMPOTENCY_KEY_TTL
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		user, found := c.Get("user")
+		userStruct, ok := user.(dao.User)
+		if key == "" || !found || !ok {
+			c.Next()
+			return
+		}
+		if len(key) > MAX_IDEMPOTENCY_KEY_LENGTH {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid idempotency key."})
+			return
+		}
+
+		body, readError := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MAX_IDEMPOTENT_REQUEST_SIZE))
+		var maxBytesError *http.MaxBytesError
+		if errors.As(readError, &maxBytesError) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "The request exceeds the maximum size."})
+			return
+		}
+		if readError != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters."})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := time.Now()
+		idempotencyKey := dao.IdempotencyKey{
+			UserID:      uint(userStruct.ID),
+			Key:         key,
+			RequestHash: requestHash(c.Request, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+		}
+		reserved, recordError := initConfig.IdempotencyKeyRepo.Reserve(&idempotencyKey)
+		if recordError != nil {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while checking the idempotency key."})
+			return
+		}
+		if !reserved {
+			replayResponse(c, initConfig, userStruct, idempotencyKey)
+			return
+		}
+
+		writer := &idempotencyResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		completed := false
+		defer func() {
+			if !completed {
+				initConfig.IdempotencyKeyRepo.Release(&idempotencyKey)
+			}
+		}()
+
+		c.Next()
+
+		// Server errors are not stored, the request can be retried with the
+		// same key.
+		if writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+		idempotencyKey.StatusCode = writer.Status()
+		idempotencyKey.ContentType = writer.Header().Get("Content-Type")
+		idempotencyKey.Body = writer.body.String()
+		_, recordError = initConfig.IdempotencyKeyRepo.Complete(&idempotencyKey)
+		completed = recordError == nil
+	}
+}
+
+func replayResponse(c *gin.Context, initConfig *config.Initialization, user dao.User, idempotencyKey dao.IdempotencyKey) {
+	stored, recordError := initConfig.IdempotencyKeyRepo.FindIdempotencyKeyByUserAndKey(user, idempotencyKey.Key)
+	if recordError != nil {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while checking the idempotency key."})
+		return
+	}
+	if stored.RequestHash != idempotencyKey.RequestHash {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "The idempotency key was already used with a different request."})
+		return
+	}
+	if stored.StatusCode == 0 {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A request with the same idempotency key is in progress."})
+		return
+	}
+	c.Header("Idempotent-Replayed", "true")
+	c.Data(stored.StatusCode, stored.ContentType, []byte(stored.Body))
+	c.Abort()
+}
+
+// requestHash identifies the payload of a request by its method, path and
+// body. Multipart bodies are identified by their fields and files, the
+// boundary changes every time a client builds the request.
+func requestHash(request *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(request.Method + " " + request.URL.Path + "\n"))
+	if !writeMultipartParts(hash, request.Header.Get("Content-Type"), body) {
+		hash.Write(body)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// writeMultipartParts writes the name, file name and content of every part of a
+// multipart body. It returns false when the body is not a valid multipart one.
+func writeMultipartParts(writer io.Writer, contentType string, body []byte) bool {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		return false
+	}
+	var parts bytes.Buffer
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return false
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return false
+		}
+		for _, field := range [][]byte{[]byte(part.FormName()), []byte(part.FileName()), content} {
+			binary.Write(&parts, binary.BigEndian, uint64(len(field)))
+			parts.Write(field)
+		}
+	}
+	writer.Write(parts.Bytes())
+	return true
+}
+
+// idempotencyResponseWriter keeps a copy of the response body as it is
+// written.
+type idempotencyResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyResponseWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyResponseWriter) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"GoGin-API-CuentasClaras/config"
+	"GoGin-API-CuentasClaras/dao"
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type MockIdempotencyKeyRepository struct {
+	keys     map[string]dao.IdempotencyKey
+	released int
+}
+
+func (u *MockIdempotencyKeyRepository) Reserve(idempotencyKey *dao.IdempotencyKey) (bool, error) {
+	if idempotencyKey.Key == "database-error" {
+		return false, errors.New("Database error.")
+	}
+	if _, found := u.keys[idempotencyKey.Key]; found {
+		return false, nil
+	}
+	idempotencyKey.ID = len(u.keys) + 1
+	u.keys[idempotencyKey.Key] = *idempotencyKey
+	return true, nil
+}
+
+func (u *MockIdempotencyKeyRepository) FindIdempotencyKeyByUserAndKey(user dao.User, key string) (dao.IdempotencyKey, error) {
+	return u.keys[key], nil
+}
+
+func (u *MockIdempotencyKeyRepository) Complete(idempotencyKey *dao.IdempotencyKey) (dao.IdempotencyKey, error) {
+	u.keys[idempotencyKey.Key] = *idempotencyKey
+	return *idempotencyKey, nil
+}
+
+func (u *MockIdempotencyKeyRepository) Release(idempotencyKey *dao.IdempotencyKey) (dao.IdempotencyKey, error) {
+	delete(u.keys, idempotencyKey.Key)
+	u.released++
+	return *idempotencyKey, nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repository := &MockIdempotencyKeyRepository{keys: map[string]dao.IdempotencyKey{
+		"in-progress": {Key: "in-progress", RequestHash: requestHash(httptest.NewRequest("POST", "/api/operations", nil), []byte{})},
+	}}
+	config := &config.Initialization{IdempotencyKeyRepo: repository}
+	created := 0
+
+	router := gin.New()
+	setUser := func(c *gin.Context) { c.Set("user", dao.User{ID: 1}) }
+	router.POST("/api/operations", setUser, IdempotencyMiddleware(config), func(c *gin.Context) {
+		if c.GetHeader("X-Fail") != "" {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+		created++
+		c.JSON(http.StatusCreated, gin.H{"message": "Operation successfully created.", "id": created})
+	})
+
+	send := func(key string, body string, headers ...string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest("POST", "/api/operations", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			request.Header.Set("Idempotency-Key", key)
+		}
+		for _, header := range headers {
+			request.Header.Set(header, "true")
+		}
+		responseRecorder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecorder, request)
+		return responseRecorder
+	}
+
+	t.Run("First request", func(t *testing.T) {
+		w := send("key-1", `{"amount": "10"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, `{"id":1,"message":"Operation successfully created."}`, w.Body.String())
+		assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, http.StatusCreated, repository.keys["key-1"].StatusCode)
+	})
+
+	t.Run("Repeated request", func(t *testing.T) {
+		w := send("key-1", `{"amount": "10"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, `{"id":1,"message":"Operation successfully created."}`, w.Body.String())
+		assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, 1, created)
+	})
+
+	t.Run("Key reused with a different payload", func(t *testing.T) {
+		w := send("key-1", `{"amount": "20"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, `{"error":"The idempotency key was already used with a different request."}`, w.Body.String())
+		assert.Equal(t, 1, created)
+	})
+
+	t.Run("Multipart request retried with a new boundary", func(t *testing.T) {
+		sendFile := func(content string) *httptest.ResponseRecorder {
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			part, _ := writer.CreateFormFile("file", "statement.csv")
+			part.Write([]byte(content))
+			writer.Close()
+			request, _ := http.NewRequest("POST", "/api/operations", &body)
+			request.Header.Set("Content-Type", writer.FormDataContentType())
+			request.Header.Set("Idempotency-Key", "key-3")
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+			return responseRecorder
+		}
+		sendFile("date,amount")
+		w := sendFile("date,amount")
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, 2, created)
+
+		w = sendFile("date,amount,description")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("Request too large", func(t *testing.T) {
+		w := send("key-4", strings.Repeat("a", int(MAX_IDEMPOTENT_REQUEST_SIZE)+1))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Equal(t, `{"error":"The request exceeds the maximum size."}`, w.Body.String())
+		assert.Equal(t, 2, created)
+	})
+
+	t.Run("Request in progress", func(t *testing.T) {
+		w := send("in-progress", "")
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, `{"error":"A request with the same idempotency key is in progress."}`, w.Body.String())
+	})
+
+	t.Run("Server error", func(t *testing.T) {
+		w := send("key-2", `{"amount": "10"}`, "X-Fail")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, 1, repository.released)
+		_, found := repository.keys["key-2"]
+		assert.False(t, found)
+	})
+
+	t.Run("Without key", func(t *testing.T) {
+		send("", `{"amount": "10"}`)
+		w := send("", `{"amount": "10"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 4, created)
+	})
+
+	t.Run("Invalid key", func(t *testing.T) {
+		w := send(strings.Repeat("k", MAX_IDEMPOTENCY_KEY_LENGTH+1), `{"amount": "10"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, `{"error":"Invalid idempotency key."}`, w.Body.String())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		w := send("database-error", `{"amount": "10"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, `{"error":"An error occurred while checking the idempotency key."}`, w.Body.String())
+		assert.Equal(t, 4, created)
+	})
+}
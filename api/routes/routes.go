@@ -21,26 +21,150 @@ func UserRoutes(router *gin.RouterGroup, initConfig *config.Initialization, midd
 		user.POST("/login", initConfig.UserHdler.LoginUser)
 		user.GET("/current", middleware, initConfig.UserHdler.CurrentUser)
 		user.GET("/balance", middleware, initConfig.UserHdler.BalanceUser)
+		user.GET("/balance/history", middleware, initConfig.UserHdler.BalanceHistory)
+		user.PUT("/base_currency", middleware, initConfig.UserHdler.UpdateBaseCurrency)
 	}
 }
 
-func OperationRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc) {
+func OperationRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc, idempotency gin.HandlerFunc) {
 	operation := router.Group("/operations")
 	{
 		operation.GET("", middleware, initConfig.OperationHdler.Index)
+		operation.GET("/search", middleware, initConfig.OperationHdler.Search)
+		operation.GET("/duplicates", middleware, initConfig.OperationHdler.Duplicates)
 		operation.GET("/:id", middleware, initConfig.OperationHdler.Show)
-		operation.POST("", middleware, initConfig.OperationHdler.Create)
+		operation.POST("", middleware, idempotency, initConfig.OperationHdler.Create)
+		operation.POST("/import", middleware, idempotency, initConfig.OperationHdler.Import)
+		operation.GET("/export", middleware, initConfig.OperationHdler.Export)
+		operation.POST("/batch", middleware, idempotency, initConfig.OperationHdler.Batch)
 		operation.PUT("/:id", middleware, initConfig.OperationHdler.Update)
+		operation.PATCH("/:id", middleware, initConfig.OperationHdler.Patch)
 		operation.DELETE("/:id", middleware, initConfig.OperationHdler.Delete)
+		operation.GET("/:id/history", middleware, initConfig.OperationHdler.History)
+		operation.POST("/:id/history/:entry_id/revert", middleware, initConfig.OperationHdler.Revert)
+		operation.POST("/:id/merge", middleware, initConfig.OperationHdler.Merge)
+		operation.GET("/:id/attachments", middleware, initConfig.AttachmentHdler.Index)
+		operation.POST("/:id/attachments", middleware, idempotency, initConfig.AttachmentHdler.Create)
+		operation.GET("/:id/attachments/:attachment_id", middleware, initConfig.AttachmentHdler.Download)
+		operation.DELETE("/:id/attachments/:attachment_id", middleware, initConfig.AttachmentHdler.Delete)
 	}
 }
 
-func CategoriesRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc) {
+func CategoriesRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc, idempotency gin.HandlerFunc) {
 	category := router.Group("/categories")
 	{
 		category.GET("", middleware, initConfig.CategoryHdler.Index)
-		category.POST("", middleware, initConfig.CategoryHdler.Create)
+		category.GET("/:id", middleware, initConfig.CategoryHdler.Show)
+		category.POST("", middleware, idempotency, initConfig.CategoryHdler.Create)
 		category.PUT("/:id", middleware, initConfig.CategoryHdler.Update)
+		category.PATCH("/:id", middleware, initConfig.CategoryHdler.Patch)
 		category.DELETE("/:id", middleware, initConfig.CategoryHdler.Delete)
+		category.GET("/:id/history", middleware, initConfig.CategoryHdler.History)
+		category.POST("/:id/history/:entry_id/revert", middleware, initConfig.CategoryHdler.Revert)
+	}
+}
+
+func RecurringOperationRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc, idempotency gin.HandlerFunc) {
+	recurringOperation := router.Group("/recurring_operations")
+	{
+		recurringOperation.GET("", middleware, initConfig.RecurringOperationHdler.Index)
+		recurringOperation.GET("/:id", middleware, initConfig.RecurringOperationHdler.Show)
+		recurringOperation.POST("", middleware, idempotency, initConfig.RecurringOperationHdler.Create)
+		recurringOperation.PUT("/:id", middleware, initConfig.RecurringOperationHdler.Update)
+		recurringOperation.DELETE("/:id", middleware, initConfig.RecurringOperationHdler.Delete)
+	}
+}
+
+func RuleRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc, idempotency gin.HandlerFunc) {
+	rule := router.Group("/rules")
+	{
+		rule.GET("", middleware, initConfig.RuleHdler.Index)
+		rule.GET("/:id", middleware, initConfig.RuleHdler.Show)
+		rule.POST("", middleware, idempotency, initConfig.RuleHdler.Create)
+		rule.POST("/apply", middleware, initConfig.OperationHdler.ApplyRules)
+		rule.PUT("/:id", middleware, initConfig.RuleHdler.Update)
+		rule.DELETE("/:id", middleware, initConfig.RuleHdler.Delete)
+	}
+}
+
+func ExchangeRateRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc, idempotency gin.HandlerFunc) {
+	exchangeRate := router.Group("/exchange_rates")
+	{
+		exchangeRate.GET("", middleware, initConfig.ExchangeRateHdler.Index)
+		exchangeRate.POST("", middleware, idempotency, initConfig.ExchangeRateHdler.Create)
+		exchangeRate.POST("/import", middleware, idempotency, initConfig.ExchangeRateHdler.Import)
+		exchangeRate.DELETE("/:id", middleware, initConfig.ExchangeRateHdler.Delete)
+	}
+}
+
+func AccountRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc, idempotency gin.HandlerFunc) {
+	account := router.Group("/accounts")
+	{
+		account.GET("", middleware, initConfig.AccountHdler.Index)
+		account.GET("/:id", middleware, initConfig.AccountHdler.Show)
+		account.POST("", middleware, idempotency, initConfig.AccountHdler.Create)
+		account.PUT("/:id", middleware, initConfig.AccountHdler.Update)
+		account.DELETE("/:id", middleware, initConfig.AccountHdler.Delete)
+	}
+}
+
+func TransferRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc, idempotency gin.HandlerFunc) {
+	transfer := router.Group("/transfers")
+	{
+		transfer.GET("", middleware, initConfig.TransferHdler.Index)
+		transfer.GET("/:id", middleware, initConfig.TransferHdler.Show)
+		transfer.POST("", middleware, idempotency, initConfig.TransferHdler.Create)
+		transfer.PUT("/:id", middleware, initConfig.TransferHdler.Update)
+		transfer.DELETE("/:id", middleware, initConfig.TransferHdler.Delete)
+	}
+}
+
+func ReconciliationRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc, idempotency gin.HandlerFunc) {
+	reconciliation := router.Group("/reconciliations")
+	{
+		reconciliation.GET("", middleware, initConfig.ReconciliationHdler.Index)
+		reconciliation.GET("/:id", middleware, initConfig.ReconciliationHdler.Show)
+		reconciliation.POST("", middleware, idempotency, initConfig.ReconciliationHdler.Create)
+		reconciliation.POST("/:id/operations", middleware, initConfig.ReconciliationHdler.Tick)
+		reconciliation.DELETE("/:id/operations/:operation_id", middleware, initConfig.ReconciliationHdler.Untick)
+		reconciliation.POST("/:id/finalize", middleware, initConfig.ReconciliationHdler.Finalize)
+	}
+}
+
+func PayeeRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc, idempotency gin.HandlerFunc) {
+	payee := router.Group("/payees")
+	{
+		payee.GET("", middleware, initConfig.PayeeHdler.Index)
+		payee.GET("/totals", middleware, initConfig.PayeeHdler.Totals)
+		payee.GET("/:id", middleware, initConfig.PayeeHdler.Show)
+		payee.POST("", middleware, idempotency, initConfig.PayeeHdler.Create)
+		payee.POST("/:id/merge", middleware, initConfig.PayeeHdler.Merge)
+		payee.PUT("/:id", middleware, initConfig.PayeeHdler.Update)
+		payee.DELETE("/:id", middleware, initConfig.PayeeHdler.Delete)
+	}
+}
+
+func ReportRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc) {
+	report := router.Group("/reports")
+	{
+		report.GET("/categories", middleware, initConfig.ReportHdler.Categories)
+	}
+}
+
+func TagRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc) {
+	tag := router.Group("/tags")
+	{
+		tag.GET("", middleware, initConfig.TagHdler.Index)
+	}
+}
+
+func TrashRoutes(router *gin.RouterGroup, initConfig *config.Initialization, middleware gin.HandlerFunc) {
+	trash := router.Group("/trash")
+	{
+		trash.GET("", middleware, initConfig.TrashHdler.Index)
+		trash.POST("/operations/:id/restore", middleware, initConfig.TrashHdler.RestoreOperation)
+		trash.DELETE("/operations/:id", middleware, initConfig.TrashHdler.PurgeOperation)
+		trash.POST("/categories/:id/restore", middleware, initConfig.TrashHdler.RestoreCategory)
+		trash.DELETE("/categories/:id", middleware, initConfig.TrashHdler.PurgeCategory)
 	}
 }
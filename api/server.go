@@ -21,11 +21,22 @@ func Init(init *config.Initialization) *gin.Engine {
 
 	api := router.Group("/api")
 	middlewareAuth := middleware.AuthMiddleware(init)
+	middlewareIdempotency := middleware.IdempotencyMiddleware(init)
 
 	routes.HealthRoutes(api, init)
 	routes.UserRoutes(api, init, middlewareAuth)
-	routes.OperationRoutes(api, init, middlewareAuth)
-	routes.CategoriesRoutes(api, init, middlewareAuth)
+	routes.OperationRoutes(api, init, middlewareAuth, middlewareIdempotency)
+	routes.CategoriesRoutes(api, init, middlewareAuth, middlewareIdempotency)
+	routes.RecurringOperationRoutes(api, init, middlewareAuth, middlewareIdempotency)
+	routes.RuleRoutes(api, init, middlewareAuth, middlewareIdempotency)
+	routes.ExchangeRateRoutes(api, init, middlewareAuth, middlewareIdempotency)
+	routes.AccountRoutes(api, init, middlewareAuth, middlewareIdempotency)
+	routes.TransferRoutes(api, init, middlewareAuth, middlewareIdempotency)
+	routes.ReconciliationRoutes(api, init, middlewareAuth, middlewareIdempotency)
+	routes.PayeeRoutes(api, init, middlewareAuth, middlewareIdempotency)
+	routes.ReportRoutes(api, init, middlewareAuth)
+	routes.TagRoutes(api, init, middlewareAuth)
+	routes.TrashRoutes(api, init, middlewareAuth)
 
 	return router
 }
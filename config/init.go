@@ -8,32 +8,113 @@ import (
 )
 
 type Initialization struct {
-	UserRepo       repository.UserRepository
-	operationRepo  repository.OperationRepository
-	categoryRepo   repository.CategoryRepository
-	userSvc        services.UserService
-	operationSvc   services.OperationService
-	UserHdler      handlers.UserHandler
-	OperationHdler handlers.OperationHandler
-	Auth           auth.Auth
-	CategoryHdler  handlers.CategoryHandler
+	UserRepo                repository.UserRepository
+	operationRepo           repository.OperationRepository
+	categoryRepo            repository.CategoryRepository
+	recurringOperationRepo  repository.RecurringOperationRepository
+	exchangeRateRepo        repository.ExchangeRateRepository
+	accountRepo             repository.AccountRepository
+	transferRepo            repository.TransferRepository
+	attachmentRepo          repository.AttachmentRepository
+	tagRepo                 repository.TagRepository
+	trashRepo               repository.TrashRepository
+	auditRepo               repository.AuditRepository
+	IdempotencyKeyRepo      repository.IdempotencyKeyRepository
+	ruleRepo                repository.RuleRepository
+	reconciliationRepo      repository.ReconciliationRepository
+	payeeRepo               repository.PayeeRepository
+	reportingRepo           repository.ReportingRepository
+	userSvc                 services.UserService
+	operationSvc            services.OperationService
+	UserHdler               handlers.UserHandler
+	OperationHdler          handlers.OperationHandler
+	Auth                    auth.Auth
+	CategoryHdler           handlers.CategoryHandler
+	RecurringOperationHdler handlers.RecurringOperationHandler
+	RecurringScheduler      services.RecurringOperationScheduler
+	ExchangeRateHdler       handlers.ExchangeRateHandler
+	AccountHdler            handlers.AccountHandler
+	TransferHdler           handlers.TransferHandler
+	AttachmentHdler         handlers.AttachmentHandler
+	AttachmentCleaner       services.AttachmentCleaner
+	TagHdler                handlers.TagHandler
+	TrashHdler              handlers.TrashHandler
+	TrashPurger             services.TrashPurger
+	RuleHdler               handlers.RuleHandler
+	ReconciliationHdler     handlers.ReconciliationHandler
+	PayeeHdler              handlers.PayeeHandler
+	ReportHdler             handlers.ReportHandler
 }
 
 func NewInitialization(userRepo repository.UserRepository, operationRepo repository.OperationRepository,
 	categoryRepo repository.CategoryRepository,
+	recurringOperationRepo repository.RecurringOperationRepository,
+	exchangeRateRepo repository.ExchangeRateRepository,
+	accountRepo repository.AccountRepository,
+	transferRepo repository.TransferRepository,
+	attachmentRepo repository.AttachmentRepository,
+	tagRepo repository.TagRepository,
+	trashRepo repository.TrashRepository,
+	auditRepo repository.AuditRepository,
+	idempotencyKeyRepo repository.IdempotencyKeyRepository,
+	ruleRepo repository.RuleRepository,
+	reconciliationRepo repository.ReconciliationRepository,
+	payeeRepo repository.PayeeRepository,
+	reportingRepo repository.ReportingRepository,
 	userService services.UserService, operationSvc services.OperationService,
 	UserHdler handlers.UserHandler, OperationHdler handlers.OperationHandler,
 	auth auth.Auth,
-	categoryHdler handlers.CategoryHandler) *Initialization {
+	categoryHdler handlers.CategoryHandler,
+	recurringOperationHdler handlers.RecurringOperationHandler,
+	recurringScheduler services.RecurringOperationScheduler,
+	exchangeRateHdler handlers.ExchangeRateHandler,
+	accountHdler handlers.AccountHandler,
+	transferHdler handlers.TransferHandler,
+	attachmentHdler handlers.AttachmentHandler,
+	attachmentCleaner services.AttachmentCleaner,
+	tagHdler handlers.TagHandler,
+	trashHdler handlers.TrashHandler,
+	trashPurger services.TrashPurger,
+	ruleHdler handlers.RuleHandler,
+	reconciliationHdler handlers.ReconciliationHandler,
+	payeeHdler handlers.PayeeHandler,
+	reportHdler handlers.ReportHandler) *Initialization {
 	return &Initialization{
-		UserRepo:       userRepo,
-		operationRepo:  operationRepo,
-		categoryRepo:   categoryRepo,
-		userSvc:        userService,
-		operationSvc:   operationSvc,
-		UserHdler:      UserHdler,
-		OperationHdler: OperationHdler,
-		Auth:           auth,
-		CategoryHdler:  categoryHdler,
+		UserRepo:                userRepo,
+		operationRepo:           operationRepo,
+		categoryRepo:            categoryRepo,
+		recurringOperationRepo:  recurringOperationRepo,
+		exchangeRateRepo:        exchangeRateRepo,
+		accountRepo:             accountRepo,
+		transferRepo:            transferRepo,
+		attachmentRepo:          attachmentRepo,
+		tagRepo:                 tagRepo,
+		trashRepo:               trashRepo,
+		auditRepo:               auditRepo,
+		IdempotencyKeyRepo:      idempotencyKeyRepo,
+		ruleRepo:                ruleRepo,
+		reconciliationRepo:      reconciliationRepo,
+		payeeRepo:               payeeRepo,
+		reportingRepo:           reportingRepo,
+		userSvc:                 userService,
+		operationSvc:            operationSvc,
+		UserHdler:               UserHdler,
+		OperationHdler:          OperationHdler,
+		Auth:                    auth,
+		CategoryHdler:           categoryHdler,
+		RecurringOperationHdler: recurringOperationHdler,
+		RecurringScheduler:      recurringScheduler,
+		ExchangeRateHdler:       exchangeRateHdler,
+		AccountHdler:            accountHdler,
+		TransferHdler:           transferHdler,
+		AttachmentHdler:         attachmentHdler,
+		AttachmentCleaner:       attachmentCleaner,
+		TagHdler:                tagHdler,
+		TrashHdler:              trashHdler,
+		TrashPurger:             trashPurger,
+		RuleHdler:               ruleHdler,
+		ReconciliationHdler:     reconciliationHdler,
+		PayeeHdler:              payeeHdler,
+		ReportHdler:             reportHdler,
 	}
 }
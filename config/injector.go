@@ -9,6 +9,7 @@ import (
 	"GoGin-API-CuentasClaras/api/handlers"
 	"GoGin-API-CuentasClaras/repository"
 	"GoGin-API-CuentasClaras/services"
+	"GoGin-API-CuentasClaras/storage"
 
 	"github.com/google/wire"
 )
@@ -29,6 +30,60 @@ var categoryServiceSet = wire.NewSet(services.CategoryServiceInit,
 	wire.Bind(new(services.CategoryService), new(*services.CategoryServiceImpl)),
 )
 
+var recurringOperationServiceSet = wire.NewSet(services.RecurringOperationServiceInit,
+	wire.Bind(new(services.RecurringOperationService), new(*services.RecurringOperationServiceImpl)),
+	services.RecurringOperationSchedulerInit,
+	wire.Bind(new(services.RecurringOperationScheduler), new(*services.RecurringOperationSchedulerImpl)),
+)
+
+var exchangeRateServiceSet = wire.NewSet(services.ExchangeRateServiceInit,
+	wire.Bind(new(services.ExchangeRateService), new(*services.ExchangeRateServiceImpl)),
+)
+
+var accountServiceSet = wire.NewSet(services.AccountServiceInit,
+	wire.Bind(new(services.AccountService), new(*services.AccountServiceImpl)),
+)
+
+var transferServiceSet = wire.NewSet(services.TransferServiceInit,
+	wire.Bind(new(services.TransferService), new(*services.TransferServiceImpl)),
+)
+
+var attachmentServiceSet = wire.NewSet(services.AttachmentServiceInit,
+	wire.Bind(new(services.AttachmentService), new(*services.AttachmentServiceImpl)),
+	services.AttachmentCleanerInit,
+	wire.Bind(new(services.AttachmentCleaner), new(*services.AttachmentCleanerImpl)),
+)
+
+var tagServiceSet = wire.NewSet(services.TagServiceInit,
+	wire.Bind(new(services.TagService), new(*services.TagServiceImpl)),
+)
+
+var ruleServiceSet = wire.NewSet(services.RuleServiceInit,
+	wire.Bind(new(services.RuleService), new(*services.RuleServiceImpl)),
+)
+
+var reconciliationServiceSet = wire.NewSet(services.ReconciliationServiceInit,
+	wire.Bind(new(services.ReconciliationService), new(*services.ReconciliationServiceImpl)),
+)
+
+var payeeServiceSet = wire.NewSet(services.PayeeServiceInit,
+	wire.Bind(new(services.PayeeService), new(*services.PayeeServiceImpl)),
+)
+
+var reportServiceSet = wire.NewSet(services.ReportServiceInit,
+	wire.Bind(new(services.ReportService), new(*services.ReportServiceImpl)),
+)
+
+var trashServiceSet = wire.NewSet(services.TrashServiceInit,
+	wire.Bind(new(services.TrashService), new(*services.TrashServiceImpl)),
+	services.TrashPurgerInit,
+	wire.Bind(new(services.TrashPurger), new(*services.TrashPurgerImpl)),
+)
+
+var storageSet = wire.NewSet(storage.LocalStorageInit,
+	wire.Bind(new(storage.Storage), new(*storage.LocalStorage)),
+)
+
 var userRepoSet = wire.NewSet(repository.UserRepositoryInit,
 	wire.Bind(new(repository.UserRepository), new(*repository.UserRepositoryImpl)),
 )
@@ -41,6 +96,58 @@ var categoryRepoSet = wire.NewSet(repository.CategoryRepositoryInit,
 	wire.Bind(new(repository.CategoryRepository), new(*repository.CategoryRepositoryImpl)),
 )
 
+var recurringOperationRepoSet = wire.NewSet(repository.RecurringOperationRepositoryInit,
+	wire.Bind(new(repository.RecurringOperationRepository), new(*repository.RecurringOperationRepositoryImpl)),
+)
+
+var exchangeRateRepoSet = wire.NewSet(repository.ExchangeRateRepositoryInit,
+	wire.Bind(new(repository.ExchangeRateRepository), new(*repository.ExchangeRateRepositoryImpl)),
+)
+
+var accountRepoSet = wire.NewSet(repository.AccountRepositoryInit,
+	wire.Bind(new(repository.AccountRepository), new(*repository.AccountRepositoryImpl)),
+)
+
+var transferRepoSet = wire.NewSet(repository.TransferRepositoryInit,
+	wire.Bind(new(repository.TransferRepository), new(*repository.TransferRepositoryImpl)),
+)
+
+var attachmentRepoSet = wire.NewSet(repository.AttachmentRepositoryInit,
+	wire.Bind(new(repository.AttachmentRepository), new(*repository.AttachmentRepositoryImpl)),
+)
+
+var tagRepoSet = wire.NewSet(repository.TagRepositoryInit,
+	wire.Bind(new(repository.TagRepository), new(*repository.TagRepositoryImpl)),
+)
+
+var trashRepoSet = wire.NewSet(repository.TrashRepositoryInit,
+	wire.Bind(new(repository.TrashRepository), new(*repository.TrashRepositoryImpl)),
+)
+
+var auditRepoSet = wire.NewSet(repository.AuditRepositoryInit,
+	wire.Bind(new(repository.AuditRepository), new(*repository.AuditRepositoryImpl)),
+)
+
+var idempotencyKeyRepoSet = wire.NewSet(repository.IdempotencyKeyRepositoryInit,
+	wire.Bind(new(repository.IdempotencyKeyRepository), new(*repository.IdempotencyKeyRepositoryImpl)),
+)
+
+var ruleRepoSet = wire.NewSet(repository.RuleRepositoryInit,
+	wire.Bind(new(repository.RuleRepository), new(*repository.RuleRepositoryImpl)),
+)
+
+var reconciliationRepoSet = wire.NewSet(repository.ReconciliationRepositoryInit,
+	wire.Bind(new(repository.ReconciliationRepository), new(*repository.ReconciliationRepositoryImpl)),
+)
+
+var payeeRepoSet = wire.NewSet(repository.PayeeRepositoryInit,
+	wire.Bind(new(repository.PayeeRepository), new(*repository.PayeeRepositoryImpl)),
+)
+
+var reportingRepoSet = wire.NewSet(repository.ReportingRepositoryInit,
+	wire.Bind(new(repository.ReportingRepository), new(*repository.ReportingRepositoryImpl)),
+)
+
 var userHdlerSet = wire.NewSet(handlers.UserHandlerInit,
 	wire.Bind(new(handlers.UserHandler), new(*handlers.UserHandlerImpl)),
 )
@@ -53,11 +160,67 @@ var categoryHdlerSet = wire.NewSet(handlers.CategoryHandlerInit,
 	wire.Bind(new(handlers.CategoryHandler), new(*handlers.CategoryHandlerImpl)),
 )
 
+var recurringOperationHdlerSet = wire.NewSet(handlers.RecurringOperationHandlerInit,
+	wire.Bind(new(handlers.RecurringOperationHandler), new(*handlers.RecurringOperationHandlerImpl)),
+)
+
+var exchangeRateHdlerSet = wire.NewSet(handlers.ExchangeRateHandlerInit,
+	wire.Bind(new(handlers.ExchangeRateHandler), new(*handlers.ExchangeRateHandlerImpl)),
+)
+
+var accountHdlerSet = wire.NewSet(handlers.AccountHandlerInit,
+	wire.Bind(new(handlers.AccountHandler), new(*handlers.AccountHandlerImpl)),
+)
+
+var transferHdlerSet = wire.NewSet(handlers.TransferHandlerInit,
+	wire.Bind(new(handlers.TransferHandler), new(*handlers.TransferHandlerImpl)),
+)
+
+var attachmentHdlerSet = wire.NewSet(handlers.AttachmentHandlerInit,
+	wire.Bind(new(handlers.AttachmentHandler), new(*handlers.AttachmentHandlerImpl)),
+)
+
+var tagHdlerSet = wire.NewSet(handlers.TagHandlerInit,
+	wire.Bind(new(handlers.TagHandler), new(*handlers.TagHandlerImpl)),
+)
+
+var trashHdlerSet = wire.NewSet(handlers.TrashHandlerInit,
+	wire.Bind(new(handlers.TrashHandler), new(*handlers.TrashHandlerImpl)),
+)
+
+var ruleHdlerSet = wire.NewSet(handlers.RuleHandlerInit,
+	wire.Bind(new(handlers.RuleHandler), new(*handlers.RuleHandlerImpl)),
+)
+
+var reconciliationHdlerSet = wire.NewSet(handlers.ReconciliationHandlerInit,
+	wire.Bind(new(handlers.ReconciliationHandler), new(*handlers.ReconciliationHandlerImpl)),
+)
+
+var payeeHdlerSet = wire.NewSet(handlers.PayeeHandlerInit,
+	wire.Bind(new(handlers.PayeeHandler), new(*handlers.PayeeHandlerImpl)),
+)
+
+var reportHdlerSet = wire.NewSet(handlers.ReportHandlerInit,
+	wire.Bind(new(handlers.ReportHandler), new(*handlers.ReportHandlerImpl)),
+)
+
 func Init() *Initialization {
 	wire.Build(
 		NewInitialization, db, userHdlerSet, operationHdlerSet,
 		userServiceSet, operationServiceSet, categoryRepoSet,
 		userRepoSet, operationRepoSet, categoryServiceSet, categoryHdlerSet,
+		recurringOperationRepoSet, recurringOperationServiceSet, recurringOperationHdlerSet,
+		exchangeRateRepoSet, exchangeRateServiceSet, exchangeRateHdlerSet,
+		accountRepoSet, accountServiceSet, accountHdlerSet,
+		transferRepoSet, transferServiceSet, transferHdlerSet,
+		attachmentRepoSet, attachmentServiceSet, attachmentHdlerSet, storageSet,
+		tagRepoSet, tagServiceSet, tagHdlerSet,
+		trashRepoSet, trashServiceSet, trashHdlerSet,
+		auditRepoSet, idempotencyKeyRepoSet,
+		ruleRepoSet, ruleServiceSet, ruleHdlerSet,
+		reconciliationRepoSet, reconciliationServiceSet, reconciliationHdlerSet,
+		payeeRepoSet, payeeServiceSet, payeeHdlerSet,
+		reportingRepoSet, reportServiceSet, reportHdlerSet,
 	)
 	return nil
 }
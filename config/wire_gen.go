@@ -11,6 +11,7 @@ import (
 	"GoGin-API-CuentasClaras/api/handlers"
 	"GoGin-API-CuentasClaras/repository"
 	"GoGin-API-CuentasClaras/services"
+	"GoGin-API-CuentasClaras/storage"
 	"github.com/google/wire"
 )
 
@@ -21,14 +22,53 @@ func Init() *Initialization {
 	userRepositoryImpl := repository.UserRepositoryInit(gormDB)
 	operationRepositoryImpl := repository.OperationRepositoryInit(gormDB)
 	categoryRepositoryImpl := repository.CategoryRepositoryInit(gormDB)
+	recurringOperationRepositoryImpl := repository.RecurringOperationRepositoryInit(gormDB)
+	exchangeRateRepositoryImpl := repository.ExchangeRateRepositoryInit(gormDB)
+	accountRepositoryImpl := repository.AccountRepositoryInit(gormDB)
+	transferRepositoryImpl := repository.TransferRepositoryInit(gormDB)
+	attachmentRepositoryImpl := repository.AttachmentRepositoryInit(gormDB)
+	tagRepositoryImpl := repository.TagRepositoryInit(gormDB)
+	trashRepositoryImpl := repository.TrashRepositoryInit(gormDB)
+	auditRepositoryImpl := repository.AuditRepositoryInit(gormDB)
+	idempotencyKeyRepositoryImpl := repository.IdempotencyKeyRepositoryInit(gormDB)
+	ruleRepositoryImpl := repository.RuleRepositoryInit(gormDB)
+	reconciliationRepositoryImpl := repository.ReconciliationRepositoryInit(gormDB)
+	payeeRepositoryImpl := repository.PayeeRepositoryInit(gormDB)
+	reportingRepositoryImpl := repository.ReportingRepositoryInit(gormDB)
 	authImpl := auth.AuthInit()
-	userServiceImpl := services.UserServiceInit(userRepositoryImpl, authImpl, operationRepositoryImpl)
-	operationServiceImpl := services.OperationServiceInit(operationRepositoryImpl, categoryRepositoryImpl)
+	userServiceImpl := services.UserServiceInit(userRepositoryImpl, authImpl, operationRepositoryImpl, exchangeRateRepositoryImpl, accountRepositoryImpl, reportingRepositoryImpl)
+	operationServiceImpl := services.OperationServiceInit(operationRepositoryImpl, categoryRepositoryImpl, accountRepositoryImpl, transferRepositoryImpl, tagRepositoryImpl, auditRepositoryImpl, ruleRepositoryImpl, payeeRepositoryImpl)
 	userHandlerImpl := handlers.UserHandlerInit(userServiceImpl)
 	operationHandlerImpl := handlers.OperationHandlerInit(operationServiceImpl)
-	categoryServiceImpl := services.CategoryServiceInit(categoryRepositoryImpl)
+	categoryServiceImpl := services.CategoryServiceInit(categoryRepositoryImpl, auditRepositoryImpl)
 	categoryHandlerImpl := handlers.CategoryHandlerInit(categoryServiceImpl)
-	initialization := NewInitialization(userRepositoryImpl, operationRepositoryImpl, categoryRepositoryImpl, userServiceImpl, operationServiceImpl, userHandlerImpl, operationHandlerImpl, authImpl, categoryHandlerImpl)
+	recurringOperationServiceImpl := services.RecurringOperationServiceInit(recurringOperationRepositoryImpl, categoryRepositoryImpl, accountRepositoryImpl)
+	recurringOperationHandlerImpl := handlers.RecurringOperationHandlerInit(recurringOperationServiceImpl)
+	recurringOperationSchedulerImpl := services.RecurringOperationSchedulerInit(recurringOperationRepositoryImpl)
+	exchangeRateServiceImpl := services.ExchangeRateServiceInit(exchangeRateRepositoryImpl)
+	exchangeRateHandlerImpl := handlers.ExchangeRateHandlerInit(exchangeRateServiceImpl)
+	accountServiceImpl := services.AccountServiceInit(accountRepositoryImpl)
+	accountHandlerImpl := handlers.AccountHandlerInit(accountServiceImpl)
+	transferServiceImpl := services.TransferServiceInit(transferRepositoryImpl, accountRepositoryImpl)
+	transferHandlerImpl := handlers.TransferHandlerInit(transferServiceImpl)
+	localStorage := storage.LocalStorageInit()
+	attachmentServiceImpl := services.AttachmentServiceInit(attachmentRepositoryImpl, operationRepositoryImpl, localStorage)
+	attachmentHandlerImpl := handlers.AttachmentHandlerInit(attachmentServiceImpl)
+	attachmentCleanerImpl := services.AttachmentCleanerInit(attachmentRepositoryImpl, localStorage)
+	tagServiceImpl := services.TagServiceInit(tagRepositoryImpl)
+	tagHandlerImpl := handlers.TagHandlerInit(tagServiceImpl)
+	trashServiceImpl := services.TrashServiceInit(trashRepositoryImpl)
+	trashHandlerImpl := handlers.TrashHandlerInit(trashServiceImpl)
+	trashPurgerImpl := services.TrashPurgerInit(trashRepositoryImpl)
+	ruleServiceImpl := services.RuleServiceInit(ruleRepositoryImpl, categoryRepositoryImpl)
+	ruleHandlerImpl := handlers.RuleHandlerInit(ruleServiceImpl)
+	reconciliationServiceImpl := services.ReconciliationServiceInit(reconciliationRepositoryImpl, accountRepositoryImpl)
+	reconciliationHandlerImpl := handlers.ReconciliationHandlerInit(reconciliationServiceImpl)
+	payeeServiceImpl := services.PayeeServiceInit(payeeRepositoryImpl)
+	payeeHandlerImpl := handlers.PayeeHandlerInit(payeeServiceImpl)
+	reportServiceImpl := services.ReportServiceInit(reportingRepositoryImpl)
+	reportHandlerImpl := handlers.ReportHandlerInit(reportServiceImpl)
+	initialization := NewInitialization(userRepositoryImpl, operationRepositoryImpl, categoryRepositoryImpl, recurringOperationRepositoryImpl, exchangeRateRepositoryImpl, accountRepositoryImpl, transferRepositoryImpl, attachmentRepositoryImpl, tagRepositoryImpl, trashRepositoryImpl, auditRepositoryImpl, idempotencyKeyRepositoryImpl, ruleRepositoryImpl, reconciliationRepositoryImpl, payeeRepositoryImpl, reportingRepositoryImpl, userServiceImpl, operationServiceImpl, userHandlerImpl, operationHandlerImpl, authImpl, categoryHandlerImpl, recurringOperationHandlerImpl, recurringOperationSchedulerImpl, exchangeRateHandlerImpl, accountHandlerImpl, transferHandlerImpl, attachmentHandlerImpl, attachmentCleanerImpl, tagHandlerImpl, trashHandlerImpl, trashPurgerImpl, ruleHandlerImpl, reconciliationHandlerImpl, payeeHandlerImpl, reportHandlerImpl)
 	return initialization
 }
 
@@ -42,14 +82,86 @@ var operationServiceSet = wire.NewSet(services.OperationServiceInit, wire.Bind(n
 
 var categoryServiceSet = wire.NewSet(services.CategoryServiceInit, wire.Bind(new(services.CategoryService), new(*services.CategoryServiceImpl)))
 
+var recurringOperationServiceSet = wire.NewSet(services.RecurringOperationServiceInit, wire.Bind(new(services.RecurringOperationService), new(*services.RecurringOperationServiceImpl)), services.RecurringOperationSchedulerInit, wire.Bind(new(services.RecurringOperationScheduler), new(*services.RecurringOperationSchedulerImpl)))
+
+var exchangeRateServiceSet = wire.NewSet(services.ExchangeRateServiceInit, wire.Bind(new(services.ExchangeRateService), new(*services.ExchangeRateServiceImpl)))
+
+var accountServiceSet = wire.NewSet(services.AccountServiceInit, wire.Bind(new(services.AccountService), new(*services.AccountServiceImpl)))
+
+var transferServiceSet = wire.NewSet(services.TransferServiceInit, wire.Bind(new(services.TransferService), new(*services.TransferServiceImpl)))
+
 var userRepoSet = wire.NewSet(repository.UserRepositoryInit, wire.Bind(new(repository.UserRepository), new(*repository.UserRepositoryImpl)))
 
 var operationRepoSet = wire.NewSet(repository.OperationRepositoryInit, wire.Bind(new(repository.OperationRepository), new(*repository.OperationRepositoryImpl)))
 
 var categoryRepoSet = wire.NewSet(repository.CategoryRepositoryInit, wire.Bind(new(repository.CategoryRepository), new(*repository.CategoryRepositoryImpl)))
 
+var recurringOperationRepoSet = wire.NewSet(repository.RecurringOperationRepositoryInit, wire.Bind(new(repository.RecurringOperationRepository), new(*repository.RecurringOperationRepositoryImpl)))
+
+var exchangeRateRepoSet = wire.NewSet(repository.ExchangeRateRepositoryInit, wire.Bind(new(repository.ExchangeRateRepository), new(*repository.ExchangeRateRepositoryImpl)))
+
+var accountRepoSet = wire.NewSet(repository.AccountRepositoryInit, wire.Bind(new(repository.AccountRepository), new(*repository.AccountRepositoryImpl)))
+
+var transferRepoSet = wire.NewSet(repository.TransferRepositoryInit, wire.Bind(new(repository.TransferRepository), new(*repository.TransferRepositoryImpl)))
+
 var userHdlerSet = wire.NewSet(handlers.UserHandlerInit, wire.Bind(new(handlers.UserHandler), new(*handlers.UserHandlerImpl)))
 
 var operationHdlerSet = wire.NewSet(handlers.OperationHandlerInit, wire.Bind(new(handlers.OperationHandler), new(*handlers.OperationHandlerImpl)))
 
 var categoryHdlerSet = wire.NewSet(handlers.CategoryHandlerInit, wire.Bind(new(handlers.CategoryHandler), new(*handlers.CategoryHandlerImpl)))
+
+var recurringOperationHdlerSet = wire.NewSet(handlers.RecurringOperationHandlerInit, wire.Bind(new(handlers.RecurringOperationHandler), new(*handlers.RecurringOperationHandlerImpl)))
+
+var exchangeRateHdlerSet = wire.NewSet(handlers.ExchangeRateHandlerInit, wire.Bind(new(handlers.ExchangeRateHandler), new(*handlers.ExchangeRateHandlerImpl)))
+
+var accountHdlerSet = wire.NewSet(handlers.AccountHandlerInit, wire.Bind(new(handlers.AccountHandler), new(*handlers.AccountHandlerImpl)))
+
+var transferHdlerSet = wire.NewSet(handlers.TransferHandlerInit, wire.Bind(new(handlers.TransferHandler), new(*handlers.TransferHandlerImpl)))
+
+var attachmentServiceSet = wire.NewSet(services.AttachmentServiceInit, wire.Bind(new(services.AttachmentService), new(*services.AttachmentServiceImpl)), services.AttachmentCleanerInit, wire.Bind(new(services.AttachmentCleaner), new(*services.AttachmentCleanerImpl)))
+
+var storageSet = wire.NewSet(storage.LocalStorageInit, wire.Bind(new(storage.Storage), new(*storage.LocalStorage)))
+
+var attachmentRepoSet = wire.NewSet(repository.AttachmentRepositoryInit, wire.Bind(new(repository.AttachmentRepository), new(*repository.AttachmentRepositoryImpl)))
+
+var attachmentHdlerSet = wire.NewSet(handlers.AttachmentHandlerInit, wire.Bind(new(handlers.AttachmentHandler), new(*handlers.AttachmentHandlerImpl)))
+
+var tagServiceSet = wire.NewSet(services.TagServiceInit, wire.Bind(new(services.TagService), new(*services.TagServiceImpl)))
+
+var tagRepoSet = wire.NewSet(repository.TagRepositoryInit, wire.Bind(new(repository.TagRepository), new(*repository.TagRepositoryImpl)))
+
+var tagHdlerSet = wire.NewSet(handlers.TagHandlerInit, wire.Bind(new(handlers.TagHandler), new(*handlers.TagHandlerImpl)))
+
+var trashServiceSet = wire.NewSet(services.TrashServiceInit, wire.Bind(new(services.TrashService), new(*services.TrashServiceImpl)), services.TrashPurgerInit, wire.Bind(new(services.TrashPurger), new(*services.TrashPurgerImpl)))
+
+var trashRepoSet = wire.NewSet(repository.TrashRepositoryInit, wire.Bind(new(repository.TrashRepository), new(*repository.TrashRepositoryImpl)))
+
+var trashHdlerSet = wire.NewSet(handlers.TrashHandlerInit, wire.Bind(new(handlers.TrashHandler), new(*handlers.TrashHandlerImpl)))
+
+var auditRepoSet = wire.NewSet(repository.AuditRepositoryInit, wire.Bind(new(repository.AuditRepository), new(*repository.AuditRepositoryImpl)))
+
+var idempotencyKeyRepoSet = wire.NewSet(repository.IdempotencyKeyRepositoryInit, wire.Bind(new(repository.IdempotencyKeyRepository), new(*repository.IdempotencyKeyRepositoryImpl)))
+
+var ruleServiceSet = wire.NewSet(services.RuleServiceInit, wire.Bind(new(services.RuleService), new(*services.RuleServiceImpl)))
+
+var ruleRepoSet = wire.NewSet(repository.RuleRepositoryInit, wire.Bind(new(repository.RuleRepository), new(*repository.RuleRepositoryImpl)))
+
+var ruleHdlerSet = wire.NewSet(handlers.RuleHandlerInit, wire.Bind(new(handlers.RuleHandler), new(*handlers.RuleHandlerImpl)))
+
+var reconciliationServiceSet = wire.NewSet(services.ReconciliationServiceInit, wire.Bind(new(services.ReconciliationService), new(*services.ReconciliationServiceImpl)))
+
+var reconciliationRepoSet = wire.NewSet(repository.ReconciliationRepositoryInit, wire.Bind(new(repository.ReconciliationRepository), new(*repository.ReconciliationRepositoryImpl)))
+
+var reconciliationHdlerSet = wire.NewSet(handlers.ReconciliationHandlerInit, wire.Bind(new(handlers.ReconciliationHandler), new(*handlers.ReconciliationHandlerImpl)))
+
+var payeeServiceSet = wire.NewSet(services.PayeeServiceInit, wire.Bind(new(services.PayeeService), new(*services.PayeeServiceImpl)))
+
+var payeeRepoSet = wire.NewSet(repository.PayeeRepositoryInit, wire.Bind(new(repository.PayeeRepository), new(*repository.PayeeRepositoryImpl)))
+
+var payeeHdlerSet = wire.NewSet(handlers.PayeeHandlerInit, wire.Bind(new(handlers.PayeeHandler), new(*handlers.PayeeHandlerImpl)))
+
+var reportingRepoSet = wire.NewSet(repository.ReportingRepositoryInit, wire.Bind(new(repository.ReportingRepository), new(*repository.ReportingRepositoryImpl)))
+
+var reportServiceSet = wire.NewSet(services.ReportServiceInit, wire.Bind(new(services.ReportService), new(*services.ReportServiceImpl)))
+
+var reportHdlerSet = wire.NewSet(handlers.ReportHandlerInit, wire.Bind(new(handlers.ReportHandler), new(*handlers.ReportHandlerImpl)))
@@ -0,0 +1,14 @@
+package dao
+
+import "GoGin-API-CuentasClaras/money"
+
+type Account struct {
+	ID             int          `gorm:"column:id; primary_key; not null" json:"id"`
+	UserID         uint         `gorm:"index" json:"-"`
+	Name           string       `json:"name"`
+	Type           string       `json:"type"`
+	Currency       string       `gorm:"size:3; default:ARS" json:"currency"`
+	OpeningBalance money.Amount `gorm:"type:numeric(15,2); default:0" json:"opening_balance"`
+	IsDefault      bool         `gorm:"default:false" json:"is_default"`
+	BaseModel
+}
@@ -0,0 +1,15 @@
+package dao
+
+import "time"
+
+type Attachment struct {
+	ID          int        `gorm:"column:id; primary_key; not null" json:"id"`
+	UserID      uint       `gorm:"index" json:"-"`
+	OperationID int        `gorm:"index" json:"operation_id"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	StorageKey  string     `gorm:"uniqueIndex" json:"-"`
+	OrphanedAt  *time.Time `gorm:"index" json:"-"`
+	BaseModel
+}
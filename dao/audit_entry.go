@@ -0,0 +1,16 @@
+package dao
+
+import "time"
+
+type AuditEntry struct {
+	ID            int       `gorm:"column:id; primary_key; not null" json:"id"`
+	UserID        uint      `gorm:"index:idx_audit_entries_record,priority:1" json:"-"`
+	ActorID       uint      `json:"actor_id"`
+	ActorUsername string    `json:"actor"`
+	RecordType    string    `gorm:"index:idx_audit_entries_record,priority:2" json:"record_type"`
+	RecordID      int       `gorm:"index:idx_audit_entries_record,priority:3" json:"record_id"`
+	Action        string    `json:"action"`
+	Before        *string   `gorm:"type:text" json:"-"`
+	After         *string   `gorm:"type:text" json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+}
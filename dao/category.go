@@ -10,5 +10,6 @@ type Category struct {
 	Color       string
 	UserID      uint `gorm:"default:null; index" json:"-"`
 	IsDefault   bool `gorm:"default:false" json:"is_default"`
+	Version     int  `gorm:"not null; default:1" json:"-"`
 	BaseModel
 }
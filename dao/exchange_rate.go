@@ -0,0 +1,15 @@
+package dao
+
+import (
+	"time"
+)
+
+type ExchangeRate struct {
+	ID           int       `gorm:"column:id; primary_key; not null" json:"id"`
+	UserID       uint      `gorm:"uniqueIndex:idx_exchange_rates_user_pair_date,priority:1" json:"-"`
+	FromCurrency string    `gorm:"size:3; uniqueIndex:idx_exchange_rates_user_pair_date,priority:2" json:"from_currency"`
+	ToCurrency   string    `gorm:"size:3; uniqueIndex:idx_exchange_rates_user_pair_date,priority:3" json:"to_currency"`
+	Date         time.Time `gorm:"type:date; uniqueIndex:idx_exchange_rates_user_pair_date,priority:4" json:"date"`
+	Rate         float64   `json:"rate"`
+	BaseModel
+}
@@ -0,0 +1,15 @@
+package dao
+
+import "time"
+
+type IdempotencyKey struct {
+	ID          int       `gorm:"column:id; primary_key; not null" json:"id"`
+	UserID      uint      `gorm:"uniqueIndex:idx_idempotency_keys_user_key,priority:1" json:"-"`
+	Key         string    `gorm:"size:255; uniqueIndex:idx_idempotency_keys_user_key,priority:2" json:"key"`
+	RequestHash string    `gorm:"size:64" json:"-"`
+	StatusCode  int       `json:"status_code"`
+	ContentType string    `json:"-"`
+	Body        string    `gorm:"type:text" json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `gorm:"index" json:"expires_at"`
+}
@@ -1,17 +1,29 @@
 package dao
 
 import (
+	"GoGin-API-CuentasClaras/money"
 	"time"
 )
 
 type Operation struct {
-    ID          int       `gorm:"column:id; primary_key; not null" json:"id"`
-    UserID      uint      `json:"-"`
-    CategoryID  int       `json:"category_id"`
-    Category    Category  `gorm:"foreignKey:CategoryID" json:"category"`
-    Type        string    `json:"type"`
-    Amount      float64   `json:"amount"`
-    Date        time.Time `json:"date"`
-    Description string    `json:"description"`
-    BaseModel
+	ID                   int              `gorm:"column:id; primary_key; not null" json:"id"`
+	UserID               uint             `gorm:"index:idx_operations_user_date,priority:1" json:"-"`
+	CategoryID           *int             `gorm:"default:null" json:"category_id"`
+	Category             Category         `gorm:"foreignKey:CategoryID" json:"category"`
+	AccountID            *int             `gorm:"index" json:"account_id"`
+	TransferID           *int             `gorm:"index" json:"transfer_id"`
+	RecurringOperationID *int             `gorm:"uniqueIndex:idx_operations_recurring_date,priority:1" json:"recurring_operation_id"`
+	Type                 string           `json:"type"`
+	Amount               money.Amount     `gorm:"type:numeric(15,2)" json:"amount"`
+	Currency             string           `gorm:"size:3; default:ARS" json:"currency"`
+	Date                 time.Time        `gorm:"index:idx_operations_user_date,priority:2;uniqueIndex:idx_operations_recurring_date,priority:2" json:"date"`
+	Description          string           `json:"description"`
+	ExternalID           string           `gorm:"index" json:"external_id"`
+	Status               string           `gorm:"size:10; not null; default:pending" json:"status"`
+	ReconciliationID     *int             `gorm:"index" json:"reconciliation_id"`
+	PayeeID              *int             `gorm:"index" json:"payee_id"`
+	Splits               []OperationSplit `gorm:"foreignKey:OperationID" json:"splits"`
+	Tags                 []Tag            `gorm:"many2many:operation_tags" json:"tags"`
+	Version              int              `gorm:"not null; default:1" json:"-"`
+	BaseModel
 }
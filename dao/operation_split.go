@@ -0,0 +1,13 @@
+package dao
+
+import "GoGin-API-CuentasClaras/money"
+
+type OperationSplit struct {
+	ID          int          `gorm:"column:id; primary_key; not null" json:"id"`
+	OperationID int          `gorm:"index" json:"operation_id"`
+	CategoryID  int          `json:"category_id"`
+	Category    Category     `gorm:"foreignKey:CategoryID" json:"category"`
+	Amount      money.Amount `gorm:"type:numeric(15,2)" json:"amount"`
+	Note        string       `json:"note"`
+	BaseModel
+}
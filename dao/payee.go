@@ -0,0 +1,11 @@
+package dao
+
+// Payee is a merchant or person operations are paid to or received from.
+// Descriptions matching its name or one of its aliases are matched to it.
+type Payee struct {
+	ID      int    `gorm:"column:id; primary_key; not null" json:"id"`
+	UserID  uint   `gorm:"index" json:"-"`
+	Name    string `gorm:"size:100" json:"name"`
+	Aliases string `json:"aliases"`
+	BaseModel
+}
@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+// Reconciliation checks the operations of an account against a bank
+// statement. While it is open operations are ticked off; finalizing it
+// reconciles them.
+type Reconciliation struct {
+	ID             int          `gorm:"column:id; primary_key; not null" json:"id"`
+	UserID         uint         `gorm:"index" json:"-"`
+	AccountID      int          `gorm:"index" json:"account_id"`
+	StatementDate  time.Time    `json:"statement_date"`
+	ClosingBalance money.Amount `gorm:"type:numeric(15,2)" json:"closing_balance"`
+	Status         string       `gorm:"size:10; not null; default:open" json:"status"`
+	FinalizedAt    *time.Time   `json:"finalized_at"`
+	BaseModel
+}
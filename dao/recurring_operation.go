@@ -0,0 +1,27 @@
+package dao
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+type RecurringOperation struct {
+	ID                 int          `gorm:"column:id; primary_key; not null" json:"id"`
+	UserID             uint         `gorm:"index" json:"-"`
+	CategoryID         int          `json:"category_id"`
+	Category           Category     `gorm:"foreignKey:CategoryID" json:"category"`
+	AccountID          *int         `json:"account_id"`
+	Type               string       `json:"type"`
+	Amount             money.Amount `gorm:"type:numeric(15,2)" json:"amount"`
+	Currency           string       `gorm:"size:3; default:ARS" json:"currency"`
+	Description        string       `json:"description"`
+	Frequency          string       `json:"frequency"`
+	DayOfMonth         int          `json:"day_of_month"`
+	StartDate          time.Time    `json:"start_date"`
+	EndDate            *time.Time   `json:"end_date"`
+	Count              int          `json:"count"`
+	OccurrencesCreated int          `gorm:"default:0" json:"occurrences_created"`
+	LastRunAt          *time.Time   `json:"last_run_at"`
+	NextRunAt          *time.Time   `gorm:"index" json:"next_run_at"`
+	BaseModel
+}
@@ -0,0 +1,21 @@
+package dao
+
+import "GoGin-API-CuentasClaras/money"
+
+// Rule changes the operations matching all of its conditions. Conditions left
+// empty match any operation.
+type Rule struct {
+	ID                  int           `gorm:"column:id; primary_key; not null" json:"id"`
+	UserID              uint          `gorm:"index" json:"-"`
+	Name                string        `json:"name"`
+	Priority            int           `gorm:"default:0" json:"priority"`
+	DescriptionContains string        `json:"description_contains"`
+	DescriptionRegex    string        `json:"description_regex"`
+	Type                string        `json:"type"`
+	MinAmount           *money.Amount `gorm:"type:numeric(15,2)" json:"min_amount"`
+	MaxAmount           *money.Amount `gorm:"type:numeric(15,2)" json:"max_amount"`
+	SetCategoryID       *int          `json:"set_category_id"`
+	AddTags             string        `json:"add_tags"`
+	SetDescription      string        `json:"set_description"`
+	BaseModel
+}
@@ -0,0 +1,9 @@
+package dao
+
+type Tag struct {
+	ID              int    `gorm:"column:id; primary_key; not null" json:"id"`
+	UserID          uint   `gorm:"uniqueIndex:idx_tags_user_name,priority:1" json:"-"`
+	Name            string `gorm:"uniqueIndex:idx_tags_user_name,priority:2" json:"name"`
+	OperationsCount int64  `gorm:"->;-:migration" json:"operations_count"`
+	BaseModel
+}
@@ -0,0 +1,19 @@
+package dao
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+type Transfer struct {
+	ID            int          `gorm:"column:id; primary_key; not null" json:"id"`
+	UserID        uint         `gorm:"index" json:"-"`
+	FromAccountID int          `json:"from_account_id"`
+	ToAccountID   int          `json:"to_account_id"`
+	Amount        money.Amount `gorm:"type:numeric(15,2)" json:"amount"`
+	ToAmount      money.Amount `gorm:"type:numeric(15,2)" json:"to_amount"`
+	Date          time.Time    `json:"date"`
+	Description   string       `json:"description"`
+	Operations    []Operation  `gorm:"foreignKey:TransferID" json:"operations"`
+	BaseModel
+}
@@ -6,11 +6,12 @@ import (
 )
 
 type User struct {
-	ID         int         `gorm:"column:id; primary_key; not null" json:"id"`
-	Operations []Operation `gorm:"foreignKey:UserID"`
-	Username   string      `gorm:"column:username; unique" json:"username"`
-	Email      string      `gorm:"column:email; unique" json:"email"`
-	Password   string      `gorm:"column:password" json:"password"`
+	ID           int         `gorm:"column:id; primary_key; not null" json:"id"`
+	Operations   []Operation `gorm:"foreignKey:UserID"`
+	Username     string      `gorm:"column:username; unique" json:"username"`
+	Email        string      `gorm:"column:email; unique" json:"email"`
+	Password     string      `gorm:"column:password" json:"password"`
+	BaseCurrency string      `gorm:"column:base_currency; size:3; default:ARS" json:"base_currency"`
 	BaseModel
 }
 
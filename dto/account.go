@@ -0,0 +1,20 @@
+package dto
+
+import "GoGin-API-CuentasClaras/money"
+
+type TransformedAccount struct {
+	ID             int          `json:"id"`
+	Name           string       `json:"name"`
+	Type           string       `json:"type"`
+	Currency       string       `json:"currency"`
+	OpeningBalance money.Amount `json:"opening_balance"`
+	IsDefault      bool         `json:"is_default"`
+}
+
+type AccountRequest struct {
+	Name           string       `json:"name"`
+	Type           string       `json:"type"`
+	Currency       string       `json:"currency"`
+	OpeningBalance money.Amount `json:"opening_balance"`
+	IsDefault      bool         `json:"is_default"`
+}
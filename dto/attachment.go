@@ -0,0 +1,8 @@
+package dto
+
+type TransformedAttachment struct {
+	ID          int    `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
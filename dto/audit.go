@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+const AuditActionCreate = "create"
+const AuditActionUpdate = "update"
+const AuditActionDelete = "delete"
+const AuditActionRevert = "revert"
+const AuditActionMerge = "merge"
+
+const AuditRecordOperation = "operation"
+const AuditRecordCategory = "category"
+
+// OperationSnapshot is the version of an operation kept in its history.
+type OperationSnapshot struct {
+	Type        string          `json:"type"`
+	Amount      money.Amount    `json:"amount"`
+	Currency    string          `json:"currency"`
+	Date        time.Time       `json:"date"`
+	Description string          `json:"description"`
+	CategoryID  *int            `json:"category_id"`
+	AccountID   *int            `json:"account_id"`
+	Splits      []SplitSnapshot `json:"splits"`
+	Tags        []string        `json:"tags"`
+	Status      string          `json:"status,omitempty"`
+	PayeeID     *int            `json:"payee_id,omitempty"`
+}
+
+type SplitSnapshot struct {
+	CategoryID int          `json:"category_id"`
+	Amount     money.Amount `json:"amount"`
+	Note       string       `json:"note"`
+}
+
+// CategorySnapshot is the version of a category kept in its history.
+type CategorySnapshot struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+type AuditChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type TransformedAuditEntry struct {
+	ID        int           `json:"id"`
+	Action    string        `json:"action"`
+	ActorID   uint          `json:"actor_id"`
+	Actor     string        `json:"actor"`
+	CreatedAt time.Time     `json:"created_at"`
+	Changes   []AuditChange `json:"changes"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+type TransformedExchangeRate struct {
+	ID           int     `json:"id"`
+	FromCurrency string  `json:"from_currency"`
+	ToCurrency   string  `json:"to_currency"`
+	Date         string  `json:"date"`
+	Rate         float64 `json:"rate"`
+}
+
+type ExchangeRateRequest struct {
+	FromCurrency string  `json:"from_currency"`
+	ToCurrency   string  `json:"to_currency"`
+	Date         string  `json:"date"`
+	Rate         float64 `json:"rate"`
+}
@@ -1,28 +1,109 @@
 package dto
 
-import "time"
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+const (
+	SortDateDesc   string = "date_desc"
+	SortDateAsc    string = "date_asc"
+	SortAmountDesc string = "amount_desc"
+	SortAmountAsc  string = "amount_asc"
+)
+
+const (
+	TagModeAny string = "any"
+	TagModeAll string = "all"
+)
+
+// The status of an operation: pending until it shows up in the bank, cleared
+// once it does and reconciled when a finalized reconciliation includes it.
+const (
+	OperationStatusPending    string = "pending"
+	OperationStatusCleared    string = "cleared"
+	OperationStatusReconciled string = "reconciled"
+)
+
+// TransferType selects the sides of transfers when filtering operations by type.
+const TransferType string = "transfer"
 
 type TransformedOperation struct {
-	ID       int                 `json:"id"`
-	Type     string              `json:"type"`
-	Amount   float64             `json:"amount"`
-	Date     time.Time           `json:"date"`
-	Category TransformedCategory `json:"category"`
+	ID         int                 `json:"id"`
+	Type       string              `json:"type"`
+	Amount     money.Amount        `json:"amount"`
+	Currency   string              `json:"currency"`
+	Date       time.Time           `json:"date"`
+	AccountID  *int                `json:"account_id"`
+	TransferID *int                `json:"transfer_id"`
+	Status     string              `json:"status"`
+	PayeeID    *int                `json:"payee_id"`
+	Category   TransformedCategory `json:"category"`
+	Tags       []string            `json:"tags"`
 }
 
 type TransformedShowOperation struct {
 	ID          int                     `json:"id"`
 	Type        string                  `json:"type"`
-	Amount      float64                 `json:"amount"`
+	Amount      money.Amount            `json:"amount"`
+	Currency    string                  `json:"currency"`
 	Date        time.Time               `json:"date"`
+	AccountID   *int                    `json:"account_id"`
+	TransferID  *int                    `json:"transfer_id"`
+	Status      string                  `json:"status"`
+	PayeeID     *int                    `json:"payee_id"`
 	Category    TransformedShowCategory `json:"category"`
 	Description string                  `json:"description"`
+	Splits      []TransformedSplit      `json:"splits"`
+	Tags        []string                `json:"tags"`
+}
+
+type TransformedSplit struct {
+	Category TransformedCategory `json:"category"`
+	Amount   money.Amount        `json:"amount"`
+	Note     string              `json:"note"`
+}
+
+type PaginatedOperations struct {
+	Operations []TransformedOperation `json:"operations"`
+	NextCursor string                 `json:"next_cursor"`
 }
 
 type OperationRequest struct {
-	Type        string  `json:"type"`
-	Amount      float64 `json:"amount"`
-	Date        string  `json:"date"`
-	Description string  `json:"description"`
-	CategoryID  string  `json:"category_id"`
+	Type        string         `json:"type"`
+	Amount      money.Amount   `json:"amount"`
+	Currency    string         `json:"currency"`
+	Date        string         `json:"date"`
+	Description string         `json:"description"`
+	CategoryID  string         `json:"category_id"`
+	AccountID   string         `json:"account_id"`
+	Splits      []SplitRequest `json:"splits"`
+	Tags        []string       `json:"tags"`
+	Status      string         `json:"status,omitempty"`
+	PayeeID     string         `json:"payee_id,omitempty"`
+}
+
+type SplitRequest struct {
+	CategoryID string       `json:"category_id"`
+	Amount     money.Amount `json:"amount"`
+	Note       string       `json:"note"`
+}
+
+type OperationFilter struct {
+	From        *time.Time    `form:"from"`
+	To          *time.Time    `form:"to"`
+	Type        string        `form:"type"`
+	Currency    string        `form:"currency"`
+	CategoryID  *int          `form:"category_id"`
+	AccountID   *int          `form:"account_id"`
+	PayeeID     *int          `form:"payee_id"`
+	MinAmount   *money.Amount `form:"-"`
+	MaxAmount   *money.Amount `form:"-"`
+	Description string        `form:"description"`
+	Status      string        `form:"status"`
+	Tags        []string      `form:"tags"`
+	TagMode     string        `form:"tag_mode"`
+	Sort        string        `form:"sort"`
+	Cursor      string        `form:"cursor"`
+	Limit       int           `form:"limit"`
 }
@@ -0,0 +1,48 @@
+package dto
+
+const (
+	BatchActionCreate string = "create"
+	BatchActionUpdate string = "update"
+	BatchActionDelete string = "delete"
+)
+
+const (
+	BatchModeAllOrNothing string = "all_or_nothing"
+	BatchModeBestEffort   string = "best_effort"
+)
+
+// OperationBatchRequest is a list of changes applied in one transaction. In
+// the all or nothing mode, the default, a failed action rolls back the rest;
+// in the best effort mode only the failed actions are left out.
+type OperationBatchRequest struct {
+	Mode    string                 `json:"mode"`
+	Actions []OperationBatchAction `json:"actions"`
+}
+
+// OperationBatchAction creates an operation, or updates or deletes the one
+// with the ID. Invalid is set by the handler for the actions that fail the
+// validation of their parameters.
+type OperationBatchAction struct {
+	Action    string           `json:"action"`
+	ID        int              `json:"id"`
+	Operation OperationRequest `json:"operation"`
+	Invalid   bool             `json:"-"`
+}
+
+// OperationBatchActionResult carries the status and the response each action
+// would have had as a single request.
+type OperationBatchActionResult struct {
+	Index    int         `json:"index"`
+	Action   string      `json:"action"`
+	ID       int         `json:"id"`
+	Status   int         `json:"status"`
+	Response interface{} `json:"response"`
+}
+
+type OperationBatchResult struct {
+	Mode      string                       `json:"mode"`
+	Committed bool                         `json:"committed"`
+	Succeeded int                          `json:"succeeded"`
+	Failed    int                          `json:"failed"`
+	Results   []OperationBatchActionResult `json:"results"`
+}
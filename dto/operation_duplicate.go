@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+// OperationDuplicateFilter looks for operations with the same type, amount and
+// currency dated within Days of each other. With an OperationID only the
+// operations matching that one are looked for.
+type OperationDuplicateFilter struct {
+	Days        int `form:"days"`
+	OperationID int `form:"-"`
+}
+
+// OperationDuplicateRow is a pair of operations that may be the same one
+// recorded twice, the older id first.
+type OperationDuplicateRow struct {
+	OperationID          int
+	DuplicateID          int
+	Type                 string
+	Amount               money.Amount
+	Currency             string
+	OperationDate        time.Time
+	DuplicateDate        time.Time
+	OperationDescription string
+	DuplicateDescription string
+}
+
+type TransformedDuplicate struct {
+	Type       string               `json:"type"`
+	Amount     money.Amount         `json:"amount"`
+	Currency   string               `json:"currency"`
+	Similarity float64              `json:"similarity"`
+	Operations []DuplicateOperation `json:"operations"`
+}
+
+type DuplicateOperation struct {
+	ID          int       `json:"id"`
+	Date        time.Time `json:"date"`
+	Description string    `json:"description"`
+}
+
+type OperationMergeRequest struct {
+	DuplicateID string `json:"duplicate_id"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+const (
+	ExportFormatCSV       string = "csv"
+	ExportFormatJSONLines string = "jsonl"
+	ExportFormatOFX       string = "ofx"
+)
+
+// OperationExportRequest selects the operations to export with the filters of
+// the index, sorting and pagination aside, and how to write them.
+type OperationExportRequest struct {
+	OperationFilter
+	Format           string `form:"format"`
+	DecimalSeparator string `form:"decimal_separator"`
+	Delimiter        string `form:"delimiter"`
+}
+
+// OperationExportRow is an operation as read for an export, with the name of
+// its category.
+type OperationExportRow struct {
+	ID           int          `json:"id"`
+	Date         time.Time    `json:"date"`
+	Type         string       `json:"type"`
+	Amount       money.Amount `json:"amount"`
+	Currency     string       `json:"currency"`
+	CategoryID   *int         `json:"category_id"`
+	CategoryName string       `json:"category"`
+	AccountID    *int         `json:"account_id"`
+	TransferID   *int         `json:"transfer_id"`
+	Description  string       `json:"description"`
+	ExternalID   string       `json:"external_id"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import "GoGin-API-CuentasClaras/money"
+
+const (
+	AmountSignNegativeExpense string = "negative_expense"
+	AmountSignPositiveExpense string = "positive_expense"
+)
+
+const (
+	ImportFormatCSV string = "csv"
+	ImportFormatOFX string = "ofx"
+	ImportFormatQIF string = "qif"
+)
+
+const (
+	ImportRowValid     string = "valid"
+	ImportRowInvalid   string = "invalid"
+	ImportRowDuplicate string = "duplicate"
+)
+
+// OperationImportRequest maps the columns of an imported file, named as in its
+// header row, onto the fields of the operations. The column mapping only
+// applies to CSV files, OFX and QIF statements name their own fields.
+type OperationImportRequest struct {
+	Format            string `form:"format"`
+	DateColumn        string `form:"date_column"`
+	AmountColumn      string `form:"amount_column"`
+	DescriptionColumn string `form:"description_column"`
+	CategoryColumn    string `form:"category_column"`
+	TypeColumn        string `form:"type_column"`
+	CurrencyColumn    string `form:"currency_column"`
+	DateFormat        string `form:"date_format"`
+	AmountSign        string `form:"amount_sign"`
+	DecimalSeparator  string `form:"decimal_separator"`
+	Delimiter         string `form:"delimiter"`
+	AccountID         string `form:"account_id"`
+	DefaultCategoryID string `form:"default_category_id"`
+	DryRun            bool   `form:"dry_run"`
+}
+
+type OperationImportRow struct {
+	Line        int          `json:"line"`
+	Date        string       `json:"date"`
+	Type        string       `json:"type"`
+	Amount      money.Amount `json:"amount"`
+	Currency    string       `json:"currency"`
+	Description string       `json:"description"`
+	Category    string       `json:"category"`
+	ExternalID  string       `json:"external_id,omitempty"`
+	Status      string       `json:"status"`
+	Errors      []string     `json:"errors"`
+}
+
+type OperationImportResult struct {
+	DryRun     bool                 `json:"dry_run"`
+	Imported   int                  `json:"imported"`
+	Duplicates int                  `json:"duplicates"`
+	Invalid    int                  `json:"invalid"`
+	Rows       []OperationImportRow `json:"rows"`
+}
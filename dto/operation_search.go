@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+type OperationSearchRequest struct {
+	Query string `form:"q"`
+	Limit int    `form:"limit"`
+}
+
+// OperationSearchRow is an operation matching a search, with its category,
+// rank and the descriptions with the matched words highlighted.
+type OperationSearchRow struct {
+	ID                   int
+	Type                 string
+	Amount               money.Amount
+	Currency             string
+	Date                 time.Time
+	AccountID            *int
+	TransferID           *int
+	Description          string
+	CategoryName         string
+	CategoryColor        string
+	Rank                 float64
+	DescriptionHighlight string
+	CategoryHighlight    string
+}
+
+type TransformedSearchOperation struct {
+	ID          int                 `json:"id"`
+	Type        string              `json:"type"`
+	Amount      money.Amount        `json:"amount"`
+	Currency    string              `json:"currency"`
+	Date        time.Time           `json:"date"`
+	AccountID   *int                `json:"account_id"`
+	TransferID  *int                `json:"transfer_id"`
+	Category    TransformedCategory `json:"category"`
+	Description string              `json:"description"`
+	Rank        float64             `json:"rank"`
+	Highlights  OperationHighlights `json:"highlights"`
+}
+
+type OperationHighlights struct {
+	Description string `json:"description"`
+	Category    string `json:"category"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+type TransformedPayee struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+type PayeeRequest struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+// PayeeMergeRequest names the payee merged into another one.
+type PayeeMergeRequest struct {
+	PayeeID int `json:"payee_id"`
+}
+
+type PayeeTotalsFilter struct {
+	From *time.Time `form:"from"`
+	To   *time.Time `form:"to"`
+}
+
+// PayeeTotalRow is what was spent on a payee in one currency.
+type PayeeTotalRow struct {
+	PayeeID  int          `json:"payee_id"`
+	Name     string       `json:"name"`
+	Currency string       `json:"currency"`
+	Total    money.Amount `json:"total"`
+	Count    int          `json:"count"`
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+const (
+	ReconciliationStatusOpen      string = "open"
+	ReconciliationStatusFinalized string = "finalized"
+)
+
+type ReconciliationRequest struct {
+	AccountID      int          `json:"account_id"`
+	StatementDate  string       `json:"statement_date"`
+	ClosingBalance money.Amount `json:"closing_balance"`
+}
+
+type ReconciliationTickRequest struct {
+	OperationIDs []int `json:"operation_ids"`
+}
+
+type TransformedReconciliation struct {
+	ID             int          `json:"id"`
+	AccountID      int          `json:"account_id"`
+	StatementDate  string       `json:"statement_date"`
+	ClosingBalance money.Amount `json:"closing_balance"`
+	Status         string       `json:"status"`
+	FinalizedAt    *time.Time   `json:"finalized_at"`
+}
+
+// TransformedReconciliationDetail is a reconciliation with the operations it
+// can tick off. The cleared balance is the opening balance of the account plus
+// the operations reconciled before and the ones ticked; the difference is what
+// is left to reach the closing balance of the statement.
+type TransformedReconciliationDetail struct {
+	ID             int                       `json:"id"`
+	AccountID      int                       `json:"account_id"`
+	StatementDate  string                    `json:"statement_date"`
+	ClosingBalance money.Amount              `json:"closing_balance"`
+	Status         string                    `json:"status"`
+	FinalizedAt    *time.Time                `json:"finalized_at"`
+	ClearedBalance money.Amount              `json:"cleared_balance"`
+	Difference     money.Amount              `json:"difference"`
+	Operations     []ReconciliationOperation `json:"operations"`
+}
+
+type ReconciliationOperation struct {
+	ID          int          `json:"id"`
+	Type        string       `json:"type"`
+	Amount      money.Amount `json:"amount"`
+	Date        time.Time    `json:"date"`
+	Description string       `json:"description"`
+	Status      string       `json:"status"`
+	Ticked      bool         `json:"ticked"`
+}
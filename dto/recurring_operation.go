@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+type TransformedRecurringOperation struct {
+	ID                 int                 `json:"id"`
+	Type               string              `json:"type"`
+	Amount             money.Amount        `json:"amount"`
+	Currency           string              `json:"currency"`
+	Description        string              `json:"description"`
+	Category           TransformedCategory `json:"category"`
+	AccountID          *int                `json:"account_id"`
+	Frequency          string              `json:"frequency"`
+	DayOfMonth         int                 `json:"day_of_month"`
+	StartDate          time.Time           `json:"start_date"`
+	EndDate            *time.Time          `json:"end_date"`
+	Count              int                 `json:"count"`
+	OccurrencesCreated int                 `json:"occurrences_created"`
+	NextRunAt          *time.Time          `json:"next_run_at"`
+}
+
+type RecurringOperationRequest struct {
+	Type        string       `json:"type"`
+	Amount      money.Amount `json:"amount"`
+	Currency    string       `json:"currency"`
+	Description string       `json:"description"`
+	CategoryID  string       `json:"category_id"`
+	AccountID   string       `json:"account_id"`
+	Frequency   string       `json:"frequency"`
+	DayOfMonth  int          `json:"day_of_month"`
+	StartDate   string       `json:"start_date"`
+	EndDate     string       `json:"end_date"`
+	Count       int          `json:"count"`
+}
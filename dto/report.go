@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+// Intervals the balance history is grouped by.
+const (
+	IntervalDay   string = "day"
+	IntervalWeek  string = "week"
+	IntervalMonth string = "month"
+)
+
+type BalanceHistoryFilter struct {
+	From     *time.Time `form:"from"`
+	To       *time.Time `form:"to"`
+	Interval string     `form:"interval"`
+}
+
+// BalanceHistoryRow is one interval of the balance history in one currency.
+type BalanceHistoryRow struct {
+	Bucket   time.Time
+	Currency string
+	Income   money.Amount
+	Expense  money.Amount
+	Balance  money.Amount
+}
+
+type BalanceHistoryPoint struct {
+	Date    string       `json:"date"`
+	Income  money.Amount `json:"income"`
+	Expense money.Amount `json:"expense"`
+	Balance money.Amount `json:"balance"`
+}
+
+type BalanceHistorySeries struct {
+	Currency string                `json:"currency"`
+	Points   []BalanceHistoryPoint `json:"points"`
+}
+
+type BalanceHistoryResponse struct {
+	Interval string                 `json:"interval"`
+	From     string                 `json:"from"`
+	To       string                 `json:"to"`
+	Series   []BalanceHistorySeries `json:"series"`
+}
+
+type CategoryReportFilter struct {
+	From *time.Time `form:"from"`
+	To   *time.Time `form:"to"`
+	Type string     `form:"type"`
+}
+
+// CategoryReportRow is what the operations of a type added up to in one
+// category and currency. The category is nil for the uncategorized amounts.
+type CategoryReportRow struct {
+	Currency   string
+	CategoryID *int
+	Name       string
+	Color      string
+	IsDefault  bool
+	Total      money.Amount
+	Count      int
+}
+
+type CategoryReportLine struct {
+	CategoryID int          `json:"category_id"`
+	Name       string       `json:"name"`
+	Color      string       `json:"color"`
+	IsDefault  bool         `json:"is_default"`
+	Total      money.Amount `json:"total"`
+	Count      int          `json:"count"`
+	Percentage float64      `json:"percentage"`
+}
+
+type CategoryReportAmount struct {
+	Total      money.Amount `json:"total"`
+	Count      int          `json:"count"`
+	Percentage float64      `json:"percentage"`
+}
+
+type CategoryReportCurrency struct {
+	Currency      string               `json:"currency"`
+	Total         money.Amount         `json:"total"`
+	Categories    []CategoryReportLine `json:"categories"`
+	Uncategorized CategoryReportAmount `json:"uncategorized"`
+}
+
+type CategoryReportResponse struct {
+	Type       string                   `json:"type"`
+	Currencies []CategoryReportCurrency `json:"currencies"`
+}
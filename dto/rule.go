@@ -0,0 +1,51 @@
+package dto
+
+import "GoGin-API-CuentasClaras/money"
+
+type TransformedRule struct {
+	ID                  int           `json:"id"`
+	Name                string        `json:"name"`
+	Priority            int           `json:"priority"`
+	DescriptionContains string        `json:"description_contains"`
+	DescriptionRegex    string        `json:"description_regex"`
+	Type                string        `json:"type"`
+	MinAmount           *money.Amount `json:"min_amount"`
+	MaxAmount           *money.Amount `json:"max_amount"`
+	SetCategoryID       *int          `json:"set_category_id"`
+	AddTags             []string      `json:"add_tags"`
+	SetDescription      string        `json:"set_description"`
+}
+
+// RuleRequest is a rule as sent by the user: the description, type and amount
+// conditions an operation must meet, and the category, tags and description it
+// then gets.
+type RuleRequest struct {
+	Name                string        `json:"name"`
+	Priority            int           `json:"priority"`
+	DescriptionContains string        `json:"description_contains"`
+	DescriptionRegex    string        `json:"description_regex"`
+	Type                string        `json:"type"`
+	MinAmount           *money.Amount `json:"min_amount"`
+	MaxAmount           *money.Amount `json:"max_amount"`
+	SetCategoryID       string        `json:"set_category_id"`
+	AddTags             []string      `json:"add_tags"`
+	SetDescription      string        `json:"set_description"`
+}
+
+type RuleApplyRequest struct {
+	Preview bool `form:"preview"`
+}
+
+// RuleApplyResult lists the operations the rules change, with the rules that
+// matched each one and the changes they make.
+type RuleApplyResult struct {
+	Preview    bool                 `json:"preview"`
+	Changed    int                  `json:"changed"`
+	Operations []RuleApplyOperation `json:"operations"`
+}
+
+type RuleApplyOperation struct {
+	ID      int           `json:"id"`
+	Rules   []int         `json:"rules"`
+	Changes []AuditChange `json:"changes"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type TransformedTag struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	OperationsCount int64  `json:"operations_count"`
+}
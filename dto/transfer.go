@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+type TransformedTransfer struct {
+	ID            int          `json:"id"`
+	FromAccountID int          `json:"from_account_id"`
+	ToAccountID   int          `json:"to_account_id"`
+	Amount        money.Amount `json:"amount"`
+	ToAmount      money.Amount `json:"to_amount"`
+	Date          time.Time    `json:"date"`
+	Description   string       `json:"description"`
+}
+
+type TransferRequest struct {
+	FromAccountID int          `json:"from_account_id"`
+	ToAccountID   int          `json:"to_account_id"`
+	Amount        money.Amount `json:"amount"`
+	ToAmount      money.Amount `json:"to_amount"`
+	Date          string       `json:"date"`
+	Description   string       `json:"description"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"GoGin-API-CuentasClaras/money"
+	"time"
+)
+
+type TrashedOperation struct {
+	ID          int          `json:"id"`
+	Type        string       `json:"type"`
+	Amount      money.Amount `json:"amount"`
+	Currency    string       `json:"currency"`
+	Date        time.Time    `json:"date"`
+	Description string       `json:"description"`
+	CategoryID  *int         `json:"category_id"`
+	AccountID   *int         `json:"account_id"`
+	DeletedAt   time.Time    `json:"deleted_at"`
+}
+
+type TrashedCategory struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color"`
+	Description string    `json:"description"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+type TransformedTrash struct {
+	Operations []TrashedOperation `json:"operations"`
+	Categories []TrashedCategory  `json:"categories"`
+}
@@ -10,3 +10,27 @@ type LoginRequest struct {
 	Email    string `json:"email" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
+
+type BaseCurrencyRequest struct {
+	BaseCurrency string `json:"base_currency" binding:"required"`
+}
+
+type CurrencyBalance struct {
+	Currency string `json:"currency"`
+	Balance  string `json:"balance"`
+}
+
+type AccountBalance struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+	Balance  string `json:"balance"`
+}
+
+type BalanceResponse struct {
+	TotalBalance string            `json:"total_balance"`
+	BaseCurrency string            `json:"base_currency"`
+	Date         string            `json:"date"`
+	Balances     []CurrencyBalance `json:"balances"`
+	Accounts     []AccountBalance  `json:"accounts"`
+}
@@ -0,0 +1,107 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestAccountsIntegration_ExistingOperationsMigrated(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the existing operations are moved to a default account",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"name\":\"Default\",\"type\":\"cash\",\"currency\":\"ARS\",\"opening_balance\":\"0.00\",\"is_default\":true}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest("GET", "/api/accounts", strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
+
+func TestAccountsIntegration_Create_ValidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the account is created successfully",
+			Params:       `{"name": "Bank", "type": "bank", "opening_balance": 300}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Account successfully created.\"}",
+		},
+		{
+			Name:         "when an operation is created in the account",
+			Params:       `{"type": "expense", "amount": 100, "date": "2023-10-23T21:33:03.73297Z", "description": "Groceries", "category_id": "1", "account_id": "2"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
+		},
+		{
+			Name:         "when the balance includes every account",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"total_balance\":\"1400.50\",\"base_currency\":\"ARS\",\"date\":\"2023-10-24\",\"balances\":[{\"currency\":\"ARS\",\"balance\":\"1400.50\"}]," +
+				"\"accounts\":[{\"id\":1,\"name\":\"Default\",\"currency\":\"ARS\",\"balance\":\"1200.50\"},{\"id\":2,\"name\":\"Bank\",\"currency\":\"ARS\",\"balance\":\"200.00\"}]}",
+		},
+	}
+	uris := map[string]string{
+		"when the account is created successfully":    "/api/accounts",
+		"when an operation is created in the account": "/api/operations",
+		"when the balance includes every account":     "/api/users/balance?date=2023-10-24",
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			method := "POST"
+			if tt.Params == "" {
+				method = "GET"
+			}
+			request, _ := http.NewRequest(method, uris[tt.Name], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
+
+func TestAccountsIntegration_Delete_InvalidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the account is the default one",
+			Params:       "",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The default account cannot be deleted.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest("DELETE", "/api/accounts/1", strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
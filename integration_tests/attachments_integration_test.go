@@ -0,0 +1,116 @@
+package integration_tests
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestAttachmentsIntegration_ValidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the attachment is uploaded",
+			Params:       "%PDF-1.4 receipt",
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Attachment successfully created.\"}",
+		},
+		{
+			Name:         "when the attachments are listed",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"file_name\":\"receipt.pdf\",\"content_type\":\"application/pdf\",\"size\":16}]",
+		},
+		{
+			Name:         "when the attachment is downloaded",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "%PDF-1.4 receipt",
+		},
+		{
+			Name:         "when the operation is deleted",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully deleted.\"}",
+		},
+		{
+			Name:         "when the attachments of the deleted operation are listed",
+			Params:       "",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	requests := map[string][]string{
+		"when the attachment is uploaded":                          {"POST", "/api/operations/1/attachments"},
+		"when the attachments are listed":                          {"GET", "/api/operations/1/attachments"},
+		"when the attachment is downloaded":                        {"GET", "/api/operations/1/attachments/1"},
+		"when the operation is deleted":                            {"DELETE", "/api/operations/1"},
+		"when the attachments of the deleted operation are listed": {"GET", "/api/operations/1/attachments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			if tt.Params != "" && requests[tt.Name][0] == "POST" {
+				part, _ := writer.CreateFormFile("file", "receipt.pdf")
+				part.Write([]byte(tt.Params))
+			}
+			writer.Close()
+
+			request, _ := http.NewRequest(requests[tt.Name][0], requests[tt.Name][1], body)
+			request.Header.Set("Content-Type", writer.FormDataContentType())
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
+
+func TestAttachmentsIntegration_InvalidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the file type is not allowed",
+			Params:       "plain text receipt",
+			ExpectedCode: http.StatusUnsupportedMediaType,
+			ExpectedBody: "{\"error\":\"Unsupported file type.\"}",
+		},
+		{
+			Name:         "when the operation belongs to another user",
+			Params:       "%PDF-1.4 receipt",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			part, _ := writer.CreateFormFile("file", "receipt.pdf")
+			part.Write([]byte(tt.Params))
+			writer.Close()
+
+			request, _ := http.NewRequest("POST", "/api/operations/1/attachments", body)
+			request.Header.Set("Content-Type", writer.FormDataContentType())
+			if tt.Name == "when the operation belongs to another user" {
+				request.Header.Set("Authorization", "Bearer "+anotherToken)
+			} else {
+				request.Header.Set("Authorization", "Bearer "+token)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
@@ -0,0 +1,128 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestAuditIntegration_ValidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the amount of the operation is changed",
+			Params:       `{"type": "income", "amount": 1500, "date": "2023-10-23T21:33:03.73297Z", "description": "Salario", "category_id": "1"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully updated.\"}",
+		},
+		{
+			Name:         "when the amount of the operation is changed again",
+			Params:       `{"type": "income", "amount": 1800, "date": "2023-10-23T21:33:03.73297Z", "description": "Salario", "category_id": "1"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully updated.\"}",
+		},
+		{
+			Name:         "when the operation is reverted to its previous amount",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully reverted.\"}",
+		},
+		{
+			Name:         "when the history of the operation is listed",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":3,\"action\":\"revert\",\"actor_id\":1,\"actor\":\"pedro.fuentes\",\"created_at\":\"2023-10-25T10:00:00Z\",\"changes\":[{\"field\":\"amount\",\"before\":\"1800.00\",\"after\":\"1500.00\"}]}," +
+				"{\"id\":2,\"action\":\"update\",\"actor_id\":1,\"actor\":\"pedro.fuentes\",\"created_at\":\"2023-10-25T10:00:00Z\",\"changes\":[{\"field\":\"amount\",\"before\":\"1500.00\",\"after\":\"1800.00\"}]}," +
+				"{\"id\":1,\"action\":\"update\",\"actor_id\":1,\"actor\":\"pedro.fuentes\",\"created_at\":\"2023-10-25T10:00:00Z\",\"changes\":[{\"field\":\"amount\",\"before\":\"1200.50\",\"after\":\"1500.00\"}]}]",
+		},
+		{
+			Name:         "when a category is created",
+			Params:       `{"name": "Travel", "color": "#6495ed", "description": "Travel"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Category successfully created.\"}",
+		},
+		{
+			Name:         "when the category is renamed",
+			Params:       `{"name": "Trips", "color": "#6495ed", "description": "Travel"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Category successfully updated.\"}",
+		},
+		{
+			Name:         "when the category is reverted to its first version",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Category successfully reverted.\"}",
+		},
+		{
+			Name:         "when the history of the category is listed",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":6,\"action\":\"revert\",\"actor_id\":1,\"actor\":\"pedro.fuentes\",\"created_at\":\"2023-10-25T10:00:00Z\",\"changes\":[{\"field\":\"name\",\"before\":\"Trips\",\"after\":\"Travel\"}]}," +
+				"{\"id\":5,\"action\":\"update\",\"actor_id\":1,\"actor\":\"pedro.fuentes\",\"created_at\":\"2023-10-25T10:00:00Z\",\"changes\":[{\"field\":\"name\",\"before\":\"Travel\",\"after\":\"Trips\"}]}," +
+				"{\"id\":4,\"action\":\"create\",\"actor_id\":1,\"actor\":\"pedro.fuentes\",\"created_at\":\"2023-10-25T10:00:00Z\",\"changes\":[{\"field\":\"color\",\"before\":null,\"after\":\"#6495ed\"},{\"field\":\"description\",\"before\":null,\"after\":\"Travel\"},{\"field\":\"name\",\"before\":null,\"after\":\"Travel\"}]}]",
+		},
+	}
+	requests := map[string][]string{
+		"when the amount of the operation is changed":           {"PUT", "/api/operations/1"},
+		"when the amount of the operation is changed again":     {"PUT", "/api/operations/1"},
+		"when the operation is reverted to its previous amount": {"POST", "/api/operations/1/history/1/revert"},
+		"when the history of the operation is listed":           {"GET", "/api/operations/1/history"},
+		"when a category is created":                            {"POST", "/api/categories"},
+		"when the category is renamed":                          {"PUT", "/api/categories/3"},
+		"when the category is reverted to its first version":    {"POST", "/api/categories/3/history/4/revert"},
+		"when the history of the category is listed":            {"GET", "/api/categories/3/history"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			if requests[tt.Name][0] == "GET" {
+				db.Exec("UPDATE audit_entries SET created_at = '2023-10-25T10:00:00Z'")
+			}
+			request, _ := http.NewRequest(requests[tt.Name][0], requests[tt.Name][1], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
+
+func TestAuditIntegration_InvalidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operation is deleted",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully deleted.\"}",
+		},
+		{
+			Name:         "when the deletion is reverted",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The version cannot be restored.\"}",
+		},
+		{
+			Name:         "when the history belongs to another user",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	requests := map[string][]string{
+		"when the operation is deleted":            {"DELETE", "/api/operations/1", token},
+		"when the deletion is reverted":            {"POST", "/api/operations/1/history/1/revert", token},
+		"when the history belongs to another user": {"GET", "/api/operations/1/history", anotherToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest(requests[tt.Name][0], requests[tt.Name][1], nil)
+			request.Header.Set("Authorization", "Bearer "+requests[tt.Name][2])
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
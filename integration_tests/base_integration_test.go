@@ -5,9 +5,11 @@ import (
 	"GoGin-API-CuentasClaras/api/auth"
 	"GoGin-API-CuentasClaras/config"
 	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/money"
 	"GoGin-API-CuentasClaras/repository"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -32,6 +34,7 @@ func TestMain(m *testing.M) {
 func InitTest() *gorm.DB {
 	godotenv.Load("../.env")
 	os.Setenv("DB_DSN", os.Getenv("DB_DSN_TEST"))
+	os.Setenv("ATTACHMENTS_DIR", filepath.Join(os.TempDir(), "cuentas-claras-attachments"))
 
 	return config.ConnectToDB()
 }
@@ -39,7 +42,20 @@ func InitTest() *gorm.DB {
 func cleanDB() {
 	db.Exec("DROP TABLE users CASCADE;")
 	db.Exec("DROP TABLE operations CASCADE;")
+	db.Exec("DROP TABLE operation_splits CASCADE;")
 	db.Exec("DROP TABLE categories CASCADE;")
+	db.Exec("DROP TABLE recurring_operations CASCADE;")
+	db.Exec("DROP TABLE exchange_rates CASCADE;")
+	db.Exec("DROP TABLE accounts CASCADE;")
+	db.Exec("DROP TABLE transfers CASCADE;")
+	db.Exec("DROP TABLE attachments CASCADE;")
+	db.Exec("DROP TABLE tags CASCADE;")
+	db.Exec("DROP TABLE operation_tags CASCADE;")
+	db.Exec("DROP TABLE audit_entries CASCADE;")
+	db.Exec("DROP TABLE idempotency_keys CASCADE;")
+	db.Exec("DROP TABLE rules CASCADE;")
+	db.Exec("DROP TABLE reconciliations CASCADE;")
+	db.Exec("DROP TABLE payees CASCADE;")
 	fmt.Println("Database cleaned.")
 }
 
@@ -77,7 +93,8 @@ func setupTest() *gin.Engine {
 		UserID:      uint(user.ID),
 		Category:    category,
 		Type:        "income",
-		Amount:      1200.5,
+		Amount:      money.FromFloat(1200.5),
+		Currency:    "ARS",
 		Date:        dateInUTC,
 		Description: "Salario",
 	})
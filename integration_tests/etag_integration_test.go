@@ -0,0 +1,79 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestETagIntegration(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operation is shown with its ETag",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297Z\",\"account_id\":1,\"transfer_id\":null,\"status\":\"pending\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\",\"description\":\"Work\",\"is_default\":true},\"description\":\"Salario\",\"splits\":[],\"tags\":[]}",
+		},
+		{
+			Name:         "when the operation did not change since the ETag",
+			Params:       "",
+			ExpectedCode: http.StatusNotModified,
+			ExpectedBody: "",
+		},
+		{
+			Name:         "when the operation is updated with a stale ETag",
+			Params:       `{"type": "income", "amount": 1500, "date": "2023-10-23T21:33:03Z", "description": "Salario", "category_id": "1"}`,
+			ExpectedCode: http.StatusPreconditionFailed,
+			ExpectedBody: "{\"error\":\"The operation has been modified.\"}",
+		},
+		{
+			Name:         "when the operation is updated with its ETag",
+			Params:       `{"type": "income", "amount": 1500, "date": "2023-10-23T21:33:03Z", "description": "Salario", "category_id": "1"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully updated.\"}",
+		},
+		{
+			Name:         "when the operation is deleted with the ETag before the update",
+			Params:       "",
+			ExpectedCode: http.StatusPreconditionFailed,
+			ExpectedBody: "{\"error\":\"The operation has been modified.\"}",
+		},
+	}
+	requests := map[string][]string{
+		"when the operation is shown with its ETag":                     {"GET", "/api/operations/1"},
+		"when the operation did not change since the ETag":              {"GET", "/api/operations/1"},
+		"when the operation is updated with a stale ETag":               {"PUT", "/api/operations/1"},
+		"when the operation is updated with its ETag":                   {"PUT", "/api/operations/1"},
+		"when the operation is deleted with the ETag before the update": {"DELETE", "/api/operations/1"},
+	}
+	etag := ""
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest(requests[tt.Name][0], requests[tt.Name][1], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			if tt.Name == "when the operation did not change since the ETag" {
+				request.Header.Set("If-None-Match", etag)
+			} else if tt.Name == "when the operation is updated with a stale ETag" {
+				request.Header.Set("If-Match", `"stale"`)
+			} else if requests[tt.Name][0] != "GET" {
+				request.Header.Set("If-Match", etag)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			if tt.Name == "when the operation is shown with its ETag" {
+				etag = responseRecorder.Header().Get("ETag")
+			}
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
@@ -0,0 +1,74 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyIntegration(t *testing.T) {
+	router := setupTest()
+	operation := `{"type": "expense", "amount": "35.90", "date": "2023-10-24T10:00:00Z", "description": "Supermarket", "category_id": "1"}`
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operation is created",
+			Params:       operation,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
+		},
+		{
+			Name:         "when the creation is retried with the same key",
+			Params:       operation,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
+		},
+		{
+			Name:         "when the key is reused with a different payload",
+			Params:       strings.Replace(operation, "35.90", "40.00", 1),
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The idempotency key was already used with a different request.\"}",
+		},
+		{
+			Name:         "when another user sends the same key",
+			Params:       operation,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
+		},
+		{
+			Name:         "when a category is created with the same key",
+			Params:       `{"name": "Travel", "color": "#6495ed", "description": "Travel"}`,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The idempotency key was already used with a different request.\"}",
+		},
+	}
+	requests := map[string][]string{
+		"when the operation is created":                   {"/api/operations", token},
+		"when the creation is retried with the same key":  {"/api/operations", token},
+		"when the key is reused with a different payload": {"/api/operations", token},
+		"when another user sends the same key":            {"/api/operations", anotherToken},
+		"when a category is created with the same key":    {"/api/categories", token},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest("POST", requests[tt.Name][0], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+requests[tt.Name][1])
+			request.Header.Set("Idempotency-Key", "3f1c2a9e-create-supermarket")
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+
+	var operations int64
+	db.Table("operations").Where("description = ?", "Supermarket").Count(&operations)
+	assert.Equal(t, int64(2), operations)
+	teardownTest()
+}
@@ -0,0 +1,24 @@
+package integration_tests
+
+import (
+	"GoGin-API-CuentasClaras/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyColumnsMigrationIntegration(t *testing.T) {
+	cleanDB()
+	db.Exec("CREATE TABLE transfers (id bigserial PRIMARY KEY, amount double precision, to_amount double precision)")
+	db.Exec("INSERT INTO transfers (amount, to_amount) VALUES (1200.499999, 0.1)")
+
+	repository.TransferRepositoryInit(db)
+
+	var amount, toAmount, dataType string
+	db.Raw("SELECT amount::text, to_amount::text FROM transfers").Row().Scan(&amount, &toAmount)
+	db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'transfers' AND column_name = 'amount'").Row().Scan(&dataType)
+	assert.Equal(t, "1200.50", amount)
+	assert.Equal(t, "0.10", toAmount)
+	assert.Equal(t, "numeric", dataType)
+	teardownTest()
+}
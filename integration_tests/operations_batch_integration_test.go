@@ -0,0 +1,54 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationsBatchIntegration(t *testing.T) {
+	router := setupTest()
+	actions := `"actions": [` +
+		`{"action": "create", "operation": {"type": "expense", "amount": 300, "date": "2023-10-24T12:00:00Z", "description": "Supermercado", "category_id": "1"}},` +
+		`{"action": "delete", "id": 99}]`
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when an action fails with all or nothing",
+			Params:       `{"mode": "all_or_nothing", ` + actions + `}`,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"mode\":\"all_or_nothing\",\"committed\":false,\"succeeded\":1,\"failed\":1,\"results\":[" +
+				"{\"index\":0,\"action\":\"create\",\"id\":0,\"status\":201,\"response\":{\"message\":\"Operation successfully created.\"}}," +
+				"{\"index\":1,\"action\":\"delete\",\"id\":99,\"status\":404,\"response\":{\"error\":\"Not found.\"}}]}",
+		},
+		{
+			Name:         "when an action fails with best effort",
+			Params:       `{"mode": "best_effort", ` + actions + `}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"mode\":\"best_effort\",\"committed\":true,\"succeeded\":1,\"failed\":1,\"results\":[" +
+				"{\"index\":0,\"action\":\"create\",\"id\":3,\"status\":201,\"response\":{\"message\":\"Operation successfully created.\"}}," +
+				"{\"index\":1,\"action\":\"delete\",\"id\":99,\"status\":404,\"response\":{\"error\":\"Not found.\"}}]}",
+		},
+	}
+	expectedCounts := []int64{1, 2}
+	for index, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest("POST", "/api/operations/batch", strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+			var count int64
+			db.Table("operations").Where("deleted_at IS NULL").Count(&count)
+			assert.Equal(t, expectedCounts[index], count)
+		})
+	}
+	teardownTest()
+}
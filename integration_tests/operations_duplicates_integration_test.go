@@ -0,0 +1,75 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestOperationsDuplicatesIntegration(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when an operation is created",
+			Params:       `{"type": "expense", "amount": 50, "date": "2023-10-20T10:00:00Z", "description": "Coffee shop", "category_id": "1"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
+		},
+		{
+			Name:         "when the same operation is created again",
+			Params:       `{"type": "expense", "amount": 50, "date": "2023-10-21T10:00:00Z", "description": "COFFEE SHOP #12", "category_id": "1"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"duplicate_ids\":[2],\"message\":\"Operation successfully created.\",\"warning\":\"The operation looks like a duplicate.\"}",
+		},
+		{
+			Name:         "when the duplicated operations are listed",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"type\":\"expense\",\"amount\":\"50.00\",\"currency\":\"ARS\",\"similarity\":0.87,\"operations\":[" +
+				"{\"id\":2,\"date\":\"2023-10-20T10:00:00Z\",\"description\":\"Coffee shop\"}," +
+				"{\"id\":3,\"date\":\"2023-10-21T10:00:00Z\",\"description\":\"COFFEE SHOP #12\"}]}]",
+		},
+		{
+			Name:         "when the operations are merged",
+			Params:       `{"duplicate_id": "3"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operations successfully merged.\"}",
+		},
+		{
+			Name:         "when the duplicated operations are listed after the merge",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+		{
+			Name:         "when the duplicate is shown after the merge",
+			Params:       "",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	requests := map[string][]string{
+		"when an operation is created":                              {"POST", "/api/operations"},
+		"when the same operation is created again":                  {"POST", "/api/operations"},
+		"when the duplicated operations are listed":                 {"GET", "/api/operations/duplicates"},
+		"when the operations are merged":                            {"POST", "/api/operations/2/merge"},
+		"when the duplicated operations are listed after the merge": {"GET", "/api/operations/duplicates"},
+		"when the duplicate is shown after the merge":               {"GET", "/api/operations/3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest(requests[tt.Name][0], requests[tt.Name][1], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
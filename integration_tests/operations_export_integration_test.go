@@ -0,0 +1,46 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestOperationsExportIntegration(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operations are exported as CSV",
+			Params:       "?format=csv&decimal_separator=,",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "id;date;type;amount;currency;category;description;account_id;transfer_id;external_id\n" +
+				"1;2023-10-23;income;1200,50;ARS;Work;Salario;1;;\n",
+		},
+		{
+			Name:         "when the operations are exported as JSON Lines",
+			Params:       "?format=jsonl&type=income",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"date\":\"2023-10-23T21:33:03.73297Z\",\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"category_id\":1,\"category\":\"Work\",\"account_id\":1,\"transfer_id\":null,\"description\":\"Salario\",\"external_id\":\"\"}\n",
+		},
+		{
+			Name:         "when no operation matches the filters",
+			Params:       "?type=expense",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "id,date,type,amount,currency,category,description,account_id,transfer_id,external_id\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest("GET", "/api/operations/export"+tt.Params, nil)
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
@@ -0,0 +1,134 @@
+package integration_tests
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/patch"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationsImportIntegration(t *testing.T) {
+	router := setupTest()
+	csvFile := "date,amount,description,category\n2023-10-23,1200.5,Salario,Work\n2023-10-24,-300,Supermercado,work\n"
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when it is a dry run",
+			Params:       "true",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"dry_run\":true,\"imported\":0,\"duplicates\":1,\"invalid\":0,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-23\",\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"description\":\"Salario\",\"category\":\"Work\",\"status\":\"duplicate\",\"errors\":[]}," +
+				"{\"line\":3,\"date\":\"2023-10-24\",\"type\":\"expense\",\"amount\":\"300.00\",\"currency\":\"ARS\",\"description\":\"Supermercado\",\"category\":\"work\",\"status\":\"valid\",\"errors\":[]}]}",
+		},
+		{
+			Name:         "when the file is imported",
+			Params:       "false",
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"dry_run\":false,\"imported\":1,\"duplicates\":1,\"invalid\":0,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-23\",\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"description\":\"Salario\",\"category\":\"Work\",\"status\":\"duplicate\",\"errors\":[]}," +
+				"{\"line\":3,\"date\":\"2023-10-24\",\"type\":\"expense\",\"amount\":\"300.00\",\"currency\":\"ARS\",\"description\":\"Supermercado\",\"category\":\"work\",\"status\":\"valid\",\"errors\":[]}]}",
+		},
+		{
+			Name:         "when the file is imported again",
+			Params:       "false",
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"dry_run\":false,\"imported\":0,\"duplicates\":2,\"invalid\":0,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-23\",\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"description\":\"Salario\",\"category\":\"Work\",\"status\":\"duplicate\",\"errors\":[]}," +
+				"{\"line\":3,\"date\":\"2023-10-24\",\"type\":\"expense\",\"amount\":\"300.00\",\"currency\":\"ARS\",\"description\":\"Supermercado\",\"category\":\"work\",\"status\":\"duplicate\",\"errors\":[]}]}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			writer.WriteField("dry_run", tt.Params)
+			part, _ := writer.CreateFormFile("file", "operations.csv")
+			part.Write([]byte(csvFile))
+			writer.Close()
+
+			request, _ := http.NewRequest("POST", "/api/operations/import", body)
+			request.Header.Set("Content-Type", writer.FormDataContentType())
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
+
+func TestOperationsOFXImportIntegration(t *testing.T) {
+	router := setupTest()
+	ofxFile := "<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>ARS<BANKTRANLIST>\n" +
+		"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20231024<TRNAMT>-300.00<FITID>A-1<NAME>Supermercado</STMTTRN>\n" +
+		"</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>\n"
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the statement is imported",
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"dry_run\":false,\"imported\":1,\"duplicates\":0,\"invalid\":0,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-24\",\"type\":\"expense\",\"amount\":\"300.00\",\"currency\":\"ARS\",\"description\":\"Supermercado\",\"category\":\"\",\"external_id\":\"A-1\",\"status\":\"valid\",\"errors\":[]}]}",
+		},
+		{
+			Name:         "when the statement is imported again",
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"dry_run\":false,\"imported\":0,\"duplicates\":1,\"invalid\":0,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-24\",\"type\":\"expense\",\"amount\":\"300.00\",\"currency\":\"ARS\",\"description\":\"Supermercado\",\"category\":\"\",\"external_id\":\"A-1\",\"status\":\"duplicate\",\"errors\":[]}]}",
+		},
+		{
+			Name:         "when the statement is imported again after the operation is edited",
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"dry_run\":false,\"imported\":0,\"duplicates\":1,\"invalid\":0,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-24\",\"type\":\"expense\",\"amount\":\"300.00\",\"currency\":\"ARS\",\"description\":\"Supermercado\",\"category\":\"\",\"external_id\":\"A-1\",\"status\":\"duplicate\",\"errors\":[]}]}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			if tt.Name == "when the statement is imported again after the operation is edited" {
+				editImportedOperation(t, router, "A-1")
+			}
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			writer.WriteField("default_category_id", "1")
+			part, _ := writer.CreateFormFile("file", "statement.ofx")
+			part.Write([]byte(ofxFile))
+			writer.Close()
+
+			request, _ := http.NewRequest("POST", "/api/operations/import", body)
+			request.Header.Set("Content-Type", writer.FormDataContentType())
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
+
+// editImportedOperation renames the operation imported with the external id,
+// as a user tidying up a statement would.
+func editImportedOperation(t *testing.T, router *gin.Engine, externalID string) {
+	var operation dao.Operation
+	db.Where("external_id = ?", externalID).First(&operation)
+	request, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/operations/%d", operation.ID), strings.NewReader(`{"description": "Supermercado del barrio"}`))
+	request.Header.Set("Content-Type", patch.MergePatchContentType)
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	responseRecorder := httptest.NewRecorder()
+	router.ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
@@ -17,24 +17,36 @@ func TestOperationsIntegration_Index_ValidRequest(t *testing.T) {
 			Name:         "when the user has operations",
 			Params:       "",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "[{\"id\":1,\"type\":\"income\",\"amount\":1200.5,\"date\":\"2023-10-23T21:33:03.73297Z\",\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"}}]",
+			ExpectedBody: "{\"operations\":[{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297Z\",\"account_id\":1,\"transfer_id\":null,\"status\":\"pending\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"tags\":[]}],\"next_cursor\":\"\"}",
 		},
 		{
 			Name:         "when the user has no operations",
 			Params:       "",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "[]",
+			ExpectedBody: "{\"operations\":[],\"next_cursor\":\"\"}",
+		},
+		{
+			Name:         "when the filters exclude every operation",
+			Params:       "?type=expense&min_amount=10",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[],\"next_cursor\":\"\"}",
+		},
+		{
+			Name:         "when the cursor is invalid",
+			Params:       "?cursor=invalid",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid cursor.\"}",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
-			request, _ := http.NewRequest("GET", "/api/operations", strings.NewReader(tt.Params))
+			request, _ := http.NewRequest("GET", "/api/operations"+tt.Params, nil)
 			request.Header.Set("Content-Type", "application/json")
 
-			if tt.Name == "when the user has operations" {
-				request.Header.Set("Authorization", "Bearer "+token)
-			} else {
+			if tt.Name == "when the user has no operations" {
 				request.Header.Set("Authorization", "Bearer "+anotherToken)
+			} else {
+				request.Header.Set("Authorization", "Bearer "+token)
 			}
 
 			responseRecorder := httptest.NewRecorder()
@@ -79,7 +91,7 @@ func TestOperationsIntegration_Show_ValidRequest(t *testing.T) {
 			Name:         "when the operation is found",
 			Params:       "",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "{\"id\":1,\"type\":\"income\",\"amount\":1200.5,\"date\":\"2023-10-23T21:33:03.73297Z\",\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\",\"description\":\"Work\",\"is_default\":true},\"description\":\"Salario\"}",
+			ExpectedBody: "{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297Z\",\"account_id\":1,\"transfer_id\":null,\"status\":\"pending\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\",\"description\":\"Work\",\"is_default\":true},\"description\":\"Salario\",\"splits\":[],\"tags\":[]}",
 		},
 	}
 	for _, tt := range tests {
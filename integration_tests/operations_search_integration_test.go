@@ -0,0 +1,65 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"GoGin-API-CuentasClaras/dto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationsSearchIntegration(t *testing.T) {
+	router := setupTest()
+	request, _ := http.NewRequest("POST", "/api/operations", strings.NewReader(`{"type": "expense", "amount": "8500", "date": "2023-10-20T15:04:05Z", "description": "Pago al plomero", "category_id": "1"}`))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(httptest.NewRecorder(), request)
+	request, _ = http.NewRequest("POST", "/api/operations", strings.NewReader(`{"type": "expense", "amount": "120", "date": "2023-10-21T15:04:05Z", "description": "<img src=x onerror=alert(1)> running shoes", "category_id": "1"}`))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	var tests = []struct {
+		Name                 string
+		Query                string
+		Token                string
+		ExpectedCode         int
+		ExpectedDescriptions []string
+		ExpectedHighlight    string
+	}{
+		{Name: "when the description matches in Spanish", Query: "plomeros", Token: token, ExpectedCode: http.StatusOK, ExpectedDescriptions: []string{"Pago al plomero"}, ExpectedHighlight: "Pago al <mark>plomero</mark>"},
+		{Name: "when the description matches in English", Query: "runs", Token: token, ExpectedCode: http.StatusOK, ExpectedDescriptions: []string{"<img src=x onerror=alert(1)> running shoes"}, ExpectedHighlight: "&lt;img src=x onerror=alert(1)&gt; <mark>running</mark> shoes"},
+		{Name: "when the category description matches", Query: "work", Token: token, ExpectedCode: http.StatusOK, ExpectedDescriptions: []string{"Pago al plomero", "<img src=x onerror=alert(1)> running shoes", "Salario"}},
+		{Name: "when the operations belong to another user", Query: "plomero", Token: anotherToken, ExpectedCode: http.StatusOK, ExpectedDescriptions: []string{}},
+		{Name: "when the search is empty", Query: "", Token: token, ExpectedCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest("GET", "/api/operations/search?q="+tt.Query, nil)
+			request.Header.Set("Authorization", "Bearer "+tt.Token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			assert.Equal(t, tt.ExpectedCode, responseRecorder.Code)
+			if tt.ExpectedCode != http.StatusOK {
+				return
+			}
+			var operations []dto.TransformedSearchOperation
+			json.Unmarshal(responseRecorder.Body.Bytes(), &operations)
+			descriptions := []string{}
+			for _, operation := range operations {
+				descriptions = append(descriptions, operation.Description)
+			}
+			assert.ElementsMatch(t, tt.ExpectedDescriptions, descriptions)
+			if tt.ExpectedHighlight != "" {
+				assert.Equal(t, tt.ExpectedHighlight, operations[0].Highlights.Description)
+			}
+		})
+	}
+	teardownTest()
+}
@@ -0,0 +1,122 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestPatchIntegration_ValidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the amount of the operation is merge patched",
+			Params:       `{"amount": "1500", "tags": ["salary"]}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"type\":\"income\",\"amount\":\"1500.00\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297Z\",\"account_id\":1,\"transfer_id\":null,\"status\":\"pending\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\",\"description\":\"Work\",\"is_default\":true},\"description\":\"Salario\",\"splits\":[],\"tags\":[\"salary\"]}",
+		},
+		{
+			Name:         "when the description of the operation is JSON patched",
+			Params:       `[{"op": "test", "path": "/amount", "value": "1500.00"}, {"op": "replace", "path": "/description", "value": "Bonus"}, {"op": "remove", "path": "/tags/0"}]`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"type\":\"income\",\"amount\":\"1500.00\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297Z\",\"account_id\":1,\"transfer_id\":null,\"status\":\"pending\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\",\"description\":\"Work\",\"is_default\":true},\"description\":\"Bonus\",\"splits\":[],\"tags\":[]}",
+		},
+		{
+			Name:         "when a category is created",
+			Params:       `{"name": "Travel", "color": "#6495ed", "description": "Travel"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Category successfully created.\"}",
+		},
+		{
+			Name:         "when the color of the category is merge patched",
+			Params:       `{"color": "#000000"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":3,\"name\":\"Travel\",\"color\":\"#000000\",\"description\":\"Travel\",\"is_default\":false}",
+		},
+	}
+	requests := map[string][]string{
+		"when the amount of the operation is merge patched":     {"PATCH", "/api/operations/1", "application/merge-patch+json"},
+		"when the description of the operation is JSON patched": {"PATCH", "/api/operations/1", "application/json-patch+json"},
+		"when a category is created":                            {"POST", "/api/categories", "application/json"},
+		"when the color of the category is merge patched":       {"PATCH", "/api/categories/3", "application/merge-patch+json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest(requests[tt.Name][0], requests[tt.Name][1], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", requests[tt.Name][2])
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
+
+func TestPatchIntegration_InvalidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the patch sets an invalid date",
+			Params:       `{"date": "yesterday"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the patch sets an invalid category",
+			Params:       `{"category_id": "2"}`,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid category.\"}",
+		},
+		{
+			Name:         "when the patch test fails",
+			Params:       `[{"op": "test", "path": "/type", "value": "expense"}, {"op": "replace", "path": "/type", "value": "income"}]`,
+			ExpectedCode: http.StatusConflict,
+			ExpectedBody: "{\"error\":\"The patch test failed.\"}",
+		},
+		{
+			Name:         "when the operation belongs to another user",
+			Params:       `{"amount": "1500"}`,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the category is a default category",
+			Params:       `{"name": "Job"}`,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the content type is not supported",
+			Params:       `name=Job`,
+			ExpectedCode: http.StatusUnsupportedMediaType,
+			ExpectedBody: "{\"error\":\"Unsupported content type.\"}",
+		},
+	}
+	requests := map[string][]string{
+		"when the patch sets an invalid date":        {"/api/operations/1", "application/merge-patch+json", token},
+		"when the patch sets an invalid category":    {"/api/operations/1", "application/merge-patch+json", token},
+		"when the patch test fails":                  {"/api/operations/1", "application/json-patch+json", token},
+		"when the operation belongs to another user": {"/api/operations/1", "application/merge-patch+json", anotherToken},
+		"when the category is a default category":    {"/api/categories/1", "application/merge-patch+json", token},
+		"when the content type is not supported":     {"/api/categories/2", "application/x-www-form-urlencoded", anotherToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest("PATCH", requests[tt.Name][0], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", requests[tt.Name][1])
+			request.Header.Set("Authorization", "Bearer "+requests[tt.Name][2])
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
@@ -0,0 +1,105 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestPayeesIntegration(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when a payee is created",
+			Params:       `{"name": "Carrefour", "aliases": ["Carrefour Express"]}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Payee successfully created.\"}",
+		},
+		{
+			Name:         "when the alias belongs to another payee",
+			Params:       `{"name": "Supermercado", "aliases": ["carrefour express"]}`,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The payee already exists.\"}",
+		},
+		{
+			Name:         "when an operation matching an alias is created",
+			Params:       `{"type": "expense", "amount": "3500", "date": "2023-10-20T15:04:05Z", "description": "CARREFOUR EXPRESS 123", "category_id": "1"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "",
+		},
+		{
+			Name:         "when the payee totals are listed",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"payee_id\":1,\"name\":\"Carrefour\",\"currency\":\"ARS\",\"total\":\"3500.00\",\"count\":1}]",
+		},
+		{
+			Name:         "when another payee is created",
+			Params:       `{"name": "Dia"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Payee successfully created.\"}",
+		},
+		{
+			Name:         "when the payees are merged",
+			Params:       `{"payee_id": 2}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Payees successfully merged.\"}",
+		},
+		{
+			Name:         "when the merged payee is shown",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"name\":\"Carrefour\",\"aliases\":[\"Carrefour Express\",\"Dia\"]}",
+		},
+		{
+			Name:         "when the payee belongs to another user",
+			Params:       "",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the payee is deleted",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Payee successfully deleted.\"}",
+		},
+		{
+			Name:         "when the operations are filtered by the deleted payee",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[],\"next_cursor\":\"\"}",
+		},
+	}
+	requests := map[string][]string{
+		"when a payee is created":                               {"POST", "/api/payees"},
+		"when the alias belongs to another payee":               {"POST", "/api/payees"},
+		"when an operation matching an alias is created":        {"POST", "/api/operations"},
+		"when the payee totals are listed":                      {"GET", "/api/payees/totals"},
+		"when another payee is created":                         {"POST", "/api/payees"},
+		"when the payees are merged":                            {"POST", "/api/payees/1/merge"},
+		"when the merged payee is shown":                        {"GET", "/api/payees/1"},
+		"when the payee belongs to another user":                {"GET", "/api/payees/1"},
+		"when the payee is deleted":                             {"DELETE", "/api/payees/1"},
+		"when the operations are filtered by the deleted payee": {"GET", "/api/operations?payee_id=1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest(requests[tt.Name][0], requests[tt.Name][1], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			if tt.Name == "when the payee belongs to another user" {
+				request.Header.Set("Authorization", "Bearer "+anotherToken)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
@@ -0,0 +1,103 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestReconciliationsIntegration(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when a reconciliation is created",
+			Params:       `{"account_id": 1, "statement_date": "2023-10-31", "closing_balance": "1200.50"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Reconciliation successfully created.\"}",
+		},
+		{
+			Name:         "when the account already has an open reconciliation",
+			Params:       `{"account_id": 1, "statement_date": "2023-11-30", "closing_balance": "1200.50"}`,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The account already has an open reconciliation.\"}",
+		},
+		{
+			Name:         "when the reconciliation is finalized before it balances",
+			Params:       "",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The reconciliation does not balance.\"}",
+		},
+		{
+			Name:         "when an operation is ticked off",
+			Params:       `{"operation_ids": [1]}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"account_id\":1,\"statement_date\":\"2023-10-31\",\"closing_balance\":\"1200.50\",\"status\":\"open\",\"finalized_at\":null,\"cleared_balance\":\"1200.50\",\"difference\":\"0.00\",\"operations\":[" +
+				"{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"date\":\"2023-10-23T21:33:03.73297Z\",\"description\":\"Salario\",\"status\":\"cleared\",\"ticked\":true}]}",
+		},
+		{
+			Name:         "when the operation is unticked",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"account_id\":1,\"statement_date\":\"2023-10-31\",\"closing_balance\":\"1200.50\",\"status\":\"open\",\"finalized_at\":null,\"cleared_balance\":\"0.00\",\"difference\":\"1200.50\",\"operations\":[" +
+				"{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"date\":\"2023-10-23T21:33:03.73297Z\",\"description\":\"Salario\",\"status\":\"pending\",\"ticked\":false}]}",
+		},
+		{
+			Name:         "when the operation is ticked off again",
+			Params:       `{"operation_ids": [1]}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "",
+		},
+		{
+			Name:         "when the reconciliation is finalized",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Reconciliation successfully finalized.\"}",
+		},
+		{
+			Name:         "when a reconciled operation is updated",
+			Params:       `{"type": "income", "amount": 1500, "date": "2023-10-23T21:33:03Z", "description": "Salario", "category_id": "1"}`,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Reconciled operations cannot be changed.\"}",
+		},
+		{
+			Name:         "when a reconciled operation is deleted",
+			Params:       "",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Reconciled operations cannot be changed.\"}",
+		},
+		{
+			Name:         "when the operations are filtered by status",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297Z\",\"account_id\":1,\"transfer_id\":null,\"status\":\"reconciled\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"tags\":[]}],\"next_cursor\":\"\"}",
+		},
+	}
+	requests := map[string][]string{
+		"when a reconciliation is created":                        {"POST", "/api/reconciliations"},
+		"when the account already has an open reconciliation":     {"POST", "/api/reconciliations"},
+		"when the reconciliation is finalized before it balances": {"POST", "/api/reconciliations/1/finalize"},
+		"when an operation is ticked off":                         {"POST", "/api/reconciliations/1/operations"},
+		"when the operation is unticked":                          {"DELETE", "/api/reconciliations/1/operations/1"},
+		"when the operation is ticked off again":                  {"POST", "/api/reconciliations/1/operations"},
+		"when the reconciliation is finalized":                    {"POST", "/api/reconciliations/1/finalize"},
+		"when a reconciled operation is updated":                  {"PUT", "/api/operations/1"},
+		"when a reconciled operation is deleted":                  {"DELETE", "/api/operations/1"},
+		"when the operations are filtered by status":              {"GET", "/api/operations?status=reconciled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest(requests[tt.Name][0], requests[tt.Name][1], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
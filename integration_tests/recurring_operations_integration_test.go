@@ -0,0 +1,69 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"GoGin-API-CuentasClaras/repository"
+	"GoGin-API-CuentasClaras/services"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecurringOperationsIntegration_Create_ValidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the recurring operation is created successfully",
+			Params:       `{"type": "expense", "amount": 500, "description": "Rent", "category_id": "1", "frequency": "monthly", "start_date": "2023-10-01T10:00:00Z", "count": 3}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Recurring operation successfully created.\"}",
+		},
+		{
+			Name:         "when the recurring operation is listed",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"type\":\"expense\",\"amount\":\"500.00\",\"currency\":\"ARS\",\"description\":\"Rent\",\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"account_id\":1,\"frequency\":\"monthly\",\"day_of_month\":0,\"start_date\":\"2023-10-01T10:00:00Z\",\"end_date\":null,\"count\":3,\"occurrences_created\":0,\"next_run_at\":\"2023-10-01T10:00:00Z\"}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			method := "POST"
+			if tt.Params == "" {
+				method = "GET"
+			}
+			request, _ := http.NewRequest(method, "/api/recurring_operations", strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
+
+func TestRecurringOperationsIntegration_Scheduler(t *testing.T) {
+	router := setupTest()
+	request, _ := http.NewRequest("POST", "/api/recurring_operations", strings.NewReader(`{"type": "expense", "amount": 500, "description": "Rent", "category_id": "1", "frequency": "monthly", "start_date": "2023-10-01T10:00:00Z", "count": 3}`))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	scheduler := services.RecurringOperationSchedulerInit(repository.RecurringOperationRepositoryInit(db))
+	now := time.Now()
+
+	assert.Equal(t, 3, scheduler.RunDue(now))
+	assert.Equal(t, 0, scheduler.RunDue(now))
+
+	var count int64
+	db.Table("operations").Where("recurring_operation_id IS NOT NULL").Count(&count)
+	assert.Equal(t, int64(3), count)
+	teardownTest()
+}
@@ -0,0 +1,61 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestReportsIntegration(t *testing.T) {
+	router := setupTest()
+	request, _ := http.NewRequest("POST", "/api/operations", strings.NewReader(`{"type": "expense", "amount": "8500", "date": "2023-10-20T15:04:05Z", "description": "Pago al plomero", "category_id": "1"}`))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the expenses are broken down by category",
+			Params:       "?from=2023-10-01T00:00:00Z&to=2023-10-31T23:59:59Z",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"type\":\"expense\",\"currencies\":[{\"currency\":\"ARS\",\"total\":\"8500.00\",\"categories\":[" +
+				"{\"category_id\":1,\"name\":\"Work\",\"color\":\"#fdg123\",\"is_default\":true,\"total\":\"8500.00\",\"count\":1,\"percentage\":100}]," +
+				"\"uncategorized\":{\"total\":\"0.00\",\"count\":0,\"percentage\":0}}]}",
+		},
+		{
+			Name:         "when the incomes are broken down by category",
+			Params:       "?type=income",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"type\":\"income\",\"currencies\":[{\"currency\":\"ARS\",\"total\":\"1200.50\",\"categories\":[" +
+				"{\"category_id\":1,\"name\":\"Work\",\"color\":\"#fdg123\",\"is_default\":true,\"total\":\"1200.50\",\"count\":1,\"percentage\":100}]," +
+				"\"uncategorized\":{\"total\":\"0.00\",\"count\":0,\"percentage\":0}}]}",
+		},
+		{
+			Name:         "when the period has no operations",
+			Params:       "?from=2023-11-01T00:00:00Z&to=2023-11-30T23:59:59Z",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"type\":\"expense\",\"currencies\":[]}",
+		},
+		{
+			Name:         "when the type is invalid",
+			Params:       "?type=transfer",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest("GET", "/api/reports/categories"+tt.Params, nil)
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
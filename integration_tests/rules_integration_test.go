@@ -0,0 +1,73 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestRulesIntegration(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when a rule is created",
+			Params:       `{"name": "Salary", "description_contains": "salario", "type": "income", "add_tags": ["Salary"], "set_description": "Salary"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Rule successfully created.\"}",
+		},
+		{
+			Name:         "when a rule sets an invalid category",
+			Params:       `{"name": "Uber rides", "description_regex": "(?i)^uber", "set_category_id": "99"}`,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid category.\"}",
+		},
+		{
+			Name:         "when the rules are listed",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"name\":\"Salary\",\"priority\":0,\"description_contains\":\"salario\",\"description_regex\":\"\",\"type\":\"income\",\"min_amount\":null,\"max_amount\":null,\"set_category_id\":null,\"add_tags\":[\"salary\"],\"set_description\":\"Salary\"}]",
+		},
+		{
+			Name:         "when the rules are previewed on the operations",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"preview\":true,\"changed\":1,\"operations\":[{\"id\":1,\"rules\":[1],\"changes\":[{\"field\":\"description\",\"before\":\"Salario\",\"after\":\"Salary\"},{\"field\":\"tags\",\"before\":[],\"after\":[\"salary\"]}]}]}",
+		},
+		{
+			Name:         "when the rules are applied to the operations",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"preview\":false,\"changed\":1,\"operations\":[{\"id\":1,\"rules\":[1],\"changes\":[{\"field\":\"description\",\"before\":\"Salario\",\"after\":\"Salary\"},{\"field\":\"tags\",\"before\":[],\"after\":[\"salary\"]}]}]}",
+		},
+		{
+			Name:         "when the rules are applied again",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"preview\":false,\"changed\":0,\"operations\":[]}",
+		},
+	}
+	requests := map[string][]string{
+		"when a rule is created":                         {"POST", "/api/rules"},
+		"when a rule sets an invalid category":           {"POST", "/api/rules"},
+		"when the rules are listed":                      {"GET", "/api/rules"},
+		"when the rules are previewed on the operations": {"POST", "/api/rules/apply?preview=true"},
+		"when the rules are applied to the operations":   {"POST", "/api/rules/apply"},
+		"when the rules are applied again":               {"POST", "/api/rules/apply"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest(requests[tt.Name][0], requests[tt.Name][1], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
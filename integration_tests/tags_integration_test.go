@@ -0,0 +1,71 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestTagsIntegration_ValidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when an operation is created with tags",
+			Params:       `{"type": "expense", "amount": 300, "date": "2023-10-24T10:00:00Z", "description": "Hotel", "category_id": "1", "tags": ["Vacation-2026", "reimbursable"]}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
+		},
+		{
+			Name:         "when another operation is created with one of the tags",
+			Params:       `{"type": "expense", "amount": 50, "date": "2023-10-24T12:00:00Z", "description": "Dinner", "category_id": "1", "tags": ["vacation-2026"]}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
+		},
+		{
+			Name:         "when the tags are listed with their usage",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":2,\"name\":\"reimbursable\",\"operations_count\":1},{\"id\":1,\"name\":\"vacation-2026\",\"operations_count\":2}]",
+		},
+		{
+			Name:         "when the operations are filtered by any of the tags",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[{\"id\":3,\"type\":\"expense\",\"amount\":\"50.00\",\"currency\":\"ARS\",\"date\":\"2023-10-24T12:00:00Z\",\"account_id\":1,\"transfer_id\":null,\"status\":\"pending\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"tags\":[\"vacation-2026\"]}," +
+				"{\"id\":2,\"type\":\"expense\",\"amount\":\"300.00\",\"currency\":\"ARS\",\"date\":\"2023-10-24T10:00:00Z\",\"account_id\":1,\"transfer_id\":null,\"status\":\"pending\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"tags\":[\"reimbursable\",\"vacation-2026\"]}],\"next_cursor\":\"\"}",
+		},
+		{
+			Name:         "when the operations are filtered by all of the tags",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[{\"id\":2,\"type\":\"expense\",\"amount\":\"300.00\",\"currency\":\"ARS\",\"date\":\"2023-10-24T10:00:00Z\",\"account_id\":1,\"transfer_id\":null,\"status\":\"pending\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"tags\":[\"reimbursable\",\"vacation-2026\"]}],\"next_cursor\":\"\"}",
+		},
+	}
+	uris := map[string]string{
+		"when an operation is created with tags":                 "/api/operations",
+		"when another operation is created with one of the tags": "/api/operations",
+		"when the tags are listed with their usage":              "/api/tags",
+		"when the operations are filtered by any of the tags":    "/api/operations?tags=vacation-2026,reimbursable",
+		"when the operations are filtered by all of the tags":    "/api/operations?tags=vacation-2026&tags=reimbursable&tag_mode=all",
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			method := "POST"
+			if tt.Params == "" {
+				method = "GET"
+			}
+			request, _ := http.NewRequest(method, uris[tt.Name], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
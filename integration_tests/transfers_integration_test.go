@@ -0,0 +1,89 @@
+package integration_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+)
+
+func TestTransfersIntegration_Create_ValidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the destination account is created",
+			Params:       `{"name": "Bank", "type": "bank"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Account successfully created.\"}",
+		},
+		{
+			Name:         "when the transfer is created successfully",
+			Params:       `{"from_account_id": 1, "to_account_id": 2, "amount": 200, "date": "2023-10-23T22:00:00Z", "description": "Savings"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Transfer successfully created.\"}",
+		},
+		{
+			Name:         "when the income operations leave out the transfer",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-23T21:33:03.73297Z\",\"account_id\":1,\"transfer_id\":null,\"status\":\"pending\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"tags\":[]}],\"next_cursor\":\"\"}",
+		},
+		{
+			Name:         "when the balance moves the amount between accounts",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"total_balance\":\"1200.50\",\"base_currency\":\"ARS\",\"date\":\"2023-10-24\",\"balances\":[{\"currency\":\"ARS\",\"balance\":\"1200.50\"}]," +
+				"\"accounts\":[{\"id\":1,\"name\":\"Default\",\"currency\":\"ARS\",\"balance\":\"1000.50\"},{\"id\":2,\"name\":\"Bank\",\"currency\":\"ARS\",\"balance\":\"200.00\"}]}",
+		},
+	}
+	uris := map[string]string{
+		"when the destination account is created":            "/api/accounts",
+		"when the transfer is created successfully":          "/api/transfers",
+		"when the income operations leave out the transfer":  "/api/operations?type=income",
+		"when the balance moves the amount between accounts": "/api/users/balance?date=2023-10-24",
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			method := "POST"
+			if tt.Params == "" {
+				method = "GET"
+			}
+			request, _ := http.NewRequest(method, uris[tt.Name], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
+
+func TestTransfersIntegration_Create_InvalidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the destination account belongs to another user",
+			Params:       `{"from_account_id": 1, "to_account_id": 99, "amount": 200, "date": "2023-10-23T22:00:00Z"}`,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid account.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest("POST", "/api/transfers", strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
@@ -0,0 +1,176 @@
+package integration_tests
+
+import (
+	"GoGin-API-CuentasClaras/repository"
+	"GoGin-API-CuentasClaras/services"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashIntegration_ValidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when a category is created",
+			Params:       `{"name": "Travel", "color": "#6495ed", "description": "Travel"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Category successfully created.\"}",
+		},
+		{
+			Name:         "when an operation is created in the category",
+			Params:       `{"type": "expense", "amount": 300, "date": "2023-10-24T10:00:00Z", "description": "Hotel", "category_id": "3"}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
+		},
+		{
+			Name:         "when the operation is deleted",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully deleted.\"}",
+		},
+		{
+			Name:         "when the category is deleted",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Category successfully deleted.\"}",
+		},
+		{
+			Name:         "when the trash is listed",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[{\"id\":2,\"type\":\"expense\",\"amount\":\"300.00\",\"currency\":\"ARS\",\"date\":\"2023-10-24T10:00:00Z\",\"description\":\"Hotel\",\"category_id\":3,\"account_id\":1,\"deleted_at\":\"2023-10-25T10:00:00Z\"}]," +
+				"\"categories\":[{\"id\":3,\"name\":\"Travel\",\"color\":\"#6495ed\",\"description\":\"Travel\",\"deleted_at\":\"2023-10-25T10:00:00Z\"}]}",
+		},
+		{
+			Name:         "when the operation is restored before its category",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The category of the operation is in the trash.\"}",
+		},
+		{
+			Name:         "when the category is purged while used by the operation",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The category is still used by operations.\"}",
+		},
+		{
+			Name:         "when the category is restored",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Category successfully restored.\"}",
+		},
+		{
+			Name:         "when the operation is restored",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully restored.\"}",
+		},
+		{
+			Name:         "when the trash is listed after restoring",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[],\"categories\":[]}",
+		},
+		{
+			Name:         "when another operation is deleted",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully deleted.\"}",
+		},
+		{
+			Name:         "when the operation is purged",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully purged.\"}",
+		},
+		{
+			Name:         "when the purged operation is restored",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	requests := map[string][]string{
+		"when a category is created":                              {"POST", "/api/categories"},
+		"when an operation is created in the category":            {"POST", "/api/operations"},
+		"when the operation is deleted":                           {"DELETE", "/api/operations/2"},
+		"when the category is deleted":                            {"DELETE", "/api/categories/3"},
+		"when the trash is listed":                                {"GET", "/api/trash"},
+		"when the operation is restored before its category":      {"POST", "/api/trash/operations/2/restore"},
+		"when the category is purged while used by the operation": {"DELETE", "/api/trash/categories/3"},
+		"when the category is restored":                           {"POST", "/api/trash/categories/3/restore"},
+		"when the operation is restored":                          {"POST", "/api/trash/operations/2/restore"},
+		"when the trash is listed after restoring":                {"GET", "/api/trash"},
+		"when another operation is deleted":                       {"DELETE", "/api/operations/1"},
+		"when the operation is purged":                            {"DELETE", "/api/trash/operations/1"},
+		"when the purged operation is restored":                   {"POST", "/api/trash/operations/1/restore"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			if tt.Name == "when the trash is listed" {
+				db.Exec("UPDATE operations SET deleted_at = '2023-10-25T10:00:00Z' WHERE id = 2")
+				db.Exec("UPDATE categories SET deleted_at = '2023-10-25T10:00:00Z' WHERE id = 3")
+			}
+			request, _ := http.NewRequest(requests[tt.Name][0], requests[tt.Name][1], strings.NewReader(tt.Params))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
+
+func TestTrashIntegration_InvalidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the operation is not in the trash",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the category belongs to another user",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	requests := map[string][]string{
+		"when the operation is not in the trash":    {"POST", "/api/trash/operations/1/restore"},
+		"when the category belongs to another user": {"DELETE", "/api/trash/categories/2"},
+	}
+	db.Exec("UPDATE categories SET deleted_at = NOW() WHERE id = 2")
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest(requests[tt.Name][0], requests[tt.Name][1], nil)
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
+
+func TestTrashPurgerIntegration(t *testing.T) {
+	router := setupTest()
+	request, _ := http.NewRequest("DELETE", "/api/operations/1", nil)
+	request.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(httptest.NewRecorder(), request)
+	db.Exec("UPDATE categories SET deleted_at = NOW() WHERE id = 2")
+
+	trashPurger := services.TrashPurgerInit(repository.TrashRepositoryInit(db))
+	operations, categories := trashPurger.Purge(time.Now())
+	assert.Equal(t, int64(0), operations)
+	assert.Equal(t, int64(0), categories)
+
+	operations, categories = trashPurger.Purge(time.Now().AddDate(0, 0, services.DEFAULT_TRASH_RETENTION_DAYS+1))
+	assert.Equal(t, int64(1), operations)
+	assert.Equal(t, int64(1), categories)
+
+	var remaining int64
+	db.Table("operations").Where("id = 1").Count(&remaining)
+	assert.Equal(t, int64(0), remaining)
+	teardownTest()
+}
@@ -217,14 +217,14 @@ func TestUsersIntegration_Balance_ValidRequest(t *testing.T) {
 	var tests = []testhelpers.TestStructure{
 		{
 			Name:         "when the request is successful",
-			Params:       "",
+			Params:       "?date=2023-10-24",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "{\"total_balance\":\"1200.50\"}",
+			ExpectedBody: "{\"total_balance\":\"1200.50\",\"base_currency\":\"ARS\",\"date\":\"2023-10-24\",\"balances\":[{\"currency\":\"ARS\",\"balance\":\"1200.50\"}],\"accounts\":[{\"id\":1,\"name\":\"Default\",\"currency\":\"ARS\",\"balance\":\"1200.50\"}]}",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
-			request, _ := http.NewRequest("GET", "/api/users/balance", strings.NewReader(tt.Params))
+			request, _ := http.NewRequest("GET", "/api/users/balance"+tt.Params, nil)
 			request.Header.Set("Authorization", "Bearer "+token)
 
 			responseRecorder := httptest.NewRecorder()
@@ -258,3 +258,43 @@ func TestUsersIntegration_Balance_InvalidRequest(t *testing.T) {
 	}
 	teardownTest()
 }
+
+func TestUsersIntegration_BalanceHistory_ValidRequest(t *testing.T) {
+	router := setupTest()
+	var tests = []testhelpers.TestStructure{
+		{
+			Name:         "when the request is successful",
+			Params:       "?from=2023-09-15T00:00:00Z&to=2023-10-31T00:00:00Z&interval=month",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"interval\":\"month\",\"from\":\"2023-09-15\",\"to\":\"2023-10-31\",\"series\":[{\"currency\":\"ARS\",\"points\":[" +
+				"{\"date\":\"2023-09-01\",\"income\":\"0.00\",\"expense\":\"0.00\",\"balance\":\"0.00\"}," +
+				"{\"date\":\"2023-10-01\",\"income\":\"1200.50\",\"expense\":\"0.00\",\"balance\":\"1200.50\"}]}]}",
+		},
+		{
+			Name:         "when the operations are before the period",
+			Params:       "?from=2023-11-01T00:00:00Z&to=2023-11-02T00:00:00Z&interval=day",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"interval\":\"day\",\"from\":\"2023-11-01\",\"to\":\"2023-11-02\",\"series\":[{\"currency\":\"ARS\",\"points\":[" +
+				"{\"date\":\"2023-11-01\",\"income\":\"0.00\",\"expense\":\"0.00\",\"balance\":\"1200.50\"}," +
+				"{\"date\":\"2023-11-02\",\"income\":\"0.00\",\"expense\":\"0.00\",\"balance\":\"1200.50\"}]}]}",
+		},
+		{
+			Name:         "when the interval is invalid",
+			Params:       "?interval=year",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			request, _ := http.NewRequest("GET", "/api/users/balance/history"+tt.Params, nil)
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, request)
+
+			testhelpers.AssertExpectedCodeAndBodyResponse(t, tt, responseRecorder)
+		})
+	}
+	teardownTest()
+}
@@ -17,6 +17,9 @@ func main() {
 	port := os.Getenv("PORT")
 
 	init := config.Init()
+	init.RecurringScheduler.Start()
+	init.AttachmentCleaner.Start()
+	init.TrashPurger.Start()
 	app := api.Init(init)
 
 	app.Run(":" + port)
@@ -0,0 +1,163 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SCALE is the number of minor units in a unit of currency.
+const SCALE int64 = 100
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// Amount is an amount of money in minor units, so that adding up amounts is
+// exact. It is stored as NUMERIC(15,2) and written to JSON as a string with two
+// decimals.
+type Amount int64
+
+// Parse reads a decimal amount such as "-1234.5" or "0.05". More than two
+// decimals are rejected rather than rounded.
+func Parse(value string) (Amount, error) {
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	if negative || strings.HasPrefix(value, "+") {
+		value = value[1:]
+	}
+	units, decimals, _ := strings.Cut(value, ".")
+	if units == "" && decimals == "" {
+		return 0, ErrInvalidAmount
+	}
+	decimals = strings.TrimRight(decimals, "0")
+	if len(decimals) > 2 || !digits(units) || !digits(decimals) {
+		return 0, ErrInvalidAmount
+	}
+	for len(decimals) < 2 {
+		decimals += "0"
+	}
+	if units == "" {
+		units = "0"
+	}
+	parsedUnits, err := strconv.ParseInt(units, 10, 64)
+	if err != nil || parsedUnits > math.MaxInt64/SCALE-1 {
+		return 0, ErrInvalidAmount
+	}
+	parsedDecimals, _ := strconv.ParseInt(decimals, 10, 64)
+	amount := Amount(parsedUnits*SCALE + parsedDecimals)
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// FromFloat rounds a float to the closest amount, for values that only exist
+// as floats such as the result of an exchange rate.
+func FromFloat(value float64) Amount {
+	return Amount(math.Round(value * float64(SCALE)))
+}
+
+func (a Amount) Float64() float64 {
+	return float64(a) / float64(SCALE)
+}
+
+func (a Amount) Abs() Amount {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Convert applies an exchange rate, rounding to the closest minor unit.
+func (a Amount) Convert(rate float64) Amount {
+	return Amount(math.Round(float64(a) * rate))
+}
+
+// String writes the amount with two decimals and no thousands separators.
+func (a Amount) String() string {
+	sign := ""
+	value := int64(a)
+	if value < 0 {
+		sign, value = "-", -value
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, value/SCALE, value%SCALE)
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(a.String())), nil
+}
+
+// UnmarshalJSON accepts the amount either as a string or as a number, reading
+// the number from its text so it never goes through a float.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	amount, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+// UnmarshalText reads the amount from its decimal text, as sent in a query or
+// form parameter.
+func (a *Amount) UnmarshalText(text []byte) error {
+	amount, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+func (a *Amount) UnmarshalParam(param string) error {
+	return a.UnmarshalText([]byte(param))
+}
+
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+func (a *Amount) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*a = 0
+	case string:
+		return a.scanText(value)
+	case []byte:
+		return a.scanText(string(value))
+	case int64:
+		*a = Amount(value * SCALE)
+	case float64:
+		*a = FromFloat(value)
+	default:
+		return fmt.Errorf("cannot scan %T into an amount", src)
+	}
+	return nil
+}
+
+func (a *Amount) scanText(text string) error {
+	amount, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+func digits(value string) bool {
+	for _, digit := range value {
+		if digit < '0' || digit > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	var tests = []struct {
+		value    string
+		expected Amount
+	}{
+		{"1200.5", 120050},
+		{"-1234.56", -123456},
+		{"+3", 300},
+		{"0.05", 5},
+		{".5", 50},
+		{"10.", 1000},
+		{"7.100", 710},
+		{"92233720368547.75", 9223372036854775},
+	}
+	for _, tt := range tests {
+		amount, err := Parse(tt.value)
+		assert.NoError(t, err, tt.value)
+		assert.Equal(t, tt.expected, amount, tt.value)
+	}
+	for _, value := range []string{"", "-", ".", "1.005", "1,5", "1e3", "--5", "abc"} {
+		_, err := Parse(value)
+		assert.ErrorIs(t, err, ErrInvalidAmount, value)
+	}
+}
+
+func TestAmount_String(t *testing.T) {
+	assert.Equal(t, "1200.50", Amount(120050).String())
+	assert.Equal(t, "-0.05", Amount(-5).String())
+	assert.Equal(t, "0.00", Amount(0).String())
+}
+
+func TestAmount_JSON(t *testing.T) {
+	var request struct {
+		Amount   Amount `json:"amount"`
+		ToAmount Amount `json:"to_amount"`
+	}
+	err := json.Unmarshal([]byte(`{"amount": 0.1, "to_amount": "0.20"}`), &request)
+	assert.NoError(t, err)
+	assert.Equal(t, Amount(30), request.Amount+request.ToAmount)
+
+	encoded, _ := json.Marshal(request)
+	assert.Equal(t, `{"amount":"0.10","to_amount":"0.20"}`, string(encoded))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": "ten"}`), &request))
+}
+
+func TestAmount_UnmarshalParam(t *testing.T) {
+	var amount Amount
+	assert.NoError(t, amount.UnmarshalParam("10.50"))
+	assert.Equal(t, Amount(1050), amount)
+
+	assert.NoError(t, amount.UnmarshalText([]byte("-3")))
+	assert.Equal(t, Amount(-300), amount)
+
+	assert.Error(t, amount.UnmarshalParam("ten"))
+}
+
+func TestAmount_Scan(t *testing.T) {
+	var amount Amount
+	assert.NoError(t, amount.Scan([]byte("1200.50")))
+	assert.Equal(t, Amount(120050), amount)
+	assert.NoError(t, amount.Scan(1200.499999))
+	assert.Equal(t, Amount(120050), amount)
+	assert.NoError(t, amount.Scan(int64(-3)))
+	assert.Equal(t, Amount(-300), amount)
+
+	value, _ := Amount(-5).Value()
+	assert.Equal(t, "-0.05", value)
+}
+
+func TestAmount_Convert(t *testing.T) {
+	assert.Equal(t, Amount(35090), Amount(3509).Convert(10))
+	assert.Equal(t, Amount(33), Amount(100).Convert(1.0/3))
+}
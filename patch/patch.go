@@ -0,0 +1,352 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MergePatchContentType is the content type of a JSON Merge Patch (RFC 7386),
+// a partial document whose members replace the stored ones and whose nulls
+// remove them.
+const MergePatchContentType = "application/merge-patch+json"
+
+// JSONPatchContentType is the content type of a JSON Patch (RFC 6902), a list
+// of add, remove, replace, move, copy and test operations.
+const JSONPatchContentType = "application/json-patch+json"
+
+var ErrInvalidPatch = errors.New("invalid patch")
+var ErrTestFailed = errors.New("patch test failed")
+
+type operation struct {
+	Op    string           `json:"op"`
+	Path  *string          `json:"path"`
+	From  *string          `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+// Apply patches the JSON form of the document and decodes the result into the
+// target. It returns the top level members the patch touches, in the order
+// they appear in it.
+func Apply(contentType string, document interface{}, patch []byte, target interface{}) ([]string, error) {
+	documentJSON, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var patched []byte
+	var fields []string
+	if contentType == JSONPatchContentType {
+		patched, fields, err = JSONPatch(documentJSON, patch)
+	} else {
+		patched, fields, err = MergePatch(documentJSON, patch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patched, target); err != nil {
+		return nil, ErrInvalidPatch
+	}
+	return fields, nil
+}
+
+// MergePatch applies a merge patch to a JSON object. The patch must be an
+// object as well.
+func MergePatch(document []byte, patch []byte) ([]byte, []string, error) {
+	var target map[string]interface{}
+	if err := json.Unmarshal(document, &target); err != nil {
+		return nil, nil, err
+	}
+	var patchObject map[string]interface{}
+	if err := json.Unmarshal(patch, &patchObject); err != nil || patchObject == nil {
+		return nil, nil, ErrInvalidPatch
+	}
+	patched, _ := json.Marshal(mergeValue(target, patchObject))
+	return patched, objectKeys(patch), nil
+}
+
+func mergeValue(target interface{}, patch interface{}) interface{} {
+	patchObject, isObject := patch.(map[string]interface{})
+	if !isObject {
+		return patch
+	}
+	targetObject, isObject := target.(map[string]interface{})
+	if !isObject {
+		targetObject = map[string]interface{}{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergeValue(targetObject[key], value)
+		}
+	}
+	return targetObject
+}
+
+// JSONPatch applies the operations of a JSON Patch in order. Nothing is
+// applied when one of them fails. Only a test may point at the whole
+// document, since the touched members could not be told otherwise.
+func JSONPatch(document []byte, patch []byte) ([]byte, []string, error) {
+	var target interface{}
+	if err := json.Unmarshal(document, &target); err != nil {
+		return nil, nil, err
+	}
+	var operations []operation
+	if err := json.Unmarshal(patch, &operations); err != nil || len(operations) == 0 {
+		return nil, nil, ErrInvalidPatch
+	}
+
+	fields := []string{}
+	for _, op := range operations {
+		if op.Path == nil {
+			return nil, nil, ErrInvalidPatch
+		}
+		path, err := parsePointer(*op.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(path) == 0 && op.Op != "test" {
+			return nil, nil, ErrInvalidPatch
+		}
+		var value interface{}
+		if op.Value != nil {
+			json.Unmarshal(*op.Value, &value)
+		}
+
+		switch op.Op {
+		case "add":
+			if op.Value == nil {
+				return nil, nil, ErrInvalidPatch
+			}
+			target, err = addValue(target, path, value)
+		case "remove":
+			target, _, err = removeValue(target, path)
+		case "replace":
+			if op.Value == nil {
+				return nil, nil, ErrInvalidPatch
+			}
+			if target, _, err = removeValue(target, path); err == nil {
+				target, err = addValue(target, path, value)
+			}
+		case "move", "copy":
+			if op.From == nil {
+				return nil, nil, ErrInvalidPatch
+			}
+			from, fromError := parsePointer(*op.From)
+			if fromError != nil {
+				return nil, nil, fromError
+			}
+			if op.Op == "move" && (len(from) == 0 || isPrefix(from, path) && len(from) < len(path)) {
+				return nil, nil, ErrInvalidPatch
+			}
+			var moved interface{}
+			if op.Op == "move" {
+				target, moved, err = removeValue(target, from)
+				fields = appendField(fields, from)
+			} else {
+				moved, err = getValue(target, from)
+				moved = copyValue(moved)
+			}
+			if err == nil {
+				target, err = addValue(target, path, moved)
+			}
+		case "test":
+			if op.Value == nil {
+				return nil, nil, ErrInvalidPatch
+			}
+			current, getError := getValue(target, path)
+			if getError != nil || !reflect.DeepEqual(current, value) {
+				return nil, nil, ErrTestFailed
+			}
+			continue
+		default:
+			return nil, nil, ErrInvalidPatch
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		fields = appendField(fields, path)
+	}
+
+	patched, _ := json.Marshal(target)
+	return patched, fields, nil
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, ErrInvalidPatch
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for index, token := range tokens {
+		tokens[index] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func getValue(target interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch node := target.(type) {
+		case map[string]interface{}:
+			value, found := node[token]
+			if !found {
+				return nil, ErrInvalidPatch
+			}
+			target = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			target = node[index]
+		default:
+			return nil, ErrInvalidPatch
+		}
+	}
+	return target, nil
+}
+
+// addValue sets the value at the path and returns the new document. Adding to
+// an array inserts before the index, or appends for "-".
+func addValue(target interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := getValue(target, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[token] = value
+		return target, nil
+	case []interface{}:
+		index := len(node)
+		if token != "-" {
+			if index, err = arrayIndex(token, len(node)); err != nil {
+				return nil, err
+			}
+		}
+		node = append(node[:index], append([]interface{}{value}, node[index:]...)...)
+		return setValue(target, path[:len(path)-1], node)
+	}
+	return nil, ErrInvalidPatch
+}
+
+// removeValue removes the value at the path, returning the new document and
+// the removed value.
+func removeValue(target interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, target, nil
+	}
+	parent, err := getValue(target, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		value, found := node[token]
+		if !found {
+			return nil, nil, ErrInvalidPatch
+		}
+		delete(node, token)
+		return target, value, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := node[index]
+		node = append(node[:index:index], node[index+1:]...)
+		target, err = setValue(target, path[:len(path)-1], node)
+		return target, value, err
+	}
+	return nil, nil, ErrInvalidPatch
+}
+
+// setValue replaces the value at the path, used to store arrays after they
+// grow or shrink.
+func setValue(target interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := getValue(target, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[token] = value
+	case []interface{}:
+		index, _ := arrayIndex(token, len(node)-1)
+		node[index] = value
+	}
+	return target, nil
+}
+
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, ErrInvalidPatch
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max {
+		return 0, ErrInvalidPatch
+	}
+	return index, nil
+}
+
+func copyValue(value interface{}) interface{} {
+	valueJSON, _ := json.Marshal(value)
+	var copied interface{}
+	json.Unmarshal(valueJSON, &copied)
+	return copied
+}
+
+func isPrefix(prefix []string, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for index := range prefix {
+		if prefix[index] != path[index] {
+			return false
+		}
+	}
+	return true
+}
+
+func appendField(fields []string, path []string) []string {
+	if len(path) == 0 {
+		return fields
+	}
+	for _, field := range fields {
+		if field == path[0] {
+			return fields
+		}
+	}
+	return append(fields, path[0])
+}
+
+// objectKeys returns the members of a JSON object in the order they appear.
+func objectKeys(object []byte) []string {
+	decoder := json.NewDecoder(strings.NewReader(string(object)))
+	keys := []string{}
+	decoder.Token()
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		keys = append(keys, key.(string))
+		var value json.RawMessage
+		decoder.Decode(&value)
+	}
+	return keys
+}
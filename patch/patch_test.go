@@ -0,0 +1,87 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const document = `{"name":"Work","color":"#6495ed","tags":["rent","home"],"a/b":{"~c":1}}`
+
+func TestMergePatch(t *testing.T) {
+	patched, fields, err := MergePatch([]byte(document), []byte(`{"color":"#000000","a/b":{"~c":null,"d":2},"name":null}`))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"color":"#000000","tags":["rent","home"],"a/b":{"d":2}}`, string(patched))
+	assert.Equal(t, []string{"color", "a/b", "name"}, fields)
+
+	for _, patch := range []string{`[]`, `"name"`, `null`, `{"name"`} {
+		_, _, err := MergePatch([]byte(document), []byte(patch))
+		assert.ErrorIs(t, err, ErrInvalidPatch, patch)
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	var tests = []struct {
+		patch    string
+		expected string
+		fields   []string
+	}{
+		{`[{"op":"replace","path":"/name","value":"Job"}]`, `{"name":"Job","color":"#6495ed","tags":["rent","home"],"a/b":{"~c":1}}`, []string{"name"}},
+		{`[{"op":"add","path":"/tags/-","value":"bills"},{"op":"add","path":"/tags/0","value":"first"}]`, `{"name":"Work","color":"#6495ed","tags":["first","rent","home","bills"],"a/b":{"~c":1}}`, []string{"tags"}},
+		{`[{"op":"remove","path":"/tags/0"},{"op":"remove","path":"/a~1b/~0c"}]`, `{"name":"Work","color":"#6495ed","tags":["home"],"a/b":{}}`, []string{"tags", "a/b"}},
+		{`[{"op":"move","from":"/name","path":"/description"}]`, `{"description":"Work","color":"#6495ed","tags":["rent","home"],"a/b":{"~c":1}}`, []string{"name", "description"}},
+		{`[{"op":"copy","from":"/tags/1","path":"/tags/0"}]`, `{"name":"Work","color":"#6495ed","tags":["home","rent","home"],"a/b":{"~c":1}}`, []string{"tags"}},
+		{`[{"op":"test","path":"/name","value":"Work"},{"op":"replace","path":"/color","value":"#000000"}]`, `{"name":"Work","color":"#000000","tags":["rent","home"],"a/b":{"~c":1}}`, []string{"color"}},
+	}
+	for _, tt := range tests {
+		patched, fields, err := JSONPatch([]byte(document), []byte(tt.patch))
+		assert.NoError(t, err, tt.patch)
+		assert.JSONEq(t, tt.expected, string(patched), tt.patch)
+		assert.Equal(t, tt.fields, fields, tt.patch)
+	}
+
+	for _, patch := range []string{
+		`[]`,
+		`{"op":"remove","path":"/name"}`,
+		`[{"op":"remove","path":"/description"}]`,
+		`[{"op":"replace","path":"/description","value":"Job"}]`,
+		`[{"op":"add","path":"/tags/3","value":"bills"}]`,
+		`[{"op":"add","path":"/tags/01","value":"bills"}]`,
+		`[{"op":"add","path":"/name"}]`,
+		`[{"op":"add","path":"name","value":"Job"}]`,
+		`[{"op":"move","from":"/a~1b","path":"/a~1b/e"}]`,
+		`[{"op":"rename","path":"/name","value":"Job"}]`,
+		`[{"op":"replace","path":"","value":{"name":""}}]`,
+		`[{"op":"remove","path":""}]`,
+		`[{"op":"move","from":"","path":"/name"}]`,
+	} {
+		_, _, err := JSONPatch([]byte(document), []byte(patch))
+		assert.ErrorIs(t, err, ErrInvalidPatch, patch)
+	}
+
+	_, _, err := JSONPatch([]byte(document), []byte(`[{"op":"test","path":"/name","value":"Job"}]`))
+	assert.ErrorIs(t, err, ErrTestFailed)
+}
+
+func TestApply(t *testing.T) {
+	type category struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	}
+	stored := category{Name: "Work", Color: "#6495ed"}
+
+	var patched category
+	fields, err := Apply(MergePatchContentType, stored, []byte(`{"name":"Job"}`), &patched)
+	assert.NoError(t, err)
+	assert.Equal(t, category{Name: "Job", Color: "#6495ed"}, patched)
+	assert.Equal(t, []string{"name"}, fields)
+
+	patched = category{}
+	fields, err = Apply(JSONPatchContentType, stored, []byte(`[{"op":"replace","path":"/color","value":"#000000"}]`), &patched)
+	assert.NoError(t, err)
+	assert.Equal(t, category{Name: "Work", Color: "#000000"}, patched)
+	assert.Equal(t, []string{"color"}, fields)
+
+	_, err = Apply(MergePatchContentType, stored, []byte(`{"name":1}`), &patched)
+	assert.ErrorIs(t, err, ErrInvalidPatch)
+}
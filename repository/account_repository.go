@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const DEFAULT_ACCOUNT_NAME string = "Default"
+const DEFAULT_ACCOUNT_TYPE string = "cash"
+
+type AccountRepository interface {
+	FindAccountsByUser(user dao.User) ([]dao.Account, error)
+	FindAccountByUserAndId(user dao.User, accountID int) (dao.Account, error)
+	FindDefaultAccount(user dao.User) (dao.Account, error)
+	CountOperations(account dao.Account) (int64, error)
+	Save(account *dao.Account) (dao.Account, error)
+	Update(account *dao.Account) (dao.Account, error)
+	Delete(account *dao.Account) (dao.Account, error)
+}
+
+type AccountRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func (u AccountRepositoryImpl) FindAccountsByUser(user dao.User) ([]dao.Account, error) {
+	var accounts []dao.Account
+	err := u.db.Where("user_id = ?", user.ID).Order("id").Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (u AccountRepositoryImpl) FindAccountByUserAndId(user dao.User, accountID int) (dao.Account, error) {
+	var account dao.Account
+	err := u.db.Where("user_id = ? AND id = ?", user.ID, accountID).First(&account).Error
+	if err != nil {
+		log.Error("Got and error when find account by id. Error: ", err)
+		return dao.Account{}, err
+	}
+	return account, nil
+}
+
+// FindDefaultAccount returns the user's default account, creating it in the
+// user's base currency when the user has none yet. Concurrent requests that
+// both miss it create a single one, the unique index on the default account
+// turns the second insert into a no-op.
+func (u AccountRepositoryImpl) FindDefaultAccount(user dao.User) (dao.Account, error) {
+	var account dao.Account
+	err := u.db.Where("user_id = ? AND is_default", user.ID).First(&account).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return account, err
+	}
+
+	account = dao.Account{
+		UserID:    uint(user.ID),
+		Name:      DEFAULT_ACCOUNT_NAME,
+		Type:      DEFAULT_ACCOUNT_TYPE,
+		Currency:  user.BaseCurrency,
+		IsDefault: true,
+	}
+	if err := u.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&account).Error; err != nil {
+		log.Error("Account not saved. Error: ", err)
+		return dao.Account{}, err
+	}
+	account = dao.Account{}
+	err = u.db.Where("user_id = ? AND is_default", user.ID).First(&account).Error
+	return account, err
+}
+
+func (u AccountRepositoryImpl) CountOperations(account dao.Account) (int64, error) {
+	var count int64
+	err := u.db.Model(&dao.Operation{}).Where("account_id = ?", account.ID).Count(&count).Error
+	return count, err
+}
+
+func (u AccountRepositoryImpl) Save(account *dao.Account) (dao.Account, error) {
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		if err := unsetOtherDefaultAccounts(tx, account); err != nil {
+			return err
+		}
+		return tx.Create(&account).Error
+	})
+	if err != nil {
+		log.Error("Account not saved. Error: ", err)
+	}
+	return *account, err
+}
+
+func (u AccountRepositoryImpl) Update(account *dao.Account) (dao.Account, error) {
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		if err := unsetOtherDefaultAccounts(tx, account); err != nil {
+			return err
+		}
+		return tx.Save(&account).Error
+	})
+	return *account, err
+}
+
+func (u AccountRepositoryImpl) Delete(account *dao.Account) (dao.Account, error) {
+	err := u.db.Delete(&account).Error
+	return *account, err
+}
+
+// unsetOtherDefaultAccounts keeps a single default account per user. It runs
+// before the account is saved, the unique index allows one default at a time.
+func unsetOtherDefaultAccounts(tx *gorm.DB, account *dao.Account) error {
+	if !account.IsDefault {
+		return nil
+	}
+	return tx.Model(&dao.Account{}).
+		Where("user_id = ? AND id <> ? AND is_default", account.UserID, account.ID).
+		Update("is_default", false).Error
+}
+
+// migrateDefaultAccountIndex keeps the oldest default account of each user,
+// in case concurrent requests created more than one, and adds the unique index
+// that allows a single default account per user.
+func migrateDefaultAccountIndex(db *gorm.DB) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`UPDATE accounts a SET is_default = false
+			WHERE a.is_default AND a.deleted_at IS NULL AND EXISTS (
+				SELECT 1 FROM accounts b
+				WHERE b.user_id = a.user_id AND b.is_default AND b.deleted_at IS NULL AND b.id < a.id
+			)`).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_user_default
+			ON accounts (user_id) WHERE is_default AND deleted_at IS NULL`).Error
+	})
+	if err != nil {
+		log.Error("Default account index not created. Error: ", err)
+	}
+}
+
+// migrateOperationsToDefaultAccounts moves the operations recorded before
+// accounts existed into a default account of their owner.
+func migrateOperationsToDefaultAccounts(db *gorm.DB) {
+	if !db.Migrator().HasColumn(&dao.Operation{}, "account_id") {
+		return
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`INSERT INTO accounts (user_id, name, type, currency, opening_balance, is_default, created_at, updated_at)
+			SELECT DISTINCT o.user_id, ?, ?, COALESCE(NULLIF(u.base_currency, ''), 'ARS'), 0, true, NOW(), NOW()
+			FROM operations o
+			JOIN users u ON u.id = o.user_id
+			WHERE o.account_id IS NULL AND NOT EXISTS (
+				SELECT 1 FROM accounts a WHERE a.user_id = o.user_id AND a.is_default AND a.deleted_at IS NULL
+			)`, DEFAULT_ACCOUNT_NAME, DEFAULT_ACCOUNT_TYPE).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`UPDATE operations o SET account_id = a.id
+			FROM accounts a
+			WHERE o.account_id IS NULL AND a.user_id = o.user_id AND a.is_default AND a.deleted_at IS NULL`).Error
+	})
+	if err != nil {
+		log.Error("Operations not migrated to default accounts. Error: ", err)
+	}
+}
+
+func AccountRepositoryInit(db *gorm.DB) *AccountRepositoryImpl {
+	migrateMoneyColumns(db, &dao.Account{}, "opening_balance")
+	db.AutoMigrate(&dao.Account{})
+	migrateDefaultAccountIndex(db)
+	migrateOperationsToDefaultAccounts(db)
+	return &AccountRepositoryImpl{
+		db: db,
+	}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	FindAttachmentsByOperation(operation dao.Operation) ([]dao.Attachment, error)
+	FindAttachmentByOperationAndId(operation dao.Operation, attachmentID int) (dao.Attachment, error)
+	FindOrphanedAttachments(limit int) ([]dao.Attachment, error)
+	Save(attachment *dao.Attachment) (dao.Attachment, error)
+	Delete(attachment *dao.Attachment) (dao.Attachment, error)
+}
+
+type AttachmentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func (u AttachmentRepositoryImpl) FindAttachmentsByOperation(operation dao.Operation) ([]dao.Attachment, error) {
+	var attachments []dao.Attachment
+	err := u.db.Where("operation_id = ? AND orphaned_at IS NULL", operation.ID).Order("id").Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (u AttachmentRepositoryImpl) FindAttachmentByOperationAndId(operation dao.Operation, attachmentID int) (dao.Attachment, error) {
+	var attachment dao.Attachment
+	err := u.db.Where("operation_id = ? AND id = ? AND orphaned_at IS NULL", operation.ID, attachmentID).First(&attachment).Error
+	if err != nil {
+		log.Error("Got and error when find attachment by id. Error: ", err)
+		return dao.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+// FindOrphanedAttachments returns attachments whose operation was purged and
+// whose files are still waiting to be removed from the storage.
+func (u AttachmentRepositoryImpl) FindOrphanedAttachments(limit int) ([]dao.Attachment, error) {
+	var attachments []dao.Attachment
+	err := u.db.Where("orphaned_at IS NOT NULL").Order("orphaned_at, id").Limit(limit).Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (u AttachmentRepositoryImpl) Save(attachment *dao.Attachment) (dao.Attachment, error) {
+	err := u.db.Create(&attachment).Error
+	if err != nil {
+		log.Error("Attachment not saved. Error: ", err)
+	}
+	return *attachment, err
+}
+
+func (u AttachmentRepositoryImpl) Delete(attachment *dao.Attachment) (dao.Attachment, error) {
+	err := u.db.Delete(&attachment).Error
+	return *attachment, err
+}
+
+// orphanOperationAttachments marks the attachments of the given operations, a
+// slice of IDs or a subquery, so the cleaner removes their files.
+func orphanOperationAttachments(tx *gorm.DB, operationIDs interface{}) error {
+	return tx.Model(&dao.Attachment{}).
+		Where("operation_id IN (?) AND orphaned_at IS NULL", operationIDs).
+		Update("orphaned_at", time.Now()).Error
+}
+
+func AttachmentRepositoryInit(db *gorm.DB) *AttachmentRepositoryImpl {
+	db.AutoMigrate(&dao.Attachment{})
+	return &AttachmentRepositoryImpl{
+		db: db,
+	}
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AuditRepository interface {
+	SaveAll(entries []dao.AuditEntry) ([]dao.AuditEntry, error)
+	FindAuditEntriesByRecord(user dao.User, recordType string, recordID int) ([]dao.AuditEntry, error)
+	FindAuditEntryByRecordAndId(user dao.User, recordType string, recordID int, entryID int) (dao.AuditEntry, error)
+}
+
+type AuditRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func (u AuditRepositoryImpl) SaveAll(entries []dao.AuditEntry) ([]dao.AuditEntry, error) {
+	if len(entries) == 0 {
+		return entries, nil
+	}
+	err := u.db.CreateInBatches(&entries, OPERATIONS_BATCH_SIZE).Error
+	if err != nil {
+		log.Error("Audit entries not saved. Error: ", err)
+	}
+	return entries, err
+}
+
+// FindAuditEntriesByRecord returns the changes of one of the user's records,
+// the latest first.
+func (u AuditRepositoryImpl) FindAuditEntriesByRecord(user dao.User, recordType string, recordID int) ([]dao.AuditEntry, error) {
+	var entries []dao.AuditEntry
+	err := u.db.Where("user_id = ? AND record_type = ? AND record_id = ?", user.ID, recordType, recordID).
+		Order("id DESC").
+		Find(&entries).Error
+	if err != nil {
+		log.Error("Got and error when find audit entries by record. Error: ", err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (u AuditRepositoryImpl) FindAuditEntryByRecordAndId(user dao.User, recordType string, recordID int, entryID int) (dao.AuditEntry, error) {
+	var entry dao.AuditEntry
+	err := u.db.Where("user_id = ? AND record_type = ? AND record_id = ? AND id = ?", user.ID, recordType, recordID, entryID).
+		First(&entry).Error
+	if err != nil {
+		log.Error("Got and error when find audit entry by id. Error: ", err)
+		return dao.AuditEntry{}, err
+	}
+	return entry, nil
+}
+
+func AuditRepositoryInit(db *gorm.DB) *AuditRepositoryImpl {
+	db.AutoMigrate(&dao.AuditEntry{})
+	return &AuditRepositoryImpl{
+		db: db,
+	}
+}
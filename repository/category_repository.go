@@ -7,6 +7,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// CategoryRepositories are the repositories a change of categories writes
+// through, bound to the same transaction.
+type CategoryRepositories struct {
+	Categories CategoryRepository
+	Audits     AuditRepository
+}
+
 type CategoryRepository interface {
 	FindCategoryByOperation(operation dao.Operation) (dao.Category, error)
 	Save(category *dao.Category) (dao.Category, error)
@@ -16,6 +23,7 @@ type CategoryRepository interface {
 	FindCategoriesByUser(user dao.User) ([]dao.Category, error)
 	FindCategoryByUserAndId(user dao.User, categoryID int) (dao.Category, error)
 	FindDefaultCategories() ([]dao.Category, error)
+	Transaction(callback func(repositories CategoryRepositories) error) error
 }
 
 type CategoryRepositoryImpl struct {
@@ -39,14 +47,29 @@ func (u CategoryRepositoryImpl) Save(category *dao.Category) (dao.Category, erro
 	return *category, err
 }
 
+// Update saves the category and increments its version. When the category has
+// a version it is only saved if that is still the stored one, otherwise
+// ErrVersionConflict is returned.
 func (u CategoryRepositoryImpl) Update(category *dao.Category) (dao.Category, error) {
-	err := u.db.Save(&category).Error
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		if err := incrementVersion(tx, &dao.Category{}, category.ID, &category.Version); err != nil {
+			return err
+		}
+		return tx.Omit("Version").Save(&category).Error
+	})
 	return *category, err
 }
 
 func (u CategoryRepositoryImpl) Delete(category *dao.Category) (dao.Category, error) {
-	err := u.db.Delete(&category).Error
-	return *category, err
+	if category.Version == 0 {
+		err := u.db.Delete(&category).Error
+		return *category, err
+	}
+	result := u.db.Where("version = ?", category.Version).Delete(&category)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return *category, ErrVersionConflict
+	}
+	return *category, result.Error
 }
 
 func (u CategoryRepositoryImpl) FindCategoryByOperation(operation dao.Operation) (dao.Category, error) {
@@ -84,8 +107,20 @@ func (u CategoryRepositoryImpl) FindCategoryByUserAndId(user dao.User, categoryI
 	return category, nil
 }
 
+// Transaction runs the callback with repositories bound to one transaction,
+// committed when the callback returns nil and rolled back otherwise.
+func (u CategoryRepositoryImpl) Transaction(callback func(repositories CategoryRepositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return callback(CategoryRepositories{
+			Categories: CategoryRepositoryImpl{db: tx},
+			Audits:     AuditRepositoryImpl{db: tx},
+		})
+	})
+}
+
 func CategoryRepositoryInit(db *gorm.DB) *CategoryRepositoryImpl {
 	db.AutoMigrate(&dao.Category{})
+	migrateSearchVector(db, "categories")
 	return &CategoryRepositoryImpl{
 		db: db,
 	}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ExchangeRateRepository interface {
+	FindExchangeRatesByUser(user dao.User) ([]dao.ExchangeRate, error)
+	FindExchangeRateByUserAndId(user dao.User, exchangeRateID int) (dao.ExchangeRate, error)
+	FindRateOnDate(user dao.User, fromCurrency string, toCurrency string, date time.Time) (dao.ExchangeRate, error)
+	SaveAll(exchangeRates []dao.ExchangeRate) error
+	Delete(exchangeRate *dao.ExchangeRate) (dao.ExchangeRate, error)
+}
+
+type ExchangeRateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func (u ExchangeRateRepositoryImpl) FindExchangeRatesByUser(user dao.User) ([]dao.ExchangeRate, error) {
+	var exchangeRates []dao.ExchangeRate
+	err := u.db.Where("user_id = ?", user.ID).Order("date DESC, from_currency, to_currency").Find(&exchangeRates).Error
+	if err != nil {
+		return nil, err
+	}
+	return exchangeRates, nil
+}
+
+func (u ExchangeRateRepositoryImpl) FindExchangeRateByUserAndId(user dao.User, exchangeRateID int) (dao.ExchangeRate, error) {
+	var exchangeRate dao.ExchangeRate
+	err := u.db.Where("user_id = ? AND id = ?", user.ID, exchangeRateID).First(&exchangeRate).Error
+	if err != nil {
+		log.Error("Got and error when find exchange rate by id. Error: ", err)
+		return dao.ExchangeRate{}, err
+	}
+	return exchangeRate, nil
+}
+
+// FindRateOnDate returns the most recent rate for the pair dated on or before date.
+func (u ExchangeRateRepositoryImpl) FindRateOnDate(user dao.User, fromCurrency string, toCurrency string, date time.Time) (dao.ExchangeRate, error) {
+	var exchangeRate dao.ExchangeRate
+	err := u.db.Where("user_id = ? AND from_currency = ? AND to_currency = ? AND date <= ?", user.ID, fromCurrency, toCurrency, date).
+		Order("date DESC").
+		First(&exchangeRate).Error
+	return exchangeRate, err
+}
+
+// SaveAll upserts the rates in a single transaction, replacing the rate of an
+// existing pair and date.
+func (u ExchangeRateRepositoryImpl) SaveAll(exchangeRates []dao.ExchangeRate) error {
+	if len(exchangeRates) == 0 {
+		return nil
+	}
+	err := u.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "from_currency"}, {Name: "to_currency"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate"}),
+	}).Create(&exchangeRates).Error
+	if err != nil {
+		log.Error("Exchange rates not saved. Error: ", err)
+	}
+	return err
+}
+
+func (u ExchangeRateRepositoryImpl) Delete(exchangeRate *dao.ExchangeRate) (dao.ExchangeRate, error) {
+	err := u.db.Unscoped().Delete(&exchangeRate).Error
+	return *exchangeRate, err
+}
+
+func ExchangeRateRepositoryInit(db *gorm.DB) *ExchangeRateRepositoryImpl {
+	db.AutoMigrate(&dao.ExchangeRate{})
+	return &ExchangeRateRepositoryImpl{
+		db: db,
+	}
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyKeyRepository interface {
+	Reserve(idempotencyKey *dao.IdempotencyKey) (bool, error)
+	FindIdempotencyKeyByUserAndKey(user dao.User, key string) (dao.IdempotencyKey, error)
+	Complete(idempotencyKey *dao.IdempotencyKey) (dao.IdempotencyKey, error)
+	Release(idempotencyKey *dao.IdempotencyKey) (dao.IdempotencyKey, error)
+}
+
+type IdempotencyKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// Reserve saves the key while its request is handled, reporting false when the
+// user already has it. The user's expired keys are removed first, so an
+// expired key can be used again.
+func (u IdempotencyKeyRepositoryImpl) Reserve(idempotencyKey *dao.IdempotencyKey) (bool, error) {
+	reserved := false
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND expires_at < ?", idempotencyKey.UserID, idempotencyKey.CreatedAt).
+			Delete(&dao.IdempotencyKey{}).Error; err != nil {
+			return err
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(idempotencyKey)
+		reserved = result.RowsAffected == 1
+		return result.Error
+	})
+	if err != nil {
+		log.Error("Got and error when reserve idempotency key. Error: ", err)
+	}
+	return reserved, err
+}
+
+func (u IdempotencyKeyRepositoryImpl) FindIdempotencyKeyByUserAndKey(user dao.User, key string) (dao.IdempotencyKey, error) {
+	var idempotencyKey dao.IdempotencyKey
+	err := u.db.Where("user_id = ? AND key = ?", user.ID, key).First(&idempotencyKey).Error
+	if err != nil {
+		log.Error("Got and error when find idempotency key. Error: ", err)
+		return dao.IdempotencyKey{}, err
+	}
+	return idempotencyKey, nil
+}
+
+// Complete stores the response of the key's request, replayed from then on.
+func (u IdempotencyKeyRepositoryImpl) Complete(idempotencyKey *dao.IdempotencyKey) (dao.IdempotencyKey, error) {
+	err := u.db.Model(&dao.IdempotencyKey{}).Where("id = ?", idempotencyKey.ID).Updates(map[string]interface{}{
+		"status_code":  idempotencyKey.StatusCode,
+		"content_type": idempotencyKey.ContentType,
+		"body":         idempotencyKey.Body,
+	}).Error
+	return *idempotencyKey, err
+}
+
+// Release removes the key so that its request can be retried.
+func (u IdempotencyKeyRepositoryImpl) Release(idempotencyKey *dao.IdempotencyKey) (dao.IdempotencyKey, error) {
+	err := u.db.Where("id = ?", idempotencyKey.ID).Delete(&dao.IdempotencyKey{}).Error
+	return *idempotencyKey, err
+}
+
+func IdempotencyKeyRepositoryInit(db *gorm.DB) *IdempotencyKeyRepositoryImpl {
+	db.AutoMigrate(&dao.IdempotencyKey{})
+	return &IdempotencyKeyRepositoryImpl{
+		db: db,
+	}
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// migrateMoneyColumns converts amount columns created as floating point into
+// NUMERIC, rounding the stored values to cents. Tables not created yet or
+// already converted are left alone, so it is safe to run on every start.
+func migrateMoneyColumns(db *gorm.DB, model interface{}, columns ...string) {
+	if !db.Migrator().HasTable(model) {
+		return
+	}
+	columnTypes, err := db.Migrator().ColumnTypes(model)
+	if err != nil {
+		log.Error("Got and error when read the column types. Error: ", err)
+		return
+	}
+	statement := &gorm.Statement{DB: db}
+	if err := statement.Parse(model); err != nil {
+		log.Error("Got and error when parse the model. Error: ", err)
+		return
+	}
+
+	for _, columnType := range columnTypes {
+		if !containsColumn(columns, columnType.Name()) || strings.EqualFold(columnType.DatabaseTypeName(), "numeric") {
+			continue
+		}
+		err := db.Exec(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE NUMERIC(15,2) USING ROUND(%s::numeric, 2)",
+			statement.Table, columnType.Name(), columnType.Name())).Error
+		if err != nil {
+			log.Error("Got and error when migrate the money column ", columnType.Name(), ". Error: ", err)
+		}
+	}
+}
+
+func containsColumn(columns []string, name string) bool {
+	for _, column := range columns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
@@ -2,17 +2,66 @@ package repository
 
 import (
 	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
+const DEFAULT_OPERATIONS_LIMIT int = 50
+const MAX_OPERATIONS_LIMIT int = 200
+const OPERATIONS_BATCH_SIZE int = 100
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrVersionConflict is returned when a record changed after it was loaded,
+// its version no longer being the one the change was based on.
+var ErrVersionConflict = errors.New("version conflict")
+
+var operationSortColumns = map[string]string{
+	dto.SortDateDesc:   "date",
+	dto.SortDateAsc:    "date",
+	dto.SortAmountDesc: "amount",
+	dto.SortAmountAsc:  "amount",
+}
+
+type operationCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+// OperationRepositories are the repositories a change of operations writes
+// through, bound to the same transaction.
+type OperationRepositories struct {
+	Operations OperationRepository
+	Transfers  TransferRepository
+	Tags       TagRepository
+	Audits     AuditRepository
+}
+
 type OperationRepository interface {
 	FindOperationsByUser(user dao.User) ([]dao.Operation, error)
+	FindOperationsByFilter(user dao.User, filter dto.OperationFilter) ([]dao.Operation, string, error)
 	Save(operation *dao.Operation) (dao.Operation, error)
 	FindOperationByUserAndId(user dao.User, operationID int) (dao.Operation, error)
+	FindOperationsBetween(user dao.User, from time.Time, to time.Time) ([]dao.Operation, error)
+	FindOperationsByExternalIDs(user dao.User, externalIDs []string) ([]dao.Operation, error)
+	StreamOperationsByFilter(user dao.User, filter dto.OperationFilter, callback func(dto.OperationExportRow) error) error
+	SearchOperations(user dao.User, search dto.OperationSearchRequest) ([]dto.OperationSearchRow, error)
+	FindDuplicateOperations(user dao.User, filter dto.OperationDuplicateFilter) ([]dto.OperationDuplicateRow, error)
+	SaveAll(operations []dao.Operation) ([]dao.Operation, error)
 	Update(operation *dao.Operation) (dao.Operation, error)
 	Delete(operation *dao.Operation) (dao.Operation, error)
+	MoveAttachments(from dao.Operation, to dao.Operation) error
+	Transaction(callback func(repositories OperationRepositories) error) error
 }
 
 type OperationRepositoryImpl struct {
@@ -24,6 +73,54 @@ func (u OperationRepositoryImpl) FindOperationsByUser(user dao.User) ([]dao.Oper
 	return user.Operations, nil
 }
 
+func (u OperationRepositoryImpl) FindOperationsByFilter(user dao.User, filter dto.OperationFilter) ([]dao.Operation, string, error) {
+	sort := filter.Sort
+	if sort == "" {
+		sort = dto.SortDateDesc
+	}
+	column := operationSortColumns[sort]
+	direction, comparator := "DESC", "<"
+	if strings.HasSuffix(sort, "_asc") {
+		direction, comparator = "ASC", ">"
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DEFAULT_OPERATIONS_LIMIT
+	} else if limit > MAX_OPERATIONS_LIMIT {
+		limit = MAX_OPERATIONS_LIMIT
+	}
+
+	query := ApplyOperationFilter(u.db.Where("user_id = ?", user.ID), filter)
+
+	if filter.Cursor != "" {
+		cursor, cursorValue, err := decodeOperationCursor(filter.Cursor, sort)
+		if err != nil {
+			return nil, "", ErrInvalidCursor
+		}
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, comparator), cursorValue, cursor.ID)
+	}
+
+	var operations []dao.Operation
+	err := query.Preload("Category").Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}).
+		Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Limit(limit + 1).
+		Find(&operations).Error
+	if err != nil {
+		log.Error("Got and error when find operations by filter. Error: ", err)
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(operations) > limit {
+		operations = operations[:limit]
+		nextCursor = encodeOperationCursor(operations[limit-1], sort)
+	}
+	return operations, nextCursor, nil
+}
+
 func (u OperationRepositoryImpl) FindOperationByUserAndId(user dao.User, operationID int) (dao.Operation, error) {
 	operation := dao.Operation{
 		ID:     operationID,
@@ -34,27 +131,348 @@ func (u OperationRepositoryImpl) FindOperationByUserAndId(user dao.User, operati
 		log.Error("Got and error when find operation by id. Error: ", err)
 		return dao.Operation{}, err
 	}
-	u.db.Preload("Category").First(&operation)
+	u.db.Preload("Category").Preload("Splits", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Preload("Splits.Category").Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}).First(&operation)
 	return operation, nil
 }
 
+// FindOperationsBetween returns the user's operations dated from the start up to,
+// but not including, the end.
+func (u OperationRepositoryImpl) FindOperationsBetween(user dao.User, from time.Time, to time.Time) ([]dao.Operation, error) {
+	var operations []dao.Operation
+	err := u.db.Where("user_id = ? AND date >= ? AND date < ?", user.ID, from, to).Order("date, id").Find(&operations).Error
+	if err != nil {
+		log.Error("Got and error when find operations between dates. Error: ", err)
+		return nil, err
+	}
+	return operations, nil
+}
+
+// FindOperationsByExternalIDs returns the user's operations imported with one
+// of the given bank transaction ids.
+func (u OperationRepositoryImpl) FindOperationsByExternalIDs(user dao.User, externalIDs []string) ([]dao.Operation, error) {
+	var operations []dao.Operation
+	err := u.db.Where("user_id = ? AND external_id IN ?", user.ID, externalIDs).Order("id").Find(&operations).Error
+	if err != nil {
+		log.Error("Got and error when find operations by external ids. Error: ", err)
+		return nil, err
+	}
+	return operations, nil
+}
+
+// StreamOperationsByFilter reads the filtered operations by date from a cursor,
+// passing them one at a time to the callback. It stops at the first error the
+// callback returns.
+func (u OperationRepositoryImpl) StreamOperationsByFilter(user dao.User, filter dto.OperationFilter, callback func(dto.OperationExportRow) error) error {
+	filteredOperations := ApplyOperationFilter(u.db.Model(&dao.Operation{}).Where("user_id = ?", user.ID), filter)
+	rows, err := u.db.Table("(?) AS operations", filteredOperations).
+		Select(`operations.id, operations.date, operations.type, operations.amount, operations.currency,
+			operations.category_id, COALESCE(categories.name, '') AS category_name, operations.account_id,
+			operations.transfer_id, operations.description, operations.external_id`).
+		Joins("LEFT JOIN categories ON categories.id = operations.category_id").
+		Order("operations.date, operations.id").
+		Rows()
+	if err != nil {
+		log.Error("Got and error when stream operations by filter. Error: ", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row dto.OperationExportRow
+		if err := u.db.ScanRows(rows, &row); err != nil {
+			log.Error("Got and error when scan an exported operation. Error: ", err)
+			return err
+		}
+		if err := callback(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// SearchOperations returns the user's operations whose description, or the
+// description of their category, matches the words searched, the best ranked
+// first. Matches on the operation rank above those on its category. The
+// highlights are HTML escaped, with the matched words in <mark> tags.
+func (u OperationRepositoryImpl) SearchOperations(user dao.User, search dto.OperationSearchRequest) ([]dto.OperationSearchRow, error) {
+	limit := search.Limit
+	if limit <= 0 {
+		limit = DEFAULT_OPERATIONS_LIMIT
+	} else if limit > MAX_OPERATIONS_LIMIT {
+		limit = MAX_OPERATIONS_LIMIT
+	}
+
+	var rows []dto.OperationSearchRow
+	err := u.db.Raw(`WITH search AS (SELECT `+searchQuery()+` AS query)
+		SELECT o.id, o.type, o.amount, o.currency, o.date, o.account_id, o.transfer_id, o.description,
+			COALESCE(c.name, '') AS category_name, COALESCE(c.color, '') AS category_color,
+			ts_rank(o.search_vector, search.query) + 0.5 * ts_rank(COALESCE(c.search_vector, ''::tsvector), search.query) AS rank,
+			`+searchHeadline("o.description")+` AS description_highlight,
+			`+searchHeadline("COALESCE(c.description, '')")+` AS category_highlight
+		FROM operations o
+		CROSS JOIN search
+		LEFT JOIN categories c ON c.id = o.category_id AND c.deleted_at IS NULL
+		WHERE o.user_id = @user AND o.deleted_at IS NULL
+			AND (o.search_vector @@ search.query OR c.search_vector @@ search.query)
+		ORDER BY rank DESC, o.date DESC, o.id DESC
+		LIMIT @limit`, map[string]interface{}{
+		"q":     search.Query,
+		"user":  user.ID,
+		"limit": limit,
+	}).Scan(&rows).Error
+	if err != nil {
+		log.Error("Got and error when search operations. Error: ", err)
+		return nil, err
+	}
+	for index := range rows {
+		rows[index].DescriptionHighlight = mergeHeadlines(rows[index].DescriptionHighlight)
+		rows[index].CategoryHighlight = mergeHeadlines(rows[index].CategoryHighlight)
+	}
+	return rows, nil
+}
+
+// FindDuplicateOperations returns the pairs of the user's operations with the
+// same type, amount and currency dated within the days of the filter, the
+// latest first. The sides of transfers are left out.
+func (u OperationRepositoryImpl) FindDuplicateOperations(user dao.User, filter dto.OperationDuplicateFilter) ([]dto.OperationDuplicateRow, error) {
+	query := `SELECT o.id AS operation_id, d.id AS duplicate_id, o.type, o.amount, o.currency,
+			o.date AS operation_date, d.date AS duplicate_date,
+			o.description AS operation_description, d.description AS duplicate_description
+		FROM operations o
+		JOIN operations d ON d.user_id = o.user_id AND d.id > o.id
+			AND d.type = o.type AND d.amount = o.amount AND d.currency = o.currency
+			AND d.date BETWEEN o.date - make_interval(days => @days) AND o.date + make_interval(days => @days)
+			AND d.deleted_at IS NULL AND d.transfer_id IS NULL
+		WHERE o.user_id = @user AND o.deleted_at IS NULL AND o.transfer_id IS NULL`
+	if filter.OperationID != 0 {
+		query += ` AND (o.id = @operation OR d.id = @operation)`
+	}
+	query += ` ORDER BY d.date DESC, d.id DESC, o.id DESC`
+
+	var rows []dto.OperationDuplicateRow
+	err := u.db.Raw(query, map[string]interface{}{
+		"user":      user.ID,
+		"days":      filter.Days,
+		"operation": filter.OperationID,
+	}).Scan(&rows).Error
+	if err != nil {
+		log.Error("Got and error when find duplicate operations. Error: ", err)
+		return nil, err
+	}
+	return rows, nil
+}
+
+// SaveAll creates the operations in a single transaction, none is saved when
+// one of them fails.
+func (u OperationRepositoryImpl) SaveAll(operations []dao.Operation) ([]dao.Operation, error) {
+	if len(operations) == 0 {
+		return operations, nil
+	}
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(&operations, OPERATIONS_BATCH_SIZE).Error
+	})
+	if err != nil {
+		log.Error("Operations not saved. Error: ", err)
+	}
+	return operations, err
+}
+
 func (u OperationRepositoryImpl) Save(operation *dao.Operation) (dao.Operation, error) {
 	err := u.db.Create(&operation).Error
 	return *operation, err
 }
 
+// Update saves the operation and replaces its splits with the ones it carries.
+// Its tags are replaced too unless they are nil. The version is incremented;
+// when the operation has one it is only saved if that is still the stored
+// one, otherwise ErrVersionConflict is returned.
 func (u OperationRepositoryImpl) Update(operation *dao.Operation) (dao.Operation, error) {
-	err := u.db.Save(&operation).Error
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		if err := incrementVersion(tx, &dao.Operation{}, operation.ID, &operation.Version); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("operation_id = ?", operation.ID).Delete(&dao.OperationSplit{}).Error; err != nil {
+			return err
+		}
+		// The reconciliation of an operation is only changed by reconciliations, and
+		// an operation without a status keeps the stored one.
+		omitted := []string{"Tags", "Version", "ReconciliationID"}
+		if operation.Status == "" {
+			omitted = append(omitted, "Status")
+		}
+		if err := tx.Omit(omitted...).Save(&operation).Error; err != nil {
+			return err
+		}
+		if operation.Tags == nil {
+			return nil
+		}
+		return tx.Model(&operation).Association("Tags").Replace(operation.Tags)
+	})
 	return *operation, err
 }
 
+// Delete moves the operation to the trash. Its attachments are kept until the
+// operation is purged, so restoring it brings them back. As with Update, an
+// operation with a version is only deleted if that is still the stored one.
 func (u OperationRepositoryImpl) Delete(operation *dao.Operation) (dao.Operation, error) {
-	err := u.db.Delete(&operation).Error
-	return *operation, err
+	if operation.Version == 0 {
+		err := u.db.Delete(&operation).Error
+		return *operation, err
+	}
+	result := u.db.Where("version = ?", operation.Version).Delete(&operation)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return *operation, ErrVersionConflict
+	}
+	return *operation, result.Error
+}
+
+// MoveAttachments moves the attachments of an operation to another one.
+func (u OperationRepositoryImpl) MoveAttachments(from dao.Operation, to dao.Operation) error {
+	return u.db.Model(&dao.Attachment{}).
+		Where("operation_id = ? AND orphaned_at IS NULL", from.ID).
+		Update("operation_id", to.ID).Error
+}
+
+// Transaction runs the callback with repositories bound to one transaction,
+// committed when the callback returns nil and rolled back otherwise. Called on
+// repositories already in a transaction it works on a savepoint.
+func (u OperationRepositoryImpl) Transaction(callback func(repositories OperationRepositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return callback(OperationRepositories{
+			Operations: OperationRepositoryImpl{db: tx},
+			Transfers:  TransferRepositoryImpl{db: tx},
+			Tags:       TagRepositoryImpl{db: tx},
+			Audits:     AuditRepositoryImpl{db: tx},
+		})
+	})
+}
+
+// incrementVersion increments the version of the record with the given id and
+// stores the new one in version. A version set beforehand must match the stored
+// one.
+func incrementVersion(tx *gorm.DB, model interface{}, id int, version *int) error {
+	query := tx.Model(model).Where("id = ?", id)
+	if *version > 0 {
+		query = query.Where("version = ?", *version)
+	}
+	result := query.UpdateColumn("version", gorm.Expr("version + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return tx.Model(model).Select("version").Where("id = ?", id).Scan(version).Error
+}
+
+// ApplyOperationFilter adds the date, type, category, amount, status,
+// description and tag conditions of the filter to the query. Filtering by
+// income or expense leaves out the sides of transfers. Sorting and pagination
+// are left to the caller.
+func ApplyOperationFilter(query *gorm.DB, filter dto.OperationFilter) *gorm.DB {
+	if filter.From != nil {
+		query = query.Where("date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("date <= ?", *filter.To)
+	}
+	if filter.Type == dto.TransferType {
+		query = query.Where("transfer_id IS NOT NULL")
+	} else if filter.Type != "" {
+		query = query.Where("type = ? AND transfer_id IS NULL", filter.Type)
+	}
+	if filter.AccountID != nil {
+		query = query.Where("account_id = ?", *filter.AccountID)
+	}
+	if filter.PayeeID != nil {
+		query = query.Where("payee_id = ?", *filter.PayeeID)
+	}
+	if filter.Currency != "" {
+		query = query.Where("currency = ?", filter.Currency)
+	}
+	if filter.CategoryID != nil {
+		query = query.Where("category_id = ?", *filter.CategoryID)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Description != "" {
+		query = query.Where("description ILIKE ?", "%"+escapeLike(filter.Description)+"%")
+	}
+	if len(filter.Tags) > 0 {
+		taggedOperations := query.Session(&gorm.Session{NewDB: true}).Table("operation_tags").
+			Select("operation_tags.operation_id").
+			Joins("JOIN tags ON tags.id = operation_tags.tag_id AND tags.deleted_at IS NULL").
+			Where("tags.name IN ?", filter.Tags)
+		if filter.TagMode == dto.TagModeAll {
+			taggedOperations = taggedOperations.Group("operation_tags.operation_id").
+				Having("COUNT(DISTINCT tags.name) = ?", len(filter.Tags))
+		}
+		query = query.Where("id IN (?)", taggedOperations)
+	}
+	return query
+}
+
+// OperationCategoryAmounts selects one row per category of each operation: the
+// lines of split operations and the whole amount of the rest. Per-category
+// aggregations should read from it instead of the operations table.
+func OperationCategoryAmounts(db *gorm.DB) *gorm.DB {
+	return db.Raw(`SELECT o.id AS operation_id, o.user_id, o.type, o.currency, o.date, o.transfer_id,
+			COALESCE(s.category_id, o.category_id) AS category_id, COALESCE(s.amount, o.amount) AS amount
+		FROM operations o
+		LEFT JOIN operation_splits s ON s.operation_id = o.id AND s.deleted_at IS NULL
+		WHERE o.deleted_at IS NULL`)
+}
+
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
+
+func encodeOperationCursor(operation dao.Operation, sort string) string {
+	value := operation.Date.UTC().Format(time.RFC3339Nano)
+	if operationSortColumns[sort] == "amount" {
+		value = operation.Amount.String()
+	}
+	cursorJSON, _ := json.Marshal(operationCursor{Sort: sort, Value: value, ID: operation.ID})
+	return base64.RawURLEncoding.EncodeToString(cursorJSON)
+}
+
+func decodeOperationCursor(encoded string, sort string) (operationCursor, interface{}, error) {
+	var cursor operationCursor
+	cursorJSON, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, nil, err
+	}
+	if err := json.Unmarshal(cursorJSON, &cursor); err != nil {
+		return cursor, nil, err
+	}
+	if cursor.Sort != sort {
+		return cursor, nil, ErrInvalidCursor
+	}
+	if operationSortColumns[sort] == "amount" {
+		amount, err := money.Parse(cursor.Value)
+		return cursor, amount, err
+	}
+	date, err := time.Parse(time.RFC3339Nano, cursor.Value)
+	return cursor, date, err
 }
 
 func OperationRepositoryInit(db *gorm.DB) *OperationRepositoryImpl {
-	db.AutoMigrate(&dao.Operation{})
+	migrateMoneyColumns(db, &dao.Operation{}, "amount")
+	migrateMoneyColumns(db, &dao.OperationSplit{}, "amount")
+	db.AutoMigrate(&dao.Operation{}, &dao.OperationSplit{})
+	migrateSearchVector(db, "operations")
 	return &OperationRepositoryImpl{
 		db: db,
 	}
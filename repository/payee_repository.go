@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PayeeRepository interface {
+	FindPayeesByUser(user dao.User) ([]dao.Payee, error)
+	FindPayeeByUserAndId(user dao.User, payeeID int) (dao.Payee, error)
+	FindPayeeTotals(user dao.User, filter dto.PayeeTotalsFilter) ([]dto.PayeeTotalRow, error)
+	Save(payee *dao.Payee) (dao.Payee, error)
+	Update(payee *dao.Payee) (dao.Payee, error)
+	Delete(payee *dao.Payee) (dao.Payee, error)
+	Merge(payee *dao.Payee, merged *dao.Payee) error
+}
+
+type PayeeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func (u PayeeRepositoryImpl) FindPayeesByUser(user dao.User) ([]dao.Payee, error) {
+	var payees []dao.Payee
+	err := u.db.Where("user_id = ?", user.ID).Order("name, id").Find(&payees).Error
+	if err != nil {
+		log.Error("Got and error when find payees by user. Error: ", err)
+		return nil, err
+	}
+	return payees, nil
+}
+
+func (u PayeeRepositoryImpl) FindPayeeByUserAndId(user dao.User, payeeID int) (dao.Payee, error) {
+	var payee dao.Payee
+	err := u.db.Where("user_id = ? AND id = ?", user.ID, payeeID).First(&payee).Error
+	if err != nil {
+		log.Error("Got and error when find payee by id. Error: ", err)
+		return dao.Payee{}, err
+	}
+	return payee, nil
+}
+
+// FindPayeeTotals adds up the expenses of every payee by currency, the largest
+// first. The sides of transfers are left out.
+func (u PayeeRepositoryImpl) FindPayeeTotals(user dao.User, filter dto.PayeeTotalsFilter) ([]dto.PayeeTotalRow, error) {
+	query := u.db.Model(&dao.Operation{}).
+		Select("payees.id AS payee_id, payees.name, operations.currency, SUM(operations.amount) AS total, COUNT(*) AS count").
+		Joins("JOIN payees ON payees.id = operations.payee_id AND payees.deleted_at IS NULL").
+		Where("operations.user_id = ? AND operations.type = ? AND operations.transfer_id IS NULL", user.ID, "expense")
+	if filter.From != nil {
+		query = query.Where("operations.date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("operations.date <= ?", *filter.To)
+	}
+	var rows []dto.PayeeTotalRow
+	err := query.Group("payees.id, payees.name, operations.currency").
+		Order("total DESC, payees.name").
+		Scan(&rows).Error
+	if err != nil {
+		log.Error("Got and error when find payee totals. Error: ", err)
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (u PayeeRepositoryImpl) Save(payee *dao.Payee) (dao.Payee, error) {
+	err := u.db.Create(&payee).Error
+	return *payee, err
+}
+
+func (u PayeeRepositoryImpl) Update(payee *dao.Payee) (dao.Payee, error) {
+	err := u.db.Save(&payee).Error
+	return *payee, err
+}
+
+// Delete deletes the payee and leaves its operations without one.
+func (u PayeeRepositoryImpl) Delete(payee *dao.Payee) (dao.Payee, error) {
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&dao.Operation{}).Where("payee_id = ?", payee.ID).Updates(map[string]interface{}{
+			"payee_id": nil,
+			"version":  gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&payee).Error
+	})
+	return *payee, err
+}
+
+// Merge moves the operations of merged to payee, saves payee with the aliases
+// it took over and deletes merged, in a single transaction.
+func (u PayeeRepositoryImpl) Merge(payee *dao.Payee, merged *dao.Payee) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&dao.Operation{}).Where("payee_id = ?", merged.ID).Updates(map[string]interface{}{
+			"payee_id": payee.ID,
+			"version":  gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Save(&payee).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&merged).Error
+	})
+}
+
+func PayeeRepositoryInit(db *gorm.DB) *PayeeRepositoryImpl {
+	db.AutoMigrate(&dao.Payee{})
+	return &PayeeRepositoryImpl{
+		db: db,
+	}
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ReconciliationRepository interface {
+	FindReconciliationsByUser(user dao.User) ([]dao.Reconciliation, error)
+	FindReconciliationByUserAndId(user dao.User, reconciliationID int) (dao.Reconciliation, error)
+	FindReconciliationOperations(reconciliation dao.Reconciliation) ([]dao.Operation, error)
+	FindReconciledBalance(reconciliation dao.Reconciliation) (money.Amount, error)
+	Save(reconciliation *dao.Reconciliation) (dao.Reconciliation, error)
+	TickOperations(reconciliation dao.Reconciliation, operationIDs []int) error
+	UntickOperations(reconciliation dao.Reconciliation, operationIDs []int) error
+	Finalize(reconciliation *dao.Reconciliation) error
+}
+
+type ReconciliationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func (u ReconciliationRepositoryImpl) FindReconciliationsByUser(user dao.User) ([]dao.Reconciliation, error) {
+	var reconciliations []dao.Reconciliation
+	err := u.db.Where("user_id = ?", user.ID).Order("statement_date DESC, id DESC").Find(&reconciliations).Error
+	if err != nil {
+		log.Error("Got and error when find reconciliations by user. Error: ", err)
+		return nil, err
+	}
+	return reconciliations, nil
+}
+
+func (u ReconciliationRepositoryImpl) FindReconciliationByUserAndId(user dao.User, reconciliationID int) (dao.Reconciliation, error) {
+	var reconciliation dao.Reconciliation
+	err := u.db.Where("user_id = ? AND id = ?", user.ID, reconciliationID).First(&reconciliation).Error
+	if err != nil {
+		log.Error("Got and error when find reconciliation by id. Error: ", err)
+		return dao.Reconciliation{}, err
+	}
+	return reconciliation, nil
+}
+
+// FindReconciliationOperations returns the operations of the reconciliation,
+// the oldest first. An open reconciliation also returns the operations of its
+// account dated up to the statement that can still be ticked off.
+func (u ReconciliationRepositoryImpl) FindReconciliationOperations(reconciliation dao.Reconciliation) ([]dao.Operation, error) {
+	query := u.db.Where("reconciliation_id = ?", reconciliation.ID)
+	if reconciliation.Status == dto.ReconciliationStatusOpen {
+		query = query.Or("account_id = ? AND date < ? AND reconciliation_id IS NULL AND status <> ?",
+			reconciliation.AccountID, statementEnd(reconciliation), dto.OperationStatusReconciled)
+	}
+	var operations []dao.Operation
+	err := u.db.Where(query).Order("date, id").Find(&operations).Error
+	if err != nil {
+		log.Error("Got and error when find reconciliation operations. Error: ", err)
+		return nil, err
+	}
+	return operations, nil
+}
+
+// FindReconciledBalance adds up the operations of the account reconciled by
+// the reconciliations finalized before this one, incomes adding and expenses
+// subtracting.
+func (u ReconciliationRepositoryImpl) FindReconciledBalance(reconciliation dao.Reconciliation) (money.Amount, error) {
+	var balance money.Amount
+	previous := u.db.Model(&dao.Reconciliation{}).Select("id").
+		Where("account_id = ? AND status = ? AND id < ?", reconciliation.AccountID, dto.ReconciliationStatusFinalized, reconciliation.ID)
+	err := u.db.Model(&dao.Operation{}).
+		Select("COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE -amount END), 0)", "income").
+		Where("reconciliation_id IN (?)", previous).
+		Scan(&balance).Error
+	if err != nil {
+		log.Error("Got and error when find reconciled balance. Error: ", err)
+		return 0, err
+	}
+	return balance, nil
+}
+
+func (u ReconciliationRepositoryImpl) Save(reconciliation *dao.Reconciliation) (dao.Reconciliation, error) {
+	err := u.db.Create(&reconciliation).Error
+	return *reconciliation, err
+}
+
+// TickOperations adds the operations to the reconciliation and clears them.
+func (u ReconciliationRepositoryImpl) TickOperations(reconciliation dao.Reconciliation, operationIDs []int) error {
+	return u.db.Model(&dao.Operation{}).Where("id IN ?", operationIDs).Updates(map[string]interface{}{
+		"reconciliation_id": reconciliation.ID,
+		"status":            dto.OperationStatusCleared,
+		"version":           gorm.Expr("version + 1"),
+	}).Error
+}
+
+// UntickOperations takes the operations out of the reconciliation, back to
+// pending.
+func (u ReconciliationRepositoryImpl) UntickOperations(reconciliation dao.Reconciliation, operationIDs []int) error {
+	return u.db.Model(&dao.Operation{}).Where("id IN ? AND reconciliation_id = ?", operationIDs, reconciliation.ID).Updates(map[string]interface{}{
+		"reconciliation_id": nil,
+		"status":            dto.OperationStatusPending,
+		"version":           gorm.Expr("version + 1"),
+	}).Error
+}
+
+// Finalize reconciles the operations ticked off and closes the reconciliation,
+// in a single transaction.
+func (u ReconciliationRepositoryImpl) Finalize(reconciliation *dao.Reconciliation) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&dao.Operation{}).Where("reconciliation_id = ?", reconciliation.ID).Updates(map[string]interface{}{
+			"status":  dto.OperationStatusReconciled,
+			"version": gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		reconciliation.Status = dto.ReconciliationStatusFinalized
+		reconciliation.FinalizedAt = &now
+		return tx.Save(&reconciliation).Error
+	})
+}
+
+// statementEnd is the first instant after the statement date.
+func statementEnd(reconciliation dao.Reconciliation) time.Time {
+	return reconciliation.StatementDate.AddDate(0, 0, 1)
+}
+
+func ReconciliationRepositoryInit(db *gorm.DB) *ReconciliationRepositoryImpl {
+	migrateMoneyColumns(db, &dao.Reconciliation{}, "closing_balance")
+	db.AutoMigrate(&dao.Reconciliation{})
+	return &ReconciliationRepositoryImpl{
+		db: db,
+	}
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RecurringOperationRepository interface {
+	FindRecurringOperationsByUser(user dao.User) ([]dao.RecurringOperation, error)
+	FindRecurringOperationByUserAndId(user dao.User, recurringOperationID int) (dao.RecurringOperation, error)
+	FindDueRecurringOperationIDs(now time.Time) ([]int, error)
+	Save(recurringOperation *dao.RecurringOperation) (dao.RecurringOperation, error)
+	Update(recurringOperation *dao.RecurringOperation, nextRun func(recurringOperation dao.RecurringOperation) *time.Time) (dao.RecurringOperation, error)
+	Delete(recurringOperation *dao.RecurringOperation) (dao.RecurringOperation, error)
+	GenerateOccurrences(recurringOperationID int, now time.Time, schedule func(recurringOperation *dao.RecurringOperation) []dao.Operation) (int, error)
+}
+
+type RecurringOperationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func (u RecurringOperationRepositoryImpl) FindRecurringOperationsByUser(user dao.User) ([]dao.RecurringOperation, error) {
+	var recurringOperations []dao.RecurringOperation
+	err := u.db.Preload("Category").Where("user_id = ?", user.ID).Order("id").Find(&recurringOperations).Error
+	if err != nil {
+		return nil, err
+	}
+	return recurringOperations, nil
+}
+
+func (u RecurringOperationRepositoryImpl) FindRecurringOperationByUserAndId(user dao.User, recurringOperationID int) (dao.RecurringOperation, error) {
+	var recurringOperation dao.RecurringOperation
+	err := u.db.Preload("Category").Where("user_id = ? AND id = ?", user.ID, recurringOperationID).First(&recurringOperation).Error
+	if err != nil {
+		log.Error("Got and error when find recurring operation by id. Error: ", err)
+		return dao.RecurringOperation{}, err
+	}
+	return recurringOperation, nil
+}
+
+func (u RecurringOperationRepositoryImpl) FindDueRecurringOperationIDs(now time.Time) ([]int, error) {
+	var ids []int
+	err := u.db.Model(&dao.RecurringOperation{}).
+		Where("next_run_at IS NOT NULL AND next_run_at <= ?", now).
+		Order("next_run_at").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (u RecurringOperationRepositoryImpl) Save(recurringOperation *dao.RecurringOperation) (dao.RecurringOperation, error) {
+	err := u.db.Create(&recurringOperation).Error
+	return *recurringOperation, err
+}
+
+// Update saves the recurring operation under the row lock GenerateOccurrences
+// takes. The occurrences created and the last run are read from the locked row,
+// so a run of the scheduler is never overwritten, and nextRun computes the next
+// one from them.
+func (u RecurringOperationRepositoryImpl) Update(recurringOperation *dao.RecurringOperation, nextRun func(recurringOperation dao.RecurringOperation) *time.Time) (dao.RecurringOperation, error) {
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		var stored dao.RecurringOperation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", recurringOperation.ID).
+			First(&stored).Error
+		if err != nil {
+			return err
+		}
+		recurringOperation.OccurrencesCreated = stored.OccurrencesCreated
+		recurringOperation.LastRunAt = stored.LastRunAt
+		recurringOperation.NextRunAt = nextRun(*recurringOperation)
+		return tx.Save(&recurringOperation).Error
+	})
+	return *recurringOperation, err
+}
+
+func (u RecurringOperationRepositoryImpl) Delete(recurringOperation *dao.RecurringOperation) (dao.RecurringOperation, error) {
+	err := u.db.Delete(&recurringOperation).Error
+	return *recurringOperation, err
+}
+
+// GenerateOccurrences locks the recurring operation, lets schedule build the due
+// operations (advancing the schedule state on the struct) and persists both in a
+// single transaction. Rows already locked by another worker are skipped.
+func (u RecurringOperationRepositoryImpl) GenerateOccurrences(recurringOperationID int, now time.Time, schedule func(recurringOperation *dao.RecurringOperation) []dao.Operation) (int, error) {
+	created := 0
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		var recurringOperation dao.RecurringOperation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("id = ? AND next_run_at <= ?", recurringOperationID, now).
+			First(&recurringOperation).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		operationRepository := OperationRepositoryImpl{db: tx}
+		for _, operation := range schedule(&recurringOperation) {
+			if _, err := operationRepository.Save(&operation); err != nil {
+				return err
+			}
+			created++
+		}
+
+		return tx.Model(&recurringOperation).
+			Select("occurrences_created", "last_run_at", "next_run_at").
+			Updates(&recurringOperation).Error
+	})
+	if err != nil {
+		log.Error("Got and error when generating recurring occurrences. Error: ", err)
+		return 0, err
+	}
+	return created, nil
+}
+
+func RecurringOperationRepositoryInit(db *gorm.DB) *RecurringOperationRepositoryImpl {
+	migrateMoneyColumns(db, &dao.RecurringOperation{}, "amount")
+	db.AutoMigrate(&dao.RecurringOperation{})
+	return &RecurringOperationRepositoryImpl{
+		db: db,
+	}
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ReportingRepository interface {
+	FindBalanceHistory(user dao.User, from time.Time, to time.Time, interval string) ([]dto.BalanceHistoryRow, error)
+	FindCategoryTotals(user dao.User, filter dto.CategoryReportFilter) ([]dto.CategoryReportRow, error)
+}
+
+type ReportingRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// balanceHistoryQuery groups the operations of the user by interval and
+// currency and carries the balance forward with a window function. The
+// operations before the period and the opening balances of the accounts make
+// up the starting balance, so every interval of the period gets a row. Sides of
+// transfers move the balance but are not counted as income or expense.
+const balanceHistoryQuery = `WITH buckets AS (
+		SELECT generate_series(
+			date_trunc(@interval, CAST(@from AS timestamptz) AT TIME ZONE 'UTC'),
+			CAST(@to AS timestamptz) AT TIME ZONE 'UTC',
+			CAST('1 ' || @interval AS interval)) AS bucket
+	), totals AS (
+		SELECT date_trunc(@interval, date AT TIME ZONE 'UTC') AS bucket, currency,
+			SUM(CASE WHEN type = 'income' AND transfer_id IS NULL THEN amount ELSE 0 END) AS income,
+			SUM(CASE WHEN type <> 'income' AND transfer_id IS NULL THEN amount ELSE 0 END) AS expense,
+			SUM(CASE WHEN type = 'income' THEN amount ELSE -amount END) AS net
+		FROM operations
+		WHERE user_id = @user AND deleted_at IS NULL AND date <= @to
+		GROUP BY 1, 2
+	), openings AS (
+		SELECT currency, SUM(opening_balance) AS opening
+		FROM accounts
+		WHERE user_id = @user AND deleted_at IS NULL
+		GROUP BY currency
+	), previous AS (
+		SELECT currency, SUM(net) AS net
+		FROM totals
+		WHERE bucket < (SELECT MIN(bucket) FROM buckets)
+		GROUP BY currency
+	), currencies AS (
+		SELECT currency FROM totals UNION SELECT currency FROM openings
+	)
+	SELECT buckets.bucket, currencies.currency,
+		COALESCE(totals.income, 0) AS income, COALESCE(totals.expense, 0) AS expense,
+		COALESCE(openings.opening, 0) + COALESCE(previous.net, 0) +
+			SUM(COALESCE(totals.net, 0)) OVER (PARTITION BY currencies.currency ORDER BY buckets.bucket) AS balance
+	FROM buckets
+	CROSS JOIN currencies
+	LEFT JOIN totals ON totals.bucket = buckets.bucket AND totals.currency = currencies.currency
+	LEFT JOIN openings ON openings.currency = currencies.currency
+	LEFT JOIN previous ON previous.currency = currencies.currency
+	ORDER BY currencies.currency, buckets.bucket`
+
+// FindBalanceHistory returns the income, expense and closing balance of every
+// interval between the dates, by currency.
+func (u ReportingRepositoryImpl) FindBalanceHistory(user dao.User, from time.Time, to time.Time, interval string) ([]dto.BalanceHistoryRow, error) {
+	var rows []dto.BalanceHistoryRow
+	err := u.db.Raw(balanceHistoryQuery, map[string]interface{}{
+		"user":     user.ID,
+		"from":     from,
+		"to":       to,
+		"interval": interval,
+	}).Scan(&rows).Error
+	if err != nil {
+		log.Error("Got and error when find the balance history. Error: ", err)
+		return nil, err
+	}
+	return rows, nil
+}
+
+// FindCategoryTotals adds up the operations of the type by currency and
+// category, splits counting in each of their categories, the largest first.
+// The sides of transfers are left out.
+func (u ReportingRepositoryImpl) FindCategoryTotals(user dao.User, filter dto.CategoryReportFilter) ([]dto.CategoryReportRow, error) {
+	query := u.db.Table("(?) AS amounts", OperationCategoryAmounts(u.db)).
+		Select("amounts.currency, amounts.category_id, categories.name, categories.color, categories.is_default, SUM(amounts.amount) AS total, COUNT(DISTINCT amounts.operation_id) AS count").
+		Joins("LEFT JOIN categories ON categories.id = amounts.category_id").
+		Where("amounts.user_id = ? AND amounts.type = ? AND amounts.transfer_id IS NULL", user.ID, filter.Type)
+	if filter.From != nil {
+		query = query.Where("amounts.date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("amounts.date <= ?", *filter.To)
+	}
+	var rows []dto.CategoryReportRow
+	err := query.Group("amounts.currency, amounts.category_id, categories.name, categories.color, categories.is_default").
+		Order("amounts.currency, total DESC, categories.name").
+		Scan(&rows).Error
+	if err != nil {
+		log.Error("Got and error when find the category totals. Error: ", err)
+		return nil, err
+	}
+	return rows, nil
+}
+
+func ReportingRepositoryInit(db *gorm.DB) *ReportingRepositoryImpl {
+	return &ReportingRepositoryImpl{
+		db: db,
+	}
+}
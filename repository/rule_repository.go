@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RuleRepository interface {
+	FindRulesByUser(user dao.User) ([]dao.Rule, error)
+	FindRuleByUserAndId(user dao.User, ruleID int) (dao.Rule, error)
+	Save(rule *dao.Rule) (dao.Rule, error)
+	Update(rule *dao.Rule) (dao.Rule, error)
+	Delete(rule *dao.Rule) (dao.Rule, error)
+}
+
+type RuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// FindRulesByUser returns the user's rules in the order they run, the lowest
+// priority first.
+func (u RuleRepositoryImpl) FindRulesByUser(user dao.User) ([]dao.Rule, error) {
+	var rules []dao.Rule
+	err := u.db.Where("user_id = ?", user.ID).Order("priority, id").Find(&rules).Error
+	if err != nil {
+		log.Error("Got and error when find rules by user. Error: ", err)
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (u RuleRepositoryImpl) FindRuleByUserAndId(user dao.User, ruleID int) (dao.Rule, error) {
+	var rule dao.Rule
+	err := u.db.Where("user_id = ? AND id = ?", user.ID, ruleID).First(&rule).Error
+	if err != nil {
+		log.Error("Got and error when find rule by id. Error: ", err)
+		return dao.Rule{}, err
+	}
+	return rule, nil
+}
+
+func (u RuleRepositoryImpl) Save(rule *dao.Rule) (dao.Rule, error) {
+	err := u.db.Create(&rule).Error
+	return *rule, err
+}
+
+func (u RuleRepositoryImpl) Update(rule *dao.Rule) (dao.Rule, error) {
+	err := u.db.Save(&rule).Error
+	return *rule, err
+}
+
+func (u RuleRepositoryImpl) Delete(rule *dao.Rule) (dao.Rule, error) {
+	err := u.db.Delete(&rule).Error
+	return *rule, err
+}
+
+func RuleRepositoryInit(db *gorm.DB) *RuleRepositoryImpl {
+	db.AutoMigrate(&dao.Rule{})
+	return &RuleRepositoryImpl{
+		db: db,
+	}
+}
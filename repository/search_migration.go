@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// searchConfigurations are the text search configurations descriptions are
+// indexed and searched with, as they are written in Spanish or English.
+var searchConfigurations = []string{"spanish", "english"}
+
+// migrateSearchVector adds the search_vector column of the description to the
+// table, generated by Postgres on every write, and its GIN index. Both are
+// left alone when they already exist, so it is safe to run on every start.
+func migrateSearchVector(db *gorm.DB, table string) {
+	vector := ""
+	for index, configuration := range searchConfigurations {
+		if index > 0 {
+			vector += " || "
+		}
+		vector += fmt.Sprintf("to_tsvector('%s', COALESCE(description, ''))", configuration)
+	}
+	err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (%s) STORED", table, vector)).Error
+	if err == nil {
+		err = db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_search_vector ON %s USING GIN (search_vector)", table, table)).Error
+	}
+	if err != nil {
+		log.Error("Got and error when migrate the search vector of ", table, ". Error: ", err)
+	}
+}
+
+// The highlighted words are wrapped in these tags, and searchHeadline separates
+// the headline of each configuration with headlineSeparator. None of them can
+// be mistaken for the escaped text.
+const (
+	markStart         = "<mark>"
+	markStop          = "</mark>"
+	headlineSeparator = "<>"
+	headlineOptions   = "StartSel=" + markStart + ", StopSel=" + markStop + ", HighlightAll=true"
+)
+
+// searchHeadline highlights in the text column the words of the user input,
+// bound as "q", once per configuration. The text is HTML escaped first so the
+// highlight tags are its only markup. The headlines are joined for
+// mergeHeadlines.
+func searchHeadline(column string) string {
+	text := fmt.Sprintf(`replace(replace(replace(replace(replace(%s, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`, column)
+	headlines := []string{}
+	for _, configuration := range searchConfigurations {
+		headlines = append(headlines, fmt.Sprintf("ts_headline('%s', %s, websearch_to_tsquery('%s', @q), '%s')", configuration, text, configuration, headlineOptions))
+	}
+	return fmt.Sprintf("concat_ws('%s', %s)", headlineSeparator, strings.Join(headlines, ", "))
+}
+
+// mergeHeadlines turns the headlines of searchHeadline into one, highlighting
+// the words any configuration highlights.
+func mergeHeadlines(headlines string) string {
+	var text string
+	var selected []bool
+	for _, headline := range strings.Split(headlines, headlineSeparator) {
+		plain, marked := unmarkHeadline(headline)
+		if selected == nil {
+			text, selected = plain, marked
+			continue
+		}
+		if plain != text {
+			continue
+		}
+		for index := range marked {
+			selected[index] = selected[index] || marked[index]
+		}
+	}
+
+	var merged strings.Builder
+	for index := 0; index < len(text); index++ {
+		if selected[index] && (index == 0 || !selected[index-1]) {
+			merged.WriteString(markStart)
+		}
+		merged.WriteByte(text[index])
+		if selected[index] && (index == len(text)-1 || !selected[index+1]) {
+			merged.WriteString(markStop)
+		}
+	}
+	return merged.String()
+}
+
+// unmarkHeadline returns the text of a headline without its tags and which of
+// its bytes were highlighted.
+func unmarkHeadline(headline string) (string, []bool) {
+	var text strings.Builder
+	selected := []bool{}
+	highlighted := false
+	for len(headline) > 0 {
+		switch {
+		case strings.HasPrefix(headline, markStart):
+			highlighted, headline = true, headline[len(markStart):]
+		case strings.HasPrefix(headline, markStop):
+			highlighted, headline = false, headline[len(markStop):]
+		default:
+			text.WriteByte(headline[0])
+			selected = append(selected, highlighted)
+			headline = headline[1:]
+		}
+	}
+	return text.String(), selected
+}
+
+// searchQuery is the text search query of the user input, the words of it
+// parsed with every configuration. The input is bound as the named argument
+// "q".
+func searchQuery() string {
+	query := ""
+	for index, configuration := range searchConfigurations {
+		if index > 0 {
+			query += " || "
+		}
+		query += fmt.Sprintf("websearch_to_tsquery('%s', @q)", configuration)
+	}
+	return query
+}
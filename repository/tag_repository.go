@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TagRepository interface {
+	FindTagsByUser(user dao.User) ([]dao.Tag, error)
+	FindOrCreateTags(user dao.User, names []string) ([]dao.Tag, error)
+}
+
+type TagRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// FindTagsByUser returns the user's tags by name, each with the number of
+// operations it is set on.
+func (u TagRepositoryImpl) FindTagsByUser(user dao.User) ([]dao.Tag, error) {
+	var tags []dao.Tag
+	err := u.db.Model(&dao.Tag{}).
+		Select("tags.*, COUNT(operations.id) AS operations_count").
+		Joins("LEFT JOIN operation_tags ON operation_tags.tag_id = tags.id").
+		Joins("LEFT JOIN operations ON operations.id = operation_tags.operation_id AND operations.deleted_at IS NULL").
+		Where("tags.user_id = ?", user.ID).
+		Group("tags.id").
+		Order("tags.name").
+		Find(&tags).Error
+	if err != nil {
+		log.Error("Got and error when find tags by user. Error: ", err)
+		return nil, err
+	}
+	return tags, nil
+}
+
+// FindOrCreateTags returns the user's tags with the given names, creating the
+// ones the user does not have yet.
+func (u TagRepositoryImpl) FindOrCreateTags(user dao.User, names []string) ([]dao.Tag, error) {
+	tags := []dao.Tag{}
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		for _, name := range names {
+			tag := dao.Tag{}
+			if err := tx.Where(dao.Tag{UserID: uint(user.ID), Name: name}).FirstOrCreate(&tag).Error; err != nil {
+				return err
+			}
+			tags = append(tags, tag)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("Tags not saved. Error: ", err)
+		return nil, err
+	}
+	return tags, nil
+}
+
+func TagRepositoryInit(db *gorm.DB) *TagRepositoryImpl {
+	db.AutoMigrate(&dao.Tag{})
+	return &TagRepositoryImpl{
+		db: db,
+	}
+}
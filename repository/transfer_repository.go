@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TransferRepository interface {
+	FindTransfersByUser(user dao.User) ([]dao.Transfer, error)
+	FindTransferByUserAndId(user dao.User, transferID int) (dao.Transfer, error)
+	Save(transfer *dao.Transfer) (dao.Transfer, error)
+	Update(transfer *dao.Transfer) (dao.Transfer, error)
+	Delete(transfer *dao.Transfer) (dao.Transfer, error)
+}
+
+type TransferRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func (u TransferRepositoryImpl) FindTransfersByUser(user dao.User) ([]dao.Transfer, error) {
+	var transfers []dao.Transfer
+	err := u.db.Where("user_id = ?", user.ID).Order("date DESC, id DESC").Find(&transfers).Error
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+func (u TransferRepositoryImpl) FindTransferByUserAndId(user dao.User, transferID int) (dao.Transfer, error) {
+	var transfer dao.Transfer
+	err := u.db.Preload("Operations", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Where("user_id = ? AND id = ?", user.ID, transferID).First(&transfer).Error
+	if err != nil {
+		log.Error("Got and error when find transfer by id. Error: ", err)
+		return dao.Transfer{}, err
+	}
+	return transfer, nil
+}
+
+// Save creates the transfer and both of its operations in a single transaction.
+func (u TransferRepositoryImpl) Save(transfer *dao.Transfer) (dao.Transfer, error) {
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Operations").Create(&transfer).Error; err != nil {
+			return err
+		}
+		return saveTransferOperations(tx, transfer)
+	})
+	if err != nil {
+		log.Error("Transfer not saved. Error: ", err)
+	}
+	return *transfer, err
+}
+
+// Update saves the transfer and both of its operations in a single transaction.
+func (u TransferRepositoryImpl) Update(transfer *dao.Transfer) (dao.Transfer, error) {
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Operations").Save(&transfer).Error; err != nil {
+			return err
+		}
+		return saveTransferOperations(tx, transfer)
+	})
+	return *transfer, err
+}
+
+// Delete removes the transfer together with both of its operations. Their
+// attachments are kept until the operations are purged from the trash.
+func (u TransferRepositoryImpl) Delete(transfer *dao.Transfer) (dao.Transfer, error) {
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("transfer_id = ?", transfer.ID).Delete(&dao.Operation{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&transfer).Error
+	})
+	return *transfer, err
+}
+
+func saveTransferOperations(tx *gorm.DB, transfer *dao.Transfer) error {
+	operationRepository := OperationRepositoryImpl{db: tx}
+	for index := range transfer.Operations {
+		operation := &transfer.Operations[index]
+		operation.TransferID = &transfer.ID
+
+		var err error
+		if operation.ID == 0 {
+			_, err = operationRepository.Save(operation)
+		} else {
+			_, err = operationRepository.Update(operation)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TransferRepositoryInit(db *gorm.DB) *TransferRepositoryImpl {
+	migrateMoneyColumns(db, &dao.Transfer{}, "amount", "to_amount")
+	db.AutoMigrate(&dao.Transfer{})
+	return &TransferRepositoryImpl{
+		db: db,
+	}
+}
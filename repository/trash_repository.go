@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TrashRepository interface {
+	FindDeletedOperations(user dao.User) ([]dto.TrashedOperation, error)
+	FindDeletedCategories(user dao.User) ([]dto.TrashedCategory, error)
+	FindDeletedOperationByUserAndId(user dao.User, operationID int) (dao.Operation, error)
+	FindDeletedCategoryByUserAndId(user dao.User, categoryID int) (dao.Category, error)
+	CountDeletedCategoriesByOperation(operation dao.Operation) (int64, error)
+	CountDeletedAccountsByOperation(operation dao.Operation) (int64, error)
+	CountCategoryReferences(category dao.Category) (int64, error)
+	RestoreOperation(operation *dao.Operation) (dao.Operation, error)
+	RestoreCategory(category *dao.Category) (dao.Category, error)
+	PurgeOperation(operation *dao.Operation) (dao.Operation, error)
+	PurgeCategory(category *dao.Category) (dao.Category, error)
+	PurgeDeletedBefore(deletedBefore time.Time) (int64, int64, error)
+}
+
+type TrashRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// FindDeletedOperations returns the user's deleted operations, the latest
+// deleted first. The sides of deleted transfers are left out, they go away
+// together with their transfer.
+func (u TrashRepositoryImpl) FindDeletedOperations(user dao.User) ([]dto.TrashedOperation, error) {
+	operations := []dto.TrashedOperation{}
+	err := u.db.Unscoped().Model(&dao.Operation{}).
+		Select("id, type, amount, currency, date, description, category_id, account_id, deleted_at").
+		Where("user_id = ? AND deleted_at IS NOT NULL AND transfer_id IS NULL", user.ID).
+		Order("deleted_at DESC, id DESC").
+		Scan(&operations).Error
+	if err != nil {
+		log.Error("Got and error when find deleted operations. Error: ", err)
+		return nil, err
+	}
+	return operations, nil
+}
+
+// FindDeletedCategories returns the user's deleted categories, the latest
+// deleted first.
+func (u TrashRepositoryImpl) FindDeletedCategories(user dao.User) ([]dto.TrashedCategory, error) {
+	categories := []dto.TrashedCategory{}
+	err := u.db.Unscoped().Model(&dao.Category{}).
+		Select("id, name, color, description, deleted_at").
+		Where("user_id = ? AND deleted_at IS NOT NULL", user.ID).
+		Order("deleted_at DESC, id DESC").
+		Scan(&categories).Error
+	if err != nil {
+		log.Error("Got and error when find deleted categories. Error: ", err)
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (u TrashRepositoryImpl) FindDeletedOperationByUserAndId(user dao.User, operationID int) (dao.Operation, error) {
+	var operation dao.Operation
+	err := u.db.Unscoped().
+		Where("user_id = ? AND id = ? AND deleted_at IS NOT NULL AND transfer_id IS NULL", user.ID, operationID).
+		First(&operation).Error
+	if err != nil {
+		log.Error("Got and error when find deleted operation by id. Error: ", err)
+		return dao.Operation{}, err
+	}
+	return operation, nil
+}
+
+func (u TrashRepositoryImpl) FindDeletedCategoryByUserAndId(user dao.User, categoryID int) (dao.Category, error) {
+	var category dao.Category
+	err := u.db.Unscoped().
+		Where("user_id = ? AND id = ? AND deleted_at IS NOT NULL", user.ID, categoryID).
+		First(&category).Error
+	if err != nil {
+		log.Error("Got and error when find deleted category by id. Error: ", err)
+		return dao.Category{}, err
+	}
+	return category, nil
+}
+
+// CountDeletedCategoriesByOperation counts the deleted categories the
+// operation or its splits are set on.
+func (u TrashRepositoryImpl) CountDeletedCategoriesByOperation(operation dao.Operation) (int64, error) {
+	var count int64
+	splitCategories := u.db.Model(&dao.OperationSplit{}).Select("category_id").Where("operation_id = ?", operation.ID)
+	err := u.db.Unscoped().Model(&dao.Category{}).
+		Where("deleted_at IS NOT NULL").
+		Where("id = ? OR id IN (?)", operation.CategoryID, splitCategories).
+		Count(&count).Error
+	return count, err
+}
+
+// CountDeletedAccountsByOperation counts the deleted accounts the operation is
+// set on.
+func (u TrashRepositoryImpl) CountDeletedAccountsByOperation(operation dao.Operation) (int64, error) {
+	var count int64
+	if operation.AccountID == nil {
+		return 0, nil
+	}
+	err := u.db.Unscoped().Model(&dao.Account{}).
+		Where("deleted_at IS NOT NULL AND id = ?", *operation.AccountID).
+		Count(&count).Error
+	return count, err
+}
+
+// CountCategoryReferences counts the operations, splits and recurring
+// operations set on the category, deleted ones included.
+func (u TrashRepositoryImpl) CountCategoryReferences(category dao.Category) (int64, error) {
+	var count int64
+	err := u.db.Raw(`SELECT (SELECT COUNT(*) FROM operations WHERE category_id = @id)
+		+ (SELECT COUNT(*) FROM operation_splits WHERE category_id = @id)
+		+ (SELECT COUNT(*) FROM recurring_operations WHERE category_id = @id)`,
+		map[string]interface{}{"id": category.ID}).Scan(&count).Error
+	return count, err
+}
+
+func (u TrashRepositoryImpl) RestoreOperation(operation *dao.Operation) (dao.Operation, error) {
+	err := u.db.Unscoped().Model(&dao.Operation{}).Where("id = ?", operation.ID).Update("deleted_at", nil).Error
+	return *operation, err
+}
+
+func (u TrashRepositoryImpl) RestoreCategory(category *dao.Category) (dao.Category, error) {
+	err := u.db.Unscoped().Model(&dao.Category{}).Where("id = ?", category.ID).Update("deleted_at", nil).Error
+	return *category, err
+}
+
+// PurgeOperation removes the operation for good together with its splits and
+// tags. Its attachments are left to the attachment cleaner.
+func (u TrashRepositoryImpl) PurgeOperation(operation *dao.Operation) (dao.Operation, error) {
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		return purgeOperations(tx, []int{operation.ID})
+	})
+	return *operation, err
+}
+
+func (u TrashRepositoryImpl) PurgeCategory(category *dao.Category) (dao.Category, error) {
+	err := u.db.Unscoped().Where("id = ?", category.ID).Delete(&dao.Category{}).Error
+	return *category, err
+}
+
+// PurgeDeletedBefore removes for good the operations, transfers and categories
+// deleted before the given time, returning how many operations and categories
+// were removed. Categories still set on an operation are kept.
+func (u TrashRepositoryImpl) PurgeDeletedBefore(deletedBefore time.Time) (int64, int64, error) {
+	var purgedOperations, purgedCategories int64
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		deletedOperations := tx.Session(&gorm.Session{NewDB: true}).Unscoped().Model(&dao.Operation{}).
+			Select("id").Where("deleted_at < ?", deletedBefore)
+		if err := tx.Unscoped().Model(&dao.Operation{}).Where("deleted_at < ?", deletedBefore).Count(&purgedOperations).Error; err != nil {
+			return err
+		}
+		if err := purgeOperations(tx, deletedOperations); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().
+			Where("deleted_at < ? AND id NOT IN (SELECT transfer_id FROM operations WHERE transfer_id IS NOT NULL)", deletedBefore).
+			Delete(&dao.Transfer{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().
+			Where("deleted_at < ?", deletedBefore).
+			Where("id NOT IN (SELECT category_id FROM operations WHERE category_id IS NOT NULL)").
+			Where("id NOT IN (SELECT category_id FROM operation_splits)").
+			Where("id NOT IN (SELECT category_id FROM recurring_operations)").
+			Delete(&dao.Category{})
+		purgedCategories = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		log.Error("Got and error when purge deleted records. Error: ", err)
+		return 0, 0, err
+	}
+	return purgedOperations, purgedCategories, nil
+}
+
+// purgeOperations removes for good the given operations, a slice of IDs or a
+// subquery, with their splits and tags, and marks their attachments as
+// orphaned so the cleaner removes their files.
+func purgeOperations(tx *gorm.DB, operationIDs interface{}) error {
+	if err := orphanOperationAttachments(tx, operationIDs); err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM operation_tags WHERE operation_id IN (?)", operationIDs).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("operation_id IN (?)", operationIDs).Delete(&dao.OperationSplit{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN (?)", operationIDs).Delete(&dao.Operation{}).Error
+}
+
+func TrashRepositoryInit(db *gorm.DB) *TrashRepositoryImpl {
+	return &TrashRepositoryImpl{
+		db: db,
+	}
+}
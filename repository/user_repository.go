@@ -13,6 +13,7 @@ type UserRepository interface {
 	FindUserByEmail(email string) (dao.User, error)
 	FindUserById(id int) (dao.User, error)
 	Save(user *dao.User) (dao.User, error)
+	UpdateBaseCurrency(user dao.User, baseCurrency string) error
 }
 
 type UserRepositoryImpl struct {
@@ -51,6 +52,14 @@ func (u UserRepositoryImpl) Save(user *dao.User) (dao.User, error) {
 	return *user, nil
 }
 
+func (u UserRepositoryImpl) UpdateBaseCurrency(user dao.User, baseCurrency string) error {
+	err := u.db.Model(&dao.User{}).Where("id = ?", user.ID).UpdateColumn("base_currency", baseCurrency).Error
+	if err != nil {
+		log.Error("Base currency not updated. Error: ", err)
+	}
+	return err
+}
+
 func ProcessError(err error) error {
 	pgErrCode := err.(*pgconn.PgError).Code
 	processedError := err
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/repository"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountService interface {
+	Index(user dao.User) (int, []dto.TransformedAccount)
+	Show(user dao.User, accountID int) (int, interface{})
+	Create(user dao.User, accountRequest dto.AccountRequest) (int, interface{})
+	Update(user dao.User, accountRequest dto.AccountRequest, accountID int) (int, interface{})
+	Delete(user dao.User, accountID int) (int, interface{})
+}
+
+type AccountServiceImpl struct {
+	accountRepository repository.AccountRepository
+}
+
+func (u AccountServiceImpl) Index(user dao.User) (int, []dto.TransformedAccount) {
+	accounts, _ := u.accountRepository.FindAccountsByUser(user)
+	transformedResponse := []dto.TransformedAccount{}
+	for _, account := range accounts {
+		transformedResponse = append(transformedResponse, transformAccount(account))
+	}
+
+	return http.StatusOK, transformedResponse
+}
+
+func (u AccountServiceImpl) Show(user dao.User, accountID int) (int, interface{}) {
+	account, recordError := u.accountRepository.FindAccountByUserAndId(user, accountID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	return http.StatusOK, transformAccount(account)
+}
+
+func (u AccountServiceImpl) Create(user dao.User, accountRequest dto.AccountRequest) (int, interface{}) {
+	accounts, _ := u.accountRepository.FindAccountsByUser(user)
+
+	accountDao := dao.Account{
+		UserID:         uint(user.ID),
+		Name:           accountRequest.Name,
+		Type:           accountRequest.Type,
+		Currency:       operationCurrency(user, accountRequest.Currency),
+		OpeningBalance: accountRequest.OpeningBalance,
+		IsDefault:      accountRequest.IsDefault || len(accounts) == 0,
+	}
+
+	_, recordError := u.accountRepository.Save(&accountDao)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the account."}
+	}
+
+	return http.StatusCreated, gin.H{"message": "Account successfully created."}
+}
+
+func (u AccountServiceImpl) Update(user dao.User, accountRequest dto.AccountRequest, accountID int) (int, interface{}) {
+	account, recordError := u.accountRepository.FindAccountByUserAndId(user, accountID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	account.Name = accountRequest.Name
+	account.Type = accountRequest.Type
+	if accountRequest.Currency != "" {
+		account.Currency = strings.ToUpper(accountRequest.Currency)
+	}
+	account.OpeningBalance = accountRequest.OpeningBalance
+	// The default flag can only move to another account, never be cleared.
+	account.IsDefault = account.IsDefault || accountRequest.IsDefault
+
+	_, recordError = u.accountRepository.Update(&account)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the account."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Account successfully updated."}
+}
+
+func (u AccountServiceImpl) Delete(user dao.User, accountID int) (int, interface{}) {
+	account, recordError := u.accountRepository.FindAccountByUserAndId(user, accountID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	if account.IsDefault {
+		return http.StatusUnprocessableEntity, gin.H{"error": "The default account cannot be deleted."}
+	}
+
+	operationsCount, recordError := u.accountRepository.CountOperations(account)
+	if recordError != nil || operationsCount > 0 {
+		return http.StatusUnprocessableEntity, gin.H{"error": "The account still has operations."}
+	}
+
+	_, recordError = u.accountRepository.Delete(&account)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while deleting the account."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Account successfully deleted."}
+}
+
+func transformAccount(account dao.Account) dto.TransformedAccount {
+	return dto.TransformedAccount{
+		ID:             account.ID,
+		Name:           account.Name,
+		Type:           account.Type,
+		Currency:       account.Currency,
+		OpeningBalance: account.OpeningBalance,
+		IsDefault:      account.IsDefault,
+	}
+}
+
+func AccountServiceInit(accountRepository repository.AccountRepository) *AccountServiceImpl {
+	return &AccountServiceImpl{
+		accountRepository: accountRepository,
+	}
+}
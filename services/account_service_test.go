@@ -0,0 +1,224 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type MockAccountRepository struct{}
+
+func (u MockAccountRepository) FindAccountsByUser(user dao.User) ([]dao.Account, error) {
+	if user.ID == 3 {
+		return []dao.Account{
+			{ID: 1, Name: "Wallet", Type: "cash", Currency: "ARS", OpeningBalance: money.FromFloat(500), IsDefault: true},
+			{ID: 2, Name: "Dollars", Type: "bank", Currency: "USD"},
+		}, nil
+	}
+	return []dao.Account{}, nil
+}
+
+func (u MockAccountRepository) FindAccountByUserAndId(user dao.User, accountID int) (dao.Account, error) {
+	switch accountID {
+	case 1:
+		return dao.Account{ID: 1, Name: "Wallet", Type: "cash", Currency: "ARS", OpeningBalance: money.FromFloat(500), IsDefault: true}, nil
+	case 2:
+		return dao.Account{ID: 2, Name: "Dollars", Type: "bank", Currency: "USD"}, nil
+	case 3:
+		return dao.Account{ID: 3, Name: "Old card", Type: "credit_card", Currency: "ARS"}, nil
+	case 4:
+		return dao.Account{ID: 4, Name: "Savings", Type: "savings", Currency: "ARS"}, nil
+	}
+	return dao.Account{}, errors.New("Account not found.")
+}
+
+func (u MockAccountRepository) FindDefaultAccount(user dao.User) (dao.Account, error) {
+	return u.FindAccountByUserAndId(user, 1)
+}
+
+func (u MockAccountRepository) CountOperations(account dao.Account) (int64, error) {
+	if account.ID == 4 {
+		return 2, nil
+	}
+	return 0, nil
+}
+
+func (u MockAccountRepository) Save(account *dao.Account) (dao.Account, error) {
+	if account.Name == "Invalid" {
+		return dao.Account{}, errors.New("Invalid account.")
+	}
+	return *account, nil
+}
+
+func (u MockAccountRepository) Update(account *dao.Account) (dao.Account, error) {
+	if account.ID == 3 {
+		return dao.Account{}, errors.New("Invalid account.")
+	}
+	return *account, nil
+}
+
+func (u MockAccountRepository) Delete(account *dao.Account) (dao.Account, error) {
+	if account.ID == 3 {
+		return dao.Account{}, errors.New("Invalid account.")
+	}
+	return *account, nil
+}
+
+func TestAccountServiceImpl_Index(t *testing.T) {
+	accountService := AccountServiceInit(&MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the user has accounts",
+			Params:       dao.User{ID: 3},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"name\":\"Wallet\",\"type\":\"cash\",\"currency\":\"ARS\",\"opening_balance\":\"500.00\",\"is_default\":true},{\"id\":2,\"name\":\"Dollars\",\"type\":\"bank\",\"currency\":\"USD\",\"opening_balance\":\"0.00\",\"is_default\":false}]",
+		},
+		{
+			Name:         "when the user has no accounts",
+			Params:       dao.User{ID: 2},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := accountService.Index(tt.Params.(dao.User))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestAccountServiceImpl_Show(t *testing.T) {
+	accountService := AccountServiceInit(&MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the account is found",
+			Params:       2,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":2,\"name\":\"Dollars\",\"type\":\"bank\",\"currency\":\"USD\",\"opening_balance\":\"0.00\",\"is_default\":false}",
+		},
+		{
+			Name:         "when the account is not found",
+			Params:       9,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := accountService.Show(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestAccountServiceImpl_Create(t *testing.T) {
+	accountService := AccountServiceInit(&MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the account is created successfully",
+			Params:       dto.AccountRequest{Name: "Bank", Type: "bank", Currency: "usd", OpeningBalance: money.FromFloat(1500)},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Account successfully created.\"}",
+		},
+		{
+			Name:         "when there is an error in the creation of the account",
+			Params:       dto.AccountRequest{Name: "Invalid", Type: "cash"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the creation of the account.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := accountService.Create(dao.User{ID: 1}, tt.Params.(dto.AccountRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestAccountServiceImpl_Update(t *testing.T) {
+	accountService := AccountServiceInit(&MockAccountRepository{})
+	request := dto.AccountRequest{Name: "Pocket", Type: "cash", OpeningBalance: money.FromFloat(100)}
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the account is updated successfully",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Account successfully updated.\"}",
+		},
+		{
+			Name:         "when the account is not found",
+			Params:       9,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error in the update of the account",
+			Params:       3,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the account.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := accountService.Update(dao.User{ID: 1}, request, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestAccountServiceImpl_Delete(t *testing.T) {
+	accountService := AccountServiceInit(&MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the account is deleted successfully",
+			Params:       2,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Account successfully deleted.\"}",
+		},
+		{
+			Name:         "when the account is not found",
+			Params:       9,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the account is the default one",
+			Params:       1,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The default account cannot be deleted.\"}",
+		},
+		{
+			Name:         "when the account still has operations",
+			Params:       4,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The account still has operations.\"}",
+		},
+		{
+			Name:         "when there is an error while deleting the account",
+			Params:       3,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while deleting the account.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := accountService.Delete(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/repository"
+	"GoGin-API-CuentasClaras/storage"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const DEFAULT_ATTACHMENT_CLEANER_INTERVAL time.Duration = time.Hour
+
+// ATTACHMENT_CLEANER_BATCH bounds how many orphaned files a single run removes,
+// the remainder is picked up on the next tick.
+const ATTACHMENT_CLEANER_BATCH int = 500
+
+type AttachmentCleaner interface {
+	Start()
+	CleanOrphans() int
+}
+
+type AttachmentCleanerImpl struct {
+	attachmentRepository repository.AttachmentRepository
+	storage              storage.Storage
+	interval             time.Duration
+}
+
+func (u AttachmentCleanerImpl) Start() {
+	go func() {
+		u.CleanOrphans()
+		ticker := time.NewTicker(u.interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			u.CleanOrphans()
+		}
+	}()
+	log.Info("Attachment cleaner started with interval ", u.interval)
+}
+
+// CleanOrphans removes the files of the attachments left behind by deleted
+// operations. Attachments whose file could not be removed stay orphaned.
+func (u AttachmentCleanerImpl) CleanOrphans() int {
+	attachments, err := u.attachmentRepository.FindOrphanedAttachments(ATTACHMENT_CLEANER_BATCH)
+	if err != nil {
+		log.Error("Got and error when find orphaned attachments. Error: ", err)
+		return 0
+	}
+
+	total := 0
+	for _, attachment := range attachments {
+		if err := u.storage.Delete(attachment.StorageKey); err != nil {
+			log.Error("Attachment file not deleted. Error: ", err)
+			continue
+		}
+		if _, err := u.attachmentRepository.Delete(&attachment); err != nil {
+			continue
+		}
+		total++
+	}
+	if total > 0 {
+		log.Info("Attachment cleaner removed ", total, " orphaned files")
+	}
+	return total
+}
+
+func AttachmentCleanerInit(attachmentRepository repository.AttachmentRepository, storage storage.Storage) *AttachmentCleanerImpl {
+	interval, err := time.ParseDuration(os.Getenv("ATTACHMENT_CLEANER_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = DEFAULT_ATTACHMENT_CLEANER_INTERVAL
+	}
+	return &AttachmentCleanerImpl{
+		attachmentRepository: attachmentRepository,
+		storage:              storage,
+		interval:             interval,
+	}
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/repository"
+	"GoGin-API-CuentasClaras/storage"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// MAX_ATTACHMENT_SIZE is the largest file, in bytes, accepted as an attachment.
+const MAX_ATTACHMENT_SIZE int64 = 10 << 20
+
+const MAX_ATTACHMENT_NAME_LENGTH int = 255
+
+// allowedAttachmentTypes are the MIME types, as sniffed from the content,
+// accepted for receipts.
+var allowedAttachmentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/gif":       true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+}
+
+type AttachmentService interface {
+	Index(user dao.User, operationID int) (int, interface{})
+	Create(user dao.User, operationID int, fileName string, file io.Reader) (int, interface{})
+	Download(user dao.User, operationID int, attachmentID int) (int, interface{}, io.ReadCloser)
+	Delete(user dao.User, operationID int, attachmentID int) (int, interface{})
+}
+
+type AttachmentServiceImpl struct {
+	attachmentRepository repository.AttachmentRepository
+	operationRepository  repository.OperationRepository
+	storage              storage.Storage
+}
+
+func (u AttachmentServiceImpl) Index(user dao.User, operationID int) (int, interface{}) {
+	operation, recordError := u.operationRepository.FindOperationByUserAndId(user, operationID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	attachments, _ := u.attachmentRepository.FindAttachmentsByOperation(operation)
+	transformedResponse := []dto.TransformedAttachment{}
+	for _, attachment := range attachments {
+		transformedResponse = append(transformedResponse, transformAttachment(attachment))
+	}
+
+	return http.StatusOK, transformedResponse
+}
+
+// Create stores the file behind the operation. The type is sniffed from the
+// content, the name sent by the client is only kept for downloads.
+func (u AttachmentServiceImpl) Create(user dao.User, operationID int, fileName string, file io.Reader) (int, interface{}) {
+	operation, recordError := u.operationRepository.FindOperationByUserAndId(user, operationID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	content, readError := io.ReadAll(io.LimitReader(file, MAX_ATTACHMENT_SIZE+1))
+	if readError != nil || len(content) == 0 {
+		return http.StatusBadRequest, gin.H{"error": "Invalid parameters."}
+	}
+	if int64(len(content)) > MAX_ATTACHMENT_SIZE {
+		return http.StatusRequestEntityTooLarge, gin.H{"error": "The file exceeds the maximum size."}
+	}
+	contentType := strings.Split(http.DetectContentType(content), ";")[0]
+	if !allowedAttachmentTypes[contentType] {
+		return http.StatusUnsupportedMediaType, gin.H{"error": "Unsupported file type."}
+	}
+
+	attachmentDao := dao.Attachment{
+		UserID:      uint(user.ID),
+		OperationID: operation.ID,
+		FileName:    attachmentFileName(fileName),
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		StorageKey:  attachmentStorageKey(user, operation),
+	}
+	if _, storageError := u.storage.Save(attachmentDao.StorageKey, bytes.NewReader(content)); storageError != nil {
+		log.Error("Attachment file not saved. Error: ", storageError)
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the attachment."}
+	}
+
+	_, recordError = u.attachmentRepository.Save(&attachmentDao)
+	if recordError != nil {
+		u.storage.Delete(attachmentDao.StorageKey)
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the attachment."}
+	}
+
+	return http.StatusCreated, gin.H{"message": "Attachment successfully created."}
+}
+
+// Download returns the attachment together with its opened content, the caller
+// must close it.
+func (u AttachmentServiceImpl) Download(user dao.User, operationID int, attachmentID int) (int, interface{}, io.ReadCloser) {
+	attachment, recordError := u.findAttachment(user, operationID, attachmentID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}, nil
+	}
+
+	content, storageError := u.storage.Open(attachment.StorageKey)
+	if storageError != nil {
+		log.Error("Got and error when open attachment file. Error: ", storageError)
+		return http.StatusNotFound, gin.H{"error": "Not found."}, nil
+	}
+
+	return http.StatusOK, transformAttachment(attachment), content
+}
+
+func (u AttachmentServiceImpl) Delete(user dao.User, operationID int, attachmentID int) (int, interface{}) {
+	attachment, recordError := u.findAttachment(user, operationID, attachmentID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	_, recordError = u.attachmentRepository.Delete(&attachment)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while deleting the attachment."}
+	}
+	if storageError := u.storage.Delete(attachment.StorageKey); storageError != nil {
+		log.Error("Attachment file not deleted. Error: ", storageError)
+	}
+
+	return http.StatusOK, gin.H{"message": "Attachment successfully deleted."}
+}
+
+func (u AttachmentServiceImpl) findAttachment(user dao.User, operationID int, attachmentID int) (dao.Attachment, error) {
+	operation, recordError := u.operationRepository.FindOperationByUserAndId(user, operationID)
+	if recordError != nil {
+		return dao.Attachment{}, recordError
+	}
+	return u.attachmentRepository.FindAttachmentByOperationAndId(operation, attachmentID)
+}
+
+func attachmentFileName(fileName string) string {
+	name := strings.TrimSpace(filepath.Base(strings.ReplaceAll(fileName, `\`, "/")))
+	if name == "" || name == "." || name == "/" {
+		name = "receipt"
+	}
+	if len(name) > MAX_ATTACHMENT_NAME_LENGTH {
+		name = name[len(name)-MAX_ATTACHMENT_NAME_LENGTH:]
+	}
+	return name
+}
+
+func attachmentStorageKey(user dao.User, operation dao.Operation) string {
+	randomBytes := make([]byte, 16)
+	rand.Read(randomBytes)
+	return fmt.Sprintf("%d/%d/%s", user.ID, operation.ID, hex.EncodeToString(randomBytes))
+}
+
+func transformAttachment(attachment dao.Attachment) dto.TransformedAttachment {
+	return dto.TransformedAttachment{
+		ID:          attachment.ID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+	}
+}
+
+func AttachmentServiceInit(attachmentRepository repository.AttachmentRepository, operationRepository repository.OperationRepository, storage storage.Storage) *AttachmentServiceImpl {
+	return &AttachmentServiceImpl{
+		attachmentRepository: attachmentRepository,
+		operationRepository:  operationRepository,
+		storage:              storage,
+	}
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pdfContent = []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n")
+
+type MockAttachmentRepository struct{}
+
+func (u MockAttachmentRepository) FindAttachmentsByOperation(operation dao.Operation) ([]dao.Attachment, error) {
+	if operation.ID == 1 {
+		attachment, _ := u.FindAttachmentByOperationAndId(operation, 1)
+		return []dao.Attachment{attachment}, nil
+	}
+	return []dao.Attachment{}, nil
+}
+
+func (u MockAttachmentRepository) FindAttachmentByOperationAndId(operation dao.Operation, attachmentID int) (dao.Attachment, error) {
+	if operation.ID == 1 && (attachmentID == 1 || attachmentID == 3) {
+		return dao.Attachment{
+			ID:          attachmentID,
+			OperationID: operation.ID,
+			FileName:    "receipt.pdf",
+			ContentType: "application/pdf",
+			Size:        int64(len(pdfContent)),
+			StorageKey:  "1/1/receipt",
+		}, nil
+	}
+	return dao.Attachment{}, errors.New("Attachment not found.")
+}
+
+func (u MockAttachmentRepository) FindOrphanedAttachments(limit int) ([]dao.Attachment, error) {
+	return []dao.Attachment{
+		{ID: 4, StorageKey: "1/2/orphan"},
+		{ID: 5, StorageKey: "1/2/locked"},
+	}, nil
+}
+
+func (u MockAttachmentRepository) Save(attachment *dao.Attachment) (dao.Attachment, error) {
+	if attachment.FileName == "invalid.pdf" {
+		return dao.Attachment{}, errors.New("Invalid attachment.")
+	}
+	return *attachment, nil
+}
+
+func (u MockAttachmentRepository) Delete(attachment *dao.Attachment) (dao.Attachment, error) {
+	if attachment.ID == 3 {
+		return dao.Attachment{}, errors.New("Invalid attachment.")
+	}
+	return *attachment, nil
+}
+
+type MockStorage struct {
+	files map[string][]byte
+}
+
+func (u MockStorage) Save(key string, content io.Reader) (int64, error) {
+	data, err := io.ReadAll(content)
+	u.files[key] = data
+	return int64(len(data)), err
+}
+
+func (u MockStorage) Open(key string) (io.ReadCloser, error) {
+	data, ok := u.files[key]
+	if !ok {
+		return nil, errors.New("File not found.")
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (u MockStorage) Delete(key string) error {
+	if key == "1/2/locked" {
+		return errors.New("File locked.")
+	}
+	delete(u.files, key)
+	return nil
+}
+
+func TestAttachmentServiceImpl_Index(t *testing.T) {
+	attachmentService := AttachmentServiceInit(MockAttachmentRepository{}, MockOperationRepositoryOperations{}, MockStorage{files: map[string][]byte{}})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the operation has attachments",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"file_name\":\"receipt.pdf\",\"content_type\":\"application/pdf\",\"size\":29}]",
+		},
+		{
+			Name:         "when the operation is not found",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := attachmentService.Index(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestAttachmentServiceImpl_Create(t *testing.T) {
+	storage := MockStorage{files: map[string][]byte{}}
+	attachmentService := AttachmentServiceInit(MockAttachmentRepository{}, MockOperationRepositoryOperations{}, storage)
+
+	type createParams struct {
+		operationID int
+		fileName    string
+		content     []byte
+	}
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the attachment is created successfully",
+			Params:       createParams{1, "receipt.pdf", pdfContent},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Attachment successfully created.\"}",
+		},
+		{
+			Name:         "when the operation is not found",
+			Params:       createParams{2, "receipt.pdf", pdfContent},
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the file is empty",
+			Params:       createParams{1, "receipt.pdf", []byte{}},
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name:         "when the file exceeds the maximum size",
+			Params:       createParams{1, "receipt.pdf", append(pdfContent, make([]byte, MAX_ATTACHMENT_SIZE)...)},
+			ExpectedCode: http.StatusRequestEntityTooLarge,
+			ExpectedBody: "{\"error\":\"The file exceeds the maximum size.\"}",
+		},
+		{
+			Name:         "when the file type is not allowed",
+			Params:       createParams{1, "receipt.pdf", []byte("plain text pretending to be a receipt")},
+			ExpectedCode: http.StatusUnsupportedMediaType,
+			ExpectedBody: "{\"error\":\"Unsupported file type.\"}",
+		},
+		{
+			Name:         "when there is an error in the creation of the attachment",
+			Params:       createParams{1, "invalid.pdf", pdfContent},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the creation of the attachment.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			params := tt.Params.(createParams)
+			code, response := attachmentService.Create(dao.User{ID: 1}, params.operationID, params.fileName, bytes.NewReader(params.content))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+	assert.Len(t, storage.files, 1)
+}
+
+func TestAttachmentServiceImpl_Download(t *testing.T) {
+	storage := MockStorage{files: map[string][]byte{"1/1/receipt": pdfContent}}
+	attachmentService := AttachmentServiceInit(MockAttachmentRepository{}, MockOperationRepositoryOperations{}, storage)
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the attachment is found",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"file_name\":\"receipt.pdf\",\"content_type\":\"application/pdf\",\"size\":29}",
+		},
+		{
+			Name:         "when the attachment is not found",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response, content := attachmentService.Download(dao.User{ID: 1}, 1, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+			if code == http.StatusOK {
+				data, _ := io.ReadAll(content)
+				assert.Equal(t, pdfContent, data)
+			} else {
+				assert.Nil(t, content)
+			}
+		})
+	}
+}
+
+func TestAttachmentServiceImpl_Delete(t *testing.T) {
+	storage := MockStorage{files: map[string][]byte{"1/1/receipt": pdfContent}}
+	attachmentService := AttachmentServiceInit(MockAttachmentRepository{}, MockOperationRepositoryOperations{}, storage)
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the attachment is not found",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error in the deletion of the attachment",
+			Params:       3,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while deleting the attachment.\"}",
+		},
+		{
+			Name:         "when the attachment is deleted successfully",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Attachment successfully deleted.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := attachmentService.Delete(dao.User{ID: 1}, 1, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+	assert.Empty(t, storage.files)
+}
+
+func TestAttachmentCleanerImpl_CleanOrphans(t *testing.T) {
+	storage := MockStorage{files: map[string][]byte{"1/2/orphan": pdfContent, "1/2/locked": pdfContent}}
+	attachmentCleaner := AttachmentCleanerInit(MockAttachmentRepository{}, storage)
+
+	assert.Equal(t, 1, attachmentCleaner.CleanOrphans())
+	assert.Equal(t, []string{"1/2/locked"}, mapKeys(storage.files))
+}
+
+func TestAttachmentFileName(t *testing.T) {
+	assert.Equal(t, "receipt.pdf", attachmentFileName("receipt.pdf"))
+	assert.Equal(t, "passwd", attachmentFileName("../../etc/passwd"))
+	assert.Equal(t, "ticket.jpg", attachmentFileName(`C:\Users\me\ticket.jpg`))
+	assert.Equal(t, "receipt", attachmentFileName(""))
+	assert.Equal(t, MAX_ATTACHMENT_NAME_LENGTH, len(attachmentFileName(strings.Repeat("a", 300)+".pdf")))
+}
+
+func mapKeys(files map[string][]byte) []string {
+	keys := []string{}
+	for key := range files {
+		keys = append(keys, key)
+	}
+	return keys
+}
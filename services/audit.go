@@ -0,0 +1,119 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// newAuditEntry records a change of one of the user's records made by the
+// user. The versions before and after the change are nil when the record was
+// created or deleted.
+func newAuditEntry(user dao.User, recordType string, recordID int, action string, before interface{}, after interface{}) dao.AuditEntry {
+	return dao.AuditEntry{
+		UserID:        uint(user.ID),
+		ActorID:       uint(user.ID),
+		ActorUsername: user.Username,
+		RecordType:    recordType,
+		RecordID:      recordID,
+		Action:        action,
+		Before:        auditVersion(before),
+		After:         auditVersion(after),
+	}
+}
+
+func auditVersion(version interface{}) *string {
+	if version == nil || reflect.ValueOf(version).IsNil() {
+		return nil
+	}
+	versionJSON, _ := json.Marshal(version)
+	encoded := string(versionJSON)
+	return &encoded
+}
+
+func operationSnapshot(operation dao.Operation) *dto.OperationSnapshot {
+	categoryID := operation.CategoryID
+	if categoryID == nil && operation.Category.ID != 0 {
+		categoryID = &operation.Category.ID
+	}
+	snapshot := dto.OperationSnapshot{
+		Type:        operation.Type,
+		Amount:      operation.Amount,
+		Currency:    operation.Currency,
+		Date:        operation.Date.In(utcLocation),
+		Description: operation.Description,
+		CategoryID:  categoryID,
+		AccountID:   operation.AccountID,
+		Splits:      []dto.SplitSnapshot{},
+		Tags:        tagNames(operation.Tags),
+		Status:      operation.Status,
+		PayeeID:     operation.PayeeID,
+	}
+	for _, split := range operation.Splits {
+		snapshot.Splits = append(snapshot.Splits, dto.SplitSnapshot{
+			CategoryID: split.CategoryID,
+			Amount:     split.Amount,
+			Note:       split.Note,
+		})
+	}
+	sort.Strings(snapshot.Tags)
+	return &snapshot
+}
+
+func categorySnapshot(category dao.Category) *dto.CategorySnapshot {
+	return &dto.CategorySnapshot{
+		Name:        category.Name,
+		Color:       category.Color,
+		Description: category.Description,
+	}
+}
+
+// formatAuditEntries lists the entries with the fields each one changed.
+func formatAuditEntries(entries []dao.AuditEntry) []dto.TransformedAuditEntry {
+	transformedEntries := []dto.TransformedAuditEntry{}
+	for _, entry := range entries {
+		transformedEntries = append(transformedEntries, dto.TransformedAuditEntry{
+			ID:        entry.ID,
+			Action:    entry.Action,
+			ActorID:   entry.ActorID,
+			Actor:     entry.ActorUsername,
+			CreatedAt: entry.CreatedAt.In(utcLocation),
+			Changes:   auditChanges(entry),
+		})
+	}
+	return transformedEntries
+}
+
+// auditChanges compares the versions of an entry field by field, in the
+// order of the field names.
+func auditChanges(entry dao.AuditEntry) []dto.AuditChange {
+	before, after := decodeAuditVersion(entry.Before), decodeAuditVersion(entry.After)
+	fields := []string{}
+	for field := range before {
+		fields = append(fields, field)
+	}
+	for field := range after {
+		if _, found := before[field]; !found {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := []dto.AuditChange{}
+	for _, field := range fields {
+		if !reflect.DeepEqual(before[field], after[field]) {
+			changes = append(changes, dto.AuditChange{Field: field, Before: before[field], After: after[field]})
+		}
+	}
+	return changes
+}
+
+func decodeAuditVersion(version *string) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if version != nil {
+		json.Unmarshal([]byte(*version), &fields)
+	}
+	return fields
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var auditCreatedAt = time.Date(2023, 10, 24, 10, 0, 0, 0, time.UTC)
+
+type MockAuditRepository struct{}
+
+func (u MockAuditRepository) SaveAll(entries []dao.AuditEntry) ([]dao.AuditEntry, error) {
+	return entries, nil
+}
+
+func (u MockAuditRepository) FindAuditEntriesByRecord(user dao.User, recordType string, recordID int) ([]dao.AuditEntry, error) {
+	if recordID == 3 {
+		return nil, errors.New("Database error.")
+	}
+	if recordID != 1 {
+		return []dao.AuditEntry{}, nil
+	}
+	entries := []dao.AuditEntry{}
+	for _, entryID := range []int{3, 1} {
+		entry, _ := u.FindAuditEntryByRecordAndId(user, recordType, recordID, entryID)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (u MockAuditRepository) FindAuditEntryByRecordAndId(user dao.User, recordType string, recordID int, entryID int) (dao.AuditEntry, error) {
+	var created, updated interface{}
+	if recordType == dto.AuditRecordOperation {
+		categoryID, accountID := 1, 1
+		createdOperation := dto.OperationSnapshot{Type: "income", Amount: money.FromFloat(1200.5), Currency: "ARS", Date: auditCreatedAt, Description: "Salario", CategoryID: &categoryID, AccountID: &accountID, Splits: []dto.SplitSnapshot{}, Tags: []string{}}
+		updatedOperation := createdOperation
+		updatedOperation.Amount = money.FromFloat(1500)
+		created, updated = &createdOperation, &updatedOperation
+	} else {
+		created = &dto.CategorySnapshot{Name: "Work", Color: "#fdg123", Description: "Work"}
+		updated = &dto.CategorySnapshot{Name: "Job", Color: "#fdg123", Description: "Work"}
+	}
+
+	user = dao.User{ID: 1, Username: "pedro.fuentes"}
+	switch entryID {
+	case 1:
+		entry := newAuditEntry(user, recordType, recordID, dto.AuditActionCreate, nil, created)
+		entry.ID, entry.CreatedAt = 1, auditCreatedAt
+		return entry, nil
+	case 2:
+		entry := newAuditEntry(user, recordType, recordID, dto.AuditActionDelete, updated, nil)
+		entry.ID, entry.CreatedAt = 2, auditCreatedAt
+		return entry, nil
+	case 3:
+		entry := newAuditEntry(user, recordType, recordID, dto.AuditActionUpdate, created, updated)
+		entry.ID, entry.CreatedAt = 3, auditCreatedAt
+		return entry, nil
+	}
+	return dao.AuditEntry{}, errors.New("Audit entry not found.")
+}
+
+func TestOperationServiceImpl_History(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the operation has changes",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":3,\"action\":\"update\",\"actor_id\":1,\"actor\":\"pedro.fuentes\",\"created_at\":\"2023-10-24T10:00:00Z\",\"changes\":[{\"field\":\"amount\",\"before\":\"1200.50\",\"after\":\"1500.00\"}]}," +
+				"{\"id\":1,\"action\":\"create\",\"actor_id\":1,\"actor\":\"pedro.fuentes\",\"created_at\":\"2023-10-24T10:00:00Z\",\"changes\":[" +
+				"{\"field\":\"account_id\",\"before\":null,\"after\":1},{\"field\":\"amount\",\"before\":null,\"after\":\"1200.50\"},{\"field\":\"category_id\",\"before\":null,\"after\":1}," +
+				"{\"field\":\"currency\",\"before\":null,\"after\":\"ARS\"},{\"field\":\"date\",\"before\":null,\"after\":\"2023-10-24T10:00:00Z\"},{\"field\":\"description\",\"before\":null,\"after\":\"Salario\"}," +
+				"{\"field\":\"splits\",\"before\":null,\"after\":[]},{\"field\":\"tags\",\"before\":null,\"after\":[]},{\"field\":\"type\",\"before\":null,\"after\":\"income\"}]}]",
+		},
+		{
+			Name:         "when the operation has no history",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error while listing the history",
+			Params:       3,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while listing the history of the operation.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := operationService.History(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestOperationServiceImpl_Revert(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the operation is reverted",
+			Params:       []int{1, 1},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully reverted.\"}",
+		},
+		{
+			Name:         "when the version is a deletion",
+			Params:       []int{1, 2},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The version cannot be restored.\"}",
+		},
+		{
+			Name:         "when the change is not found",
+			Params:       []int{1, 4},
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the operation no longer exists",
+			Params:       []int{2, 1},
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ids := tt.Params.([]int)
+			code, response := operationService.Revert(dao.User{ID: 1}, ids[0], ids[1])
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestCategoryServiceImpl_History(t *testing.T) {
+	categoryService := CategoryServiceInit(&MockCategoryRepositoryCategories{}, &MockAuditRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the category has changes",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":3,\"action\":\"update\",\"actor_id\":1,\"actor\":\"pedro.fuentes\",\"created_at\":\"2023-10-24T10:00:00Z\",\"changes\":[{\"field\":\"name\",\"before\":\"Work\",\"after\":\"Job\"}]}," +
+				"{\"id\":1,\"action\":\"create\",\"actor_id\":1,\"actor\":\"pedro.fuentes\",\"created_at\":\"2023-10-24T10:00:00Z\",\"changes\":[" +
+				"{\"field\":\"color\",\"before\":null,\"after\":\"#fdg123\"},{\"field\":\"description\",\"before\":null,\"after\":\"Work\"},{\"field\":\"name\",\"before\":null,\"after\":\"Work\"}]}]",
+		},
+		{
+			Name:         "when the category has no history",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := categoryService.History(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestCategoryServiceImpl_Revert(t *testing.T) {
+	categoryService := CategoryServiceInit(&MockCategoryRepositoryCategories{}, &MockAuditRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the category is reverted",
+			Params:       []int{1, 1},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Category successfully reverted.\"}",
+		},
+		{
+			Name:         "when the version is a deletion",
+			Params:       []int{1, 2},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The version cannot be restored.\"}",
+		},
+		{
+			Name:         "when the category no longer exists",
+			Params:       []int{2, 1},
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ids := tt.Params.([]int)
+			code, response := categoryService.Revert(dao.User{ID: 1}, ids[0], ids[1])
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestOperationSnapshot(t *testing.T) {
+	categoryID := 4
+	operation := dao.Operation{
+		Type:     "expense",
+		Amount:   money.FromFloat(300),
+		Date:     auditCreatedAt,
+		Category: dao.Category{ID: 1},
+		Splits:   []dao.OperationSplit{{CategoryID: categoryID, Amount: money.FromFloat(300), Note: "Hotel"}},
+		Tags:     []dao.Tag{{Name: "vacation-2026"}, {Name: "reimbursable"}},
+	}
+
+	snapshot := operationSnapshot(operation)
+
+	assert.Equal(t, 1, *snapshot.CategoryID)
+	assert.Equal(t, []string{"reimbursable", "vacation-2026"}, snapshot.Tags)
+	assert.Equal(t, []dto.SplitSnapshot{{CategoryID: 4, Amount: money.FromFloat(300), Note: "Hotel"}}, snapshot.Splits)
+	assert.Nil(t, auditVersion((*dto.OperationSnapshot)(nil)))
+}
@@ -8,3 +8,11 @@ const INCOME_TYPE string = "income"
 const EXPENSE_TYPE string = "expense"
 
 var utcLocation, _ = time.LoadLocation("UTC")
+
+const DAILY_FREQUENCY string = "daily"
+const WEEKLY_FREQUENCY string = "weekly"
+const MONTHLY_FREQUENCY string = "monthly"
+const YEARLY_FREQUENCY string = "yearly"
+
+const DEFAULT_CURRENCY string = "ARS"
+const DATE_LAYOUT string = "2006-01-02"
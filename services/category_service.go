@@ -3,7 +3,10 @@ package services
 import (
 	"GoGin-API-CuentasClaras/dao"
 	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/patch"
 	"GoGin-API-CuentasClaras/repository"
+	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -12,14 +15,21 @@ import (
 type CategoryService interface {
 	Index(user dao.User) (int, []dto.TransformedIndexCategory)
 	Create(user dao.User, categoryCreateRequest dto.CategoryRequest) (int, interface{})
-	Update(user dao.User, categoryRequest dto.CategoryRequest, categoryID int) (int, interface{})
-	Delete(user dao.User, categoryID int) (int, interface{})
+	Show(user dao.User, categoryID int) (int, interface{})
+	Update(user dao.User, categoryRequest dto.CategoryRequest, categoryID int, ifMatch string) (int, interface{})
+	Patch(user dao.User, categoryID int, ifMatch string, applyPatch func(categoryRequest dto.CategoryRequest) (dto.CategoryRequest, error)) (int, interface{})
+	Delete(user dao.User, categoryID int, ifMatch string) (int, interface{})
+	History(user dao.User, categoryID int) (int, interface{})
+	Revert(user dao.User, categoryID int, entryID int) (int, interface{})
 }
 
 type CategoryServiceImpl struct {
 	categoryRepository repository.CategoryRepository
+	auditRepository    repository.AuditRepository
 }
 
+var errCategoryChangeFailed = errors.New("category change failed")
+
 func (u CategoryServiceImpl) Index(user dao.User) (int, []dto.TransformedIndexCategory) {
 	userCategories, _ := u.categoryRepository.FindCategoriesByUser(user)
 	defaultCategories, _ := u.categoryRepository.FindDefaultCategories()
@@ -28,7 +38,22 @@ func (u CategoryServiceImpl) Index(user dao.User) (int, []dto.TransformedIndexCa
 	return http.StatusOK, transformedResponse
 }
 
+func (u CategoryServiceImpl) Show(user dao.User, categoryID int) (int, interface{}) {
+	invalidCategoryID, category := validateCategoryID(categoryID, user, u.categoryRepository)
+	if invalidCategoryID {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	return http.StatusOK, transformCategory(category)
+}
+
 func (u CategoryServiceImpl) Create(user dao.User, categoryRequest dto.CategoryRequest) (int, interface{}) {
+	return u.inTransaction(gin.H{"error": "An error occurred in the creation of the category."}, func(service CategoryServiceImpl) (int, interface{}) {
+		return service.createCategory(user, categoryRequest)
+	})
+}
+
+func (u CategoryServiceImpl) createCategory(user dao.User, categoryRequest dto.CategoryRequest) (int, interface{}) {
 	categoryDao := dao.Category{
 		Name:        categoryRequest.Name,
 		Color:       categoryRequest.Color,
@@ -37,6 +62,9 @@ func (u CategoryServiceImpl) Create(user dao.User, categoryRequest dto.CategoryR
 	}
 
 	_, recordError := u.categoryRepository.Save(&categoryDao)
+	if recordError == nil {
+		recordError = u.audit(newAuditEntry(user, dto.AuditRecordCategory, categoryDao.ID, dto.AuditActionCreate, nil, categorySnapshot(categoryDao)))
+	}
 	if recordError != nil {
 		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the category."}
 	}
@@ -44,11 +72,25 @@ func (u CategoryServiceImpl) Create(user dao.User, categoryRequest dto.CategoryR
 	return http.StatusCreated, gin.H{"message": "Category successfully created."}
 }
 
-func (u CategoryServiceImpl) Update(user dao.User, categoryRequest dto.CategoryRequest, categoryID int) (int, interface{}) {
+// Update saves the category as requested. A non empty ifMatch must match the
+// ETag of the stored category.
+func (u CategoryServiceImpl) Update(user dao.User, categoryRequest dto.CategoryRequest, categoryID int, ifMatch string) (int, interface{}) {
+	return u.inTransaction(gin.H{"error": "An error occurred in the update of the category."}, func(service CategoryServiceImpl) (int, interface{}) {
+		return service.updateCategory(user, categoryRequest, categoryID, dto.AuditActionUpdate, ifMatch)
+	})
+}
+
+// updateCategory saves the category as requested and records the change under
+// the given audit action. It is saved only if it did not change since it was
+// loaded, and since the ETag in ifMatch when one is given.
+func (u CategoryServiceImpl) updateCategory(user dao.User, categoryRequest dto.CategoryRequest, categoryID int, action string, ifMatch string) (int, interface{}) {
 	invalidOperationID, category := validateCategoryID(categoryID, user, u.categoryRepository)
 	if invalidOperationID {
 		return http.StatusNotFound, gin.H{"error": "Not found."}
 	}
+	if categoryModified(category, ifMatch) {
+		return http.StatusPreconditionFailed, gin.H{"error": "The category has been modified."}
+	}
 
 	categoryDao := dao.Category{
 		ID:          category.ID,
@@ -56,9 +98,16 @@ func (u CategoryServiceImpl) Update(user dao.User, categoryRequest dto.CategoryR
 		Color:       categoryRequest.Color,
 		Description: categoryRequest.Description,
 		UserID:      uint(user.ID),
+		Version:     category.Version,
 	}
 
 	_, recordError := u.categoryRepository.Update(&categoryDao)
+	if errors.Is(recordError, repository.ErrVersionConflict) {
+		return http.StatusPreconditionFailed, gin.H{"error": "The category has been modified."}
+	}
+	if recordError == nil {
+		recordError = u.audit(newAuditEntry(user, dto.AuditRecordCategory, category.ID, action, categorySnapshot(category), categorySnapshot(categoryDao)))
+	}
 	if recordError != nil {
 		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the category."}
 	}
@@ -66,14 +115,64 @@ func (u CategoryServiceImpl) Update(user dao.User, categoryRequest dto.CategoryR
 	return http.StatusOK, gin.H{"message": "Category successfully updated."}
 }
 
-func (u CategoryServiceImpl) Delete(user dao.User, categoryID int) (int, interface{}) {
+// Patch changes only the fields the patch sets, keeping the rest as stored,
+// and returns the updated category.
+func (u CategoryServiceImpl) Patch(user dao.User, categoryID int, ifMatch string, applyPatch func(categoryRequest dto.CategoryRequest) (dto.CategoryRequest, error)) (int, interface{}) {
+	return u.inTransaction(gin.H{"error": "An error occurred in the update of the category."}, func(service CategoryServiceImpl) (int, interface{}) {
+		invalidCategoryID, category := validateCategoryID(categoryID, user, service.categoryRepository)
+		if invalidCategoryID {
+			return http.StatusNotFound, gin.H{"error": "Not found."}
+		}
+		if categoryModified(category, ifMatch) {
+			return http.StatusPreconditionFailed, gin.H{"error": "The category has been modified."}
+		}
+
+		categoryRequest, patchError := applyPatch(dto.CategoryRequest{
+			Name:        category.Name,
+			Color:       category.Color,
+			Description: category.Description,
+		})
+		if errors.Is(patchError, patch.ErrTestFailed) {
+			return http.StatusConflict, gin.H{"error": "The patch test failed."}
+		}
+		if patchError != nil {
+			return http.StatusBadRequest, gin.H{"error": "Invalid parameters."}
+		}
+
+		code, response := service.updateCategory(user, categoryRequest, categoryID, dto.AuditActionUpdate, ifMatch)
+		if code != http.StatusOK {
+			return code, response
+		}
+		category.Name = categoryRequest.Name
+		category.Color = categoryRequest.Color
+		category.Description = categoryRequest.Description
+		return code, transformCategory(category)
+	})
+}
+
+func (u CategoryServiceImpl) Delete(user dao.User, categoryID int, ifMatch string) (int, interface{}) {
+	return u.inTransaction(gin.H{"error": "An error occurred while deleting the category."}, func(service CategoryServiceImpl) (int, interface{}) {
+		return service.deleteCategory(user, categoryID, ifMatch)
+	})
+}
+
+func (u CategoryServiceImpl) deleteCategory(user dao.User, categoryID int, ifMatch string) (int, interface{}) {
 	invalidCategoryID, category := validateCategoryID(categoryID, user, u.categoryRepository)
 
 	if invalidCategoryID {
 		return http.StatusNotFound, gin.H{"error": "Not found."}
 	}
+	if categoryModified(category, ifMatch) {
+		return http.StatusPreconditionFailed, gin.H{"error": "The category has been modified."}
+	}
 
 	_, recordError := u.categoryRepository.Delete(&category)
+	if errors.Is(recordError, repository.ErrVersionConflict) {
+		return http.StatusPreconditionFailed, gin.H{"error": "The category has been modified."}
+	}
+	if recordError == nil {
+		recordError = u.audit(newAuditEntry(user, dto.AuditRecordCategory, category.ID, dto.AuditActionDelete, categorySnapshot(category), nil))
+	}
 	if recordError != nil {
 		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while deleting the category."}
 	}
@@ -81,29 +180,106 @@ func (u CategoryServiceImpl) Delete(user dao.User, categoryID int) (int, interfa
 	return http.StatusOK, gin.H{"message": "Category successfully deleted."}
 }
 
+// History lists the changes of the category, the latest first. It is kept
+// after the category is deleted.
+func (u CategoryServiceImpl) History(user dao.User, categoryID int) (int, interface{}) {
+	entries, recordError := u.auditRepository.FindAuditEntriesByRecord(user, dto.AuditRecordCategory, categoryID)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while listing the history of the category."}
+	}
+	if len(entries) == 0 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	return http.StatusOK, formatAuditEntries(entries)
+}
+
+// Revert brings the category back to the version it had right after the given
+// change. The revert is itself recorded as a change.
+func (u CategoryServiceImpl) Revert(user dao.User, categoryID int, entryID int) (int, interface{}) {
+	entry, recordError := u.auditRepository.FindAuditEntryByRecordAndId(user, dto.AuditRecordCategory, categoryID, entryID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	var version dto.CategorySnapshot
+	if entry.After == nil || json.Unmarshal([]byte(*entry.After), &version) != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "The version cannot be restored."}
+	}
+
+	categoryRequest := dto.CategoryRequest{
+		Name:        version.Name,
+		Color:       version.Color,
+		Description: version.Description,
+	}
+	code, response := u.inTransaction(gin.H{"error": "An error occurred in the update of the category."}, func(service CategoryServiceImpl) (int, interface{}) {
+		return service.updateCategory(user, categoryRequest, categoryID, dto.AuditActionRevert, "")
+	})
+	if code == http.StatusOK {
+		return code, gin.H{"message": "Category successfully reverted."}
+	}
+	return code, response
+}
+
+// inTransaction makes the change with the service bound to one transaction, so
+// that the change and its audit entry are saved together or not at all.
+func (u CategoryServiceImpl) inTransaction(errorResponse gin.H, change func(service CategoryServiceImpl) (int, interface{})) (int, interface{}) {
+	var code int
+	var response interface{}
+	recordError := u.categoryRepository.Transaction(func(repositories repository.CategoryRepositories) error {
+		service := CategoryServiceImpl{
+			categoryRepository: repositories.Categories,
+			auditRepository:    repositories.Audits,
+		}
+		code, response = change(service)
+		if code >= http.StatusBadRequest {
+			return errCategoryChangeFailed
+		}
+		return nil
+	})
+	if recordError != nil && !errors.Is(recordError, errCategoryChangeFailed) {
+		return http.StatusUnprocessableEntity, errorResponse
+	}
+	return code, response
+}
+
+func (u CategoryServiceImpl) audit(entries ...dao.AuditEntry) error {
+	_, recordError := u.auditRepository.SaveAll(entries)
+	return recordError
+}
+
 func FormatCategories(userCategories []dao.Category, defaultCategories []dao.Category) []dto.TransformedIndexCategory {
 	transformedCategories := []dto.TransformedIndexCategory{}
 	userCategoriesAndDefaults := append(defaultCategories, userCategories...)
 	for _, category := range userCategoriesAndDefaults {
-		transformed := dto.TransformedIndexCategory{
-			Id:          category.ID,
-			Name:        category.Name,
-			Color:       category.Color,
-			Description: category.Description,
-			IsDefault:   category.IsDefault,
-		}
-		transformedCategories = append(transformedCategories, transformed)
+		transformedCategories = append(transformedCategories, transformCategory(category))
 	}
 	return transformedCategories
 }
 
+func transformCategory(category dao.Category) dto.TransformedIndexCategory {
+	return dto.TransformedIndexCategory{
+		Id:          category.ID,
+		Name:        category.Name,
+		Color:       category.Color,
+		Description: category.Description,
+		IsDefault:   category.IsDefault,
+	}
+}
+
+// categoryModified reports whether the category no longer matches the ETag in
+// ifMatch. An empty ifMatch makes the change unconditional.
+func categoryModified(category dao.Category, ifMatch string) bool {
+	return ifMatch != "" && !ETagMatches(ifMatch, ETag(transformCategory(category)), false)
+}
+
 func validateCategoryID(categoryID int, user dao.User, categoryRepository repository.CategoryRepository) (bool, dao.Category) {
 	category, errFindOperation := categoryRepository.FindCategoryByUserAndId(user, categoryID)
 	return errFindOperation != nil, category
 }
 
-func CategoryServiceInit(categoryRepository repository.CategoryRepository) *CategoryServiceImpl {
+func CategoryServiceInit(categoryRepository repository.CategoryRepository, auditRepository repository.AuditRepository) *CategoryServiceImpl {
 	return &CategoryServiceImpl{
 		categoryRepository: categoryRepository,
+		auditRepository:    auditRepository,
 	}
 }
@@ -3,6 +3,8 @@ package services
 import (
 	dao "GoGin-API-CuentasClaras/dao"
 	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/patch"
+	"GoGin-API-CuentasClaras/repository"
 	testhelpers "GoGin-API-CuentasClaras/test_helpers"
 	"errors"
 	"net/http"
@@ -29,6 +31,10 @@ func (u MockCategoryRepositoryCategories) FindCategoryByUserAndId(user dao.User,
 		return dao.Category{
 			ID: 3,
 		}, nil
+	} else if categoryID == 4 {
+		return dao.Category{ID: 4, Name: "Custom", Color: "#6495ed", Description: "Custom", UserID: 1}, nil
+	} else if categoryID == 5 {
+		return dao.Category{ID: 5, Name: "Custom", Color: "#6495ed", Description: "Custom", UserID: 1, Version: 2}, nil
 	}
 	return dao.Category{}, nil
 }
@@ -37,6 +43,9 @@ func (u MockCategoryRepositoryCategories) Update(category *dao.Category) (dao.Ca
 	if category.Description == "Payment for work" {
 		return dao.Category{}, errors.New("Invalid category.")
 	}
+	if category.Version == 2 {
+		return dao.Category{}, repository.ErrVersionConflict
+	}
 	return dao.Category{}, nil
 }
 
@@ -47,6 +56,10 @@ func (u MockCategoryRepositoryCategories) Delete(category *dao.Category) (dao.Ca
 	return dao.Category{}, nil
 }
 
+func (u MockCategoryRepositoryCategories) Transaction(callback func(repositories repository.CategoryRepositories) error) error {
+	return callback(repository.CategoryRepositories{Categories: u, Audits: &MockAuditRepository{}})
+}
+
 func (u MockCategoryRepositoryCategories) FindCategoryById(id int) (dao.Category, error) {
 	if id == 1 {
 		return dao.Category{}, nil
@@ -83,7 +96,7 @@ func (u MockCategoryRepositoryCategories) FindDefaultCategories() ([]dao.Categor
 
 func TestCategoryServiceImpl_Index(t *testing.T) {
 	categoryRepository := &MockCategoryRepositoryCategories{}
-	categoryService := CategoryServiceInit(categoryRepository)
+	categoryService := CategoryServiceInit(categoryRepository, &MockAuditRepository{})
 
 	var tests = []testhelpers.TestInterfaceStructure{
 		{
@@ -115,9 +128,36 @@ func TestCategoryServiceImpl_Index(t *testing.T) {
 	}
 }
 
+func TestCategoryServiceImpl_Show(t *testing.T) {
+	categoryRepository := &MockCategoryRepositoryCategories{}
+	categoryService := CategoryServiceInit(categoryRepository, &MockAuditRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the category is found",
+			Params:       4,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":4,\"name\":\"Custom\",\"color\":\"#6495ed\",\"description\":\"Custom\",\"is_default\":false}",
+		},
+		{
+			Name:         "when the category is not found",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := categoryService.Show(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
 func TestCategoryServiceImpl_Create(t *testing.T) {
 	categoryRepository := &MockCategoryRepositoryCategories{}
-	categoryService := CategoryServiceInit(categoryRepository)
+	categoryService := CategoryServiceInit(categoryRepository, &MockAuditRepository{})
 
 	var tests = []testhelpers.TestInterfaceStructure{
 		{
@@ -144,7 +184,7 @@ func TestCategoryServiceImpl_Create(t *testing.T) {
 
 func TestCategoryServiceImpl_Update(t *testing.T) {
 	categoryRepository := &MockCategoryRepositoryCategories{}
-	categoryService := CategoryServiceInit(categoryRepository)
+	categoryService := CategoryServiceInit(categoryRepository, &MockAuditRepository{})
 
 	var tests = []testhelpers.TestInterfaceStructure{
 		{
@@ -165,10 +205,29 @@ func TestCategoryServiceImpl_Update(t *testing.T) {
 			ExpectedCode: http.StatusUnprocessableEntity,
 			ExpectedBody: "{\"error\":\"An error occurred in the update of the category.\"}",
 		},
+		{
+			Name:         "when the category matches the ETag",
+			Params:       dto.CategoryRequest{Name: "Job", Color: "#6495ed", Description: "Custom"},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Category successfully updated.\"}",
+		},
+		{
+			Name:         "when the category does not match the ETag",
+			Params:       dto.CategoryRequest{Name: "Job", Color: "#6495ed", Description: "Custom"},
+			ExpectedCode: http.StatusPreconditionFailed,
+			ExpectedBody: "{\"error\":\"The category has been modified.\"}",
+		},
+		{
+			Name:         "when the category is changed by another request",
+			Params:       dto.CategoryRequest{Name: "Job", Color: "#6495ed", Description: "Custom"},
+			ExpectedCode: http.StatusPreconditionFailed,
+			ExpectedBody: "{\"error\":\"The category has been modified.\"}",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
 			categoryId := 1
+			ifMatch := ""
 
 			if tt.Name == "when the category is not found" {
 				categoryId = 2
@@ -178,7 +237,79 @@ func TestCategoryServiceImpl_Update(t *testing.T) {
 				categoryId = 3
 			}
 
-			code, response := categoryService.Update(dao.User{ID: 1}, tt.Params.(dto.CategoryRequest), categoryId)
+			if tt.Name == "when the category matches the ETag" {
+				categoryId = 4
+				ifMatch = `"0f1e2d3c", ` + ETag(dto.TransformedIndexCategory{Id: 4, Name: "Custom", Color: "#6495ed", Description: "Custom"})
+			} else if tt.Name == "when the category does not match the ETag" {
+				categoryId = 4
+				ifMatch = `"0f1e2d3c"`
+			} else if tt.Name == "when the category is changed by another request" {
+				categoryId = 5
+			}
+
+			code, response := categoryService.Update(dao.User{ID: 1}, tt.Params.(dto.CategoryRequest), categoryId, ifMatch)
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestCategoryServiceImpl_Patch(t *testing.T) {
+	categoryRepository := &MockCategoryRepositoryCategories{}
+	categoryService := CategoryServiceInit(categoryRepository, &MockAuditRepository{})
+	setName := func(categoryRequest dto.CategoryRequest) (dto.CategoryRequest, error) {
+		categoryRequest.Name = "Job"
+		return categoryRequest, nil
+	}
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the category is patched successfully",
+			Params:       setName,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":4,\"name\":\"Job\",\"color\":\"#6495ed\",\"description\":\"Custom\",\"is_default\":false}",
+		},
+		{
+			Name:         "when the category is not found",
+			Params:       setName,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name: "when the patch is invalid",
+			Params: func(categoryRequest dto.CategoryRequest) (dto.CategoryRequest, error) {
+				return categoryRequest, patch.ErrInvalidPatch
+			},
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name: "when the patch test fails",
+			Params: func(categoryRequest dto.CategoryRequest) (dto.CategoryRequest, error) {
+				return categoryRequest, patch.ErrTestFailed
+			},
+			ExpectedCode: http.StatusConflict,
+			ExpectedBody: "{\"error\":\"The patch test failed.\"}",
+		},
+		{
+			Name: "when there is an error in the update of the category",
+			Params: func(categoryRequest dto.CategoryRequest) (dto.CategoryRequest, error) {
+				categoryRequest.Description = "Payment for work"
+				return categoryRequest, nil
+			},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the category.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			categoryId := 4
+
+			if tt.Name == "when the category is not found" {
+				categoryId = 2
+			}
+
+			code, response := categoryService.Patch(dao.User{ID: 1}, categoryId, "", tt.Params.(func(dto.CategoryRequest) (dto.CategoryRequest, error)))
 
 			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
 		})
@@ -187,7 +318,7 @@ func TestCategoryServiceImpl_Update(t *testing.T) {
 
 func TestCategoryServiceImpl_Delete(t *testing.T) {
 	categoryRepository := &MockCategoryRepositoryCategories{}
-	categoryService := CategoryServiceInit(categoryRepository)
+	categoryService := CategoryServiceInit(categoryRepository, &MockAuditRepository{})
 
 	var tests = []testhelpers.TestInterfaceStructure{
 		{
@@ -219,7 +350,7 @@ func TestCategoryServiceImpl_Delete(t *testing.T) {
 				categoryId = 3
 			}
 
-			code, response := categoryService.Delete(dao.User{ID: 1}, categoryId)
+			code, response := categoryService.Delete(dao.User{ID: 1}, categoryId, "")
 
 			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
 		})
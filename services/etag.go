@@ -0,0 +1,33 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// ETag identifies a representation of a resource by the hash of its JSON, so
+// it changes whenever anything shown in the response changes.
+func ETag(response interface{}) string {
+	representation, _ := json.Marshal(response)
+	hash := sha256.Sum256(representation)
+	return `"` + hex.EncodeToString(hash[:16]) + `"`
+}
+
+// ETagMatches reports whether an If-Match or If-None-Match header, a list of
+// entity tags or "*", includes the given one. Weak tags only match their strong
+// counterpart under the weak comparison If-None-Match uses; If-Match compares
+// them strongly, so a weak tag never matches.
+func ETagMatches(header string, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
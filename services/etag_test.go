@@ -0,0 +1,29 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	category := dto.TransformedIndexCategory{Id: 2, Name: "Custom", Color: "#6495ed"}
+	etag := ETag(category)
+
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+	assert.Equal(t, etag, ETag(category))
+	category.Name = "Travel"
+	assert.NotEqual(t, etag, ETag(category))
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `"0f1e2d3c"`
+
+	assert.True(t, ETagMatches(etag, etag, false))
+	assert.True(t, ETagMatches(`"a1", W/"0f1e2d3c"`, etag, true))
+	assert.False(t, ETagMatches(`"a1", W/"0f1e2d3c"`, etag, false))
+	assert.True(t, ETagMatches("*", etag, false))
+	assert.False(t, ETagMatches(`"a1"`, etag, true))
+	assert.False(t, ETagMatches("", etag, true))
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"GoGin-API-CuentasClaras/repository"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var exchangeRateColumns = []string{"date", "from_currency", "to_currency", "rate"}
+
+type ExchangeRateService interface {
+	Index(user dao.User) (int, []dto.TransformedExchangeRate)
+	Create(user dao.User, exchangeRateRequest dto.ExchangeRateRequest) (int, interface{})
+	Import(user dao.User, file io.Reader) (int, interface{})
+	Delete(user dao.User, exchangeRateID int) (int, interface{})
+}
+
+type ExchangeRateServiceImpl struct {
+	exchangeRateRepository repository.ExchangeRateRepository
+}
+
+func (u ExchangeRateServiceImpl) Index(user dao.User) (int, []dto.TransformedExchangeRate) {
+	exchangeRates, _ := u.exchangeRateRepository.FindExchangeRatesByUser(user)
+	transformedResponse := []dto.TransformedExchangeRate{}
+	for _, exchangeRate := range exchangeRates {
+		transformedResponse = append(transformedResponse, dto.TransformedExchangeRate{
+			ID:           exchangeRate.ID,
+			FromCurrency: exchangeRate.FromCurrency,
+			ToCurrency:   exchangeRate.ToCurrency,
+			Date:         exchangeRate.Date.Format(DATE_LAYOUT),
+			Rate:         exchangeRate.Rate,
+		})
+	}
+
+	return http.StatusOK, transformedResponse
+}
+
+func (u ExchangeRateServiceImpl) Create(user dao.User, exchangeRateRequest dto.ExchangeRateRequest) (int, interface{}) {
+	date, _ := time.Parse(DATE_LAYOUT, exchangeRateRequest.Date)
+	exchangeRateDao := dao.ExchangeRate{
+		UserID:       uint(user.ID),
+		FromCurrency: strings.ToUpper(exchangeRateRequest.FromCurrency),
+		ToCurrency:   strings.ToUpper(exchangeRateRequest.ToCurrency),
+		Date:         date,
+		Rate:         exchangeRateRequest.Rate,
+	}
+
+	recordError := u.exchangeRateRepository.SaveAll([]dao.ExchangeRate{exchangeRateDao})
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the exchange rate."}
+	}
+
+	return http.StatusCreated, gin.H{"message": "Exchange rate successfully created."}
+}
+
+// Import loads rates from a CSV with the columns date (YYYY-MM-DD), from_currency,
+// to_currency and rate. A header row naming those columns may reorder them.
+// Reading stops as soon as the file has more than MAX_IMPORT_ROWS rates.
+func (u ExchangeRateServiceImpl) Import(user dao.User, file io.Reader) (int, interface{}) {
+	reader := csv.NewReader(file)
+	record, err := reader.Read()
+	if err != nil {
+		return http.StatusBadRequest, gin.H{"error": "Invalid CSV file."}
+	}
+
+	columns := map[string]int{}
+	for index, name := range exchangeRateColumns {
+		columns[name] = index
+	}
+	if _, err := time.Parse(DATE_LAYOUT, strings.TrimSpace(record[0])); err != nil {
+		header := map[string]int{}
+		for index, name := range record {
+			header[strings.ToLower(strings.TrimSpace(name))] = index
+		}
+		for _, name := range exchangeRateColumns {
+			if _, ok := header[name]; !ok {
+				return http.StatusBadRequest, gin.H{"error": "Invalid CSV header."}
+			}
+		}
+		columns = header
+		record, err = reader.Read()
+	}
+
+	exchangeRates := []dao.ExchangeRate{}
+	for ; err != io.EOF; record, err = reader.Read() {
+		if err != nil {
+			return http.StatusBadRequest, gin.H{"error": "Invalid CSV file."}
+		}
+		if len(exchangeRates) == MAX_IMPORT_ROWS {
+			return http.StatusBadRequest, gin.H{"error": "The file has too many rows."}
+		}
+		exchangeRate, parseError := parseExchangeRateRecord(user, record, columns)
+		if parseError != nil {
+			line, _ := reader.FieldPos(0)
+			return http.StatusUnprocessableEntity, gin.H{"error": fmt.Sprintf("Invalid exchange rate at line %d.", line)}
+		}
+		exchangeRates = append(exchangeRates, exchangeRate)
+	}
+
+	recordError := u.exchangeRateRepository.SaveAll(exchangeRates)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while importing the exchange rates."}
+	}
+
+	return http.StatusCreated, gin.H{"message": "Exchange rates successfully imported.", "imported": len(exchangeRates)}
+}
+
+func (u ExchangeRateServiceImpl) Delete(user dao.User, exchangeRateID int) (int, interface{}) {
+	exchangeRate, recordError := u.exchangeRateRepository.FindExchangeRateByUserAndId(user, exchangeRateID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	_, recordError = u.exchangeRateRepository.Delete(&exchangeRate)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while deleting the exchange rate."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Exchange rate successfully deleted."}
+}
+
+func parseExchangeRateRecord(user dao.User, record []string, columns map[string]int) (dao.ExchangeRate, error) {
+	for _, name := range exchangeRateColumns {
+		if columns[name] >= len(record) {
+			return dao.ExchangeRate{}, fmt.Errorf("missing column %s", name)
+		}
+	}
+	date, err := time.Parse(DATE_LAYOUT, strings.TrimSpace(record[columns["date"]]))
+	if err != nil {
+		return dao.ExchangeRate{}, err
+	}
+	rate, err := strconv.ParseFloat(strings.TrimSpace(record[columns["rate"]]), 64)
+	if err != nil || rate <= 0 {
+		return dao.ExchangeRate{}, fmt.Errorf("invalid rate")
+	}
+	fromCurrency := strings.ToUpper(strings.TrimSpace(record[columns["from_currency"]]))
+	toCurrency := strings.ToUpper(strings.TrimSpace(record[columns["to_currency"]]))
+	if !ValidCurrencyCode(fromCurrency) || !ValidCurrencyCode(toCurrency) || fromCurrency == toCurrency {
+		return dao.ExchangeRate{}, fmt.Errorf("invalid currency pair")
+	}
+
+	return dao.ExchangeRate{
+		UserID:       uint(user.ID),
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Date:         date,
+		Rate:         rate,
+	}, nil
+}
+
+// ValidCurrencyCode reports whether code looks like an ISO 4217 code.
+func ValidCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, letter := range code {
+		if letter < 'A' || letter > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// ConvertAmount converts amount from one currency to another with the rate in
+// effect at date, using the inverse pair when only that one is loaded.
+func ConvertAmount(exchangeRateRepository repository.ExchangeRateRepository, user dao.User, amount money.Amount, fromCurrency string, toCurrency string, date time.Time) (money.Amount, error) {
+	if fromCurrency == toCurrency {
+		return amount, nil
+	}
+	exchangeRate, err := exchangeRateRepository.FindRateOnDate(user, fromCurrency, toCurrency, date)
+	if err == nil {
+		return amount.Convert(exchangeRate.Rate), nil
+	}
+	inverseRate, inverseErr := exchangeRateRepository.FindRateOnDate(user, toCurrency, fromCurrency, date)
+	if inverseErr != nil {
+		return 0, err
+	}
+	return amount.Convert(1 / inverseRate.Rate), nil
+}
+
+func ExchangeRateServiceInit(exchangeRateRepository repository.ExchangeRateRepository) *ExchangeRateServiceImpl {
+	return &ExchangeRateServiceImpl{
+		exchangeRateRepository: exchangeRateRepository,
+	}
+}
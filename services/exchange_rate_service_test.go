@@ -0,0 +1,124 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExchangeRateServiceImpl_Create(t *testing.T) {
+	exchangeRateService := ExchangeRateServiceInit(&MockExchangeRateRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the exchange rate is created successfully",
+			Params:       dto.ExchangeRateRequest{FromCurrency: "usd", ToCurrency: "ars", Date: "2023-10-24", Rate: 350},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Exchange rate successfully created.\"}",
+		},
+		{
+			Name:         "when there is an error in the creation of the exchange rate",
+			Params:       dto.ExchangeRateRequest{FromCurrency: "USD", ToCurrency: "ARS", Date: "2023-10-24", Rate: 999},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the creation of the exchange rate.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := exchangeRateService.Create(dao.User{ID: 1}, tt.Params.(dto.ExchangeRateRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestExchangeRateServiceImpl_Import(t *testing.T) {
+	exchangeRateService := ExchangeRateServiceInit(&MockExchangeRateRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the file has a header",
+			Params:       "rate,date,from_currency,to_currency\n350.5,2023-10-24,usd,ars\n1.07,2023-10-24,EUR,USD\n",
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"imported\":2,\"message\":\"Exchange rates successfully imported.\"}",
+		},
+		{
+			Name:         "when the file has no header",
+			Params:       "2023-10-24,USD,ARS,350\n",
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"imported\":1,\"message\":\"Exchange rates successfully imported.\"}",
+		},
+		{
+			Name:         "when the header is missing a column",
+			Params:       "date,from_currency,rate\n2023-10-24,USD,350\n",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid CSV header.\"}",
+		},
+		{
+			Name:         "when a row is invalid",
+			Params:       "date,from_currency,to_currency,rate\n2023-10-24,USD,ARS,350\n2023-10-25,USD,ARS,abc\n",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid exchange rate at line 3.\"}",
+		},
+		{
+			Name:         "when a row without header is invalid",
+			Params:       "2023-10-24,USD,ARS,350\n\n2023-10-25,USD,USD,1\n",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid exchange rate at line 3.\"}",
+		},
+		{
+			Name:         "when the file has too many rows",
+			Params:       strings.Repeat("2023-10-24,USD,ARS,350\n", MAX_IMPORT_ROWS+1),
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"The file has too many rows.\"}",
+		},
+		{
+			Name:         "when the file is empty",
+			Params:       "",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid CSV file.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := exchangeRateService.Import(dao.User{ID: 1}, strings.NewReader(tt.Params.(string)))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestExchangeRateServiceImpl_Delete(t *testing.T) {
+	exchangeRateService := ExchangeRateServiceInit(&MockExchangeRateRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the exchange rate is found",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Exchange rate successfully deleted.\"}",
+		},
+		{
+			Name:         "when the exchange rate is not found",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error while deleting the exchange rate",
+			Params:       3,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while deleting the exchange rate.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := exchangeRateService.Delete(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/repository"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MAX_BATCH_ACTIONS bounds the actions of a single batch.
+const MAX_BATCH_ACTIONS int = 100
+
+var errBatchActionFailed = errors.New("batch action failed")
+
+// Batch applies the actions in order inside one transaction, each one on its
+// own savepoint so that a failed action leaves no partial changes behind. Every
+// action gets the status and response it would have had as a single request.
+func (u OperationServiceImpl) Batch(user dao.User, batchRequest dto.OperationBatchRequest) (int, interface{}) {
+	result := dto.OperationBatchResult{
+		Mode:    batchRequest.Mode,
+		Results: []dto.OperationBatchActionResult{},
+	}
+	if result.Mode == "" {
+		result.Mode = dto.BatchModeAllOrNothing
+	}
+
+	recordError := u.operationRepository.Transaction(func(repositories repository.OperationRepositories) error {
+		for index, action := range batchRequest.Actions {
+			actionResult := dto.OperationBatchActionResult{Index: index, Action: action.Action, ID: action.ID}
+			repositories.Operations.Transaction(func(actionRepositories repository.OperationRepositories) error {
+				actionResult.Status, actionResult.Response, actionResult.ID = u.withRepositories(actionRepositories).batchAction(user, action)
+				if actionResult.Status >= http.StatusBadRequest {
+					return errBatchActionFailed
+				}
+				return nil
+			})
+			if actionResult.Status >= http.StatusBadRequest {
+				result.Failed++
+			} else {
+				result.Succeeded++
+			}
+			result.Results = append(result.Results, actionResult)
+		}
+		if result.Failed > 0 && result.Mode == dto.BatchModeAllOrNothing {
+			return errBatchActionFailed
+		}
+		return nil
+	})
+	if recordError != nil && !errors.Is(recordError, errBatchActionFailed) {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the batch of operations."}
+	}
+	result.Committed = recordError == nil
+
+	if !result.Committed {
+		// The operations created before the failure were rolled back too.
+		for index := range result.Results {
+			if result.Results[index].Action == dto.BatchActionCreate {
+				result.Results[index].ID = 0
+			}
+		}
+		return http.StatusUnprocessableEntity, result
+	}
+	return http.StatusOK, result
+}
+
+func (u OperationServiceImpl) batchAction(user dao.User, action dto.OperationBatchAction) (int, interface{}, int) {
+	if action.Invalid {
+		return http.StatusBadRequest, gin.H{"error": "Invalid parameters."}, action.ID
+	}
+	switch action.Action {
+	case dto.BatchActionCreate:
+		return u.createOperation(user, action.Operation)
+	case dto.BatchActionUpdate:
+		code, response := u.updateOperation(user, action.Operation, action.ID, dto.AuditActionUpdate, "")
+		return code, response, action.ID
+	default:
+		code, response := u.deleteOperation(user, action.ID, "")
+		return code, response, action.ID
+	}
+}
+
+// withRepositories returns a copy of the service writing through the given
+// repositories.
+func (u OperationServiceImpl) withRepositories(repositories repository.OperationRepositories) OperationServiceImpl {
+	u.operationRepository = repositories.Operations
+	u.transferRepository = repositories.Transfers
+	u.tagRepository = repositories.Tags
+	u.auditRepository = repositories.Audits
+	return u
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"testing"
+)
+
+func TestOperationServiceImpl_Batch(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+	operationRequest := dto.OperationRequest{Type: "income", Amount: money.FromFloat(100), Date: "2023-10-20T15:04:05Z", CategoryID: "1", Description: "Refund"}
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name: "when all the actions succeed",
+			Params: dto.OperationBatchRequest{Actions: []dto.OperationBatchAction{
+				{Action: dto.BatchActionCreate, Operation: operationRequest},
+				{Action: dto.BatchActionUpdate, ID: 1, Operation: operationRequest},
+				{Action: dto.BatchActionDelete, ID: 1},
+			}},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"mode\":\"all_or_nothing\",\"committed\":true,\"succeeded\":3,\"failed\":0,\"results\":[" +
+				"{\"index\":0,\"action\":\"create\",\"id\":10,\"status\":201,\"response\":{\"message\":\"Operation successfully created.\"}}," +
+				"{\"index\":1,\"action\":\"update\",\"id\":1,\"status\":200,\"response\":{\"message\":\"Operation successfully updated.\"}}," +
+				"{\"index\":2,\"action\":\"delete\",\"id\":1,\"status\":200,\"response\":{\"message\":\"Operation successfully deleted.\"}}]}",
+		},
+		{
+			Name: "when an action fails with all or nothing",
+			Params: dto.OperationBatchRequest{Mode: dto.BatchModeAllOrNothing, Actions: []dto.OperationBatchAction{
+				{Action: dto.BatchActionCreate, Operation: operationRequest},
+				{Action: dto.BatchActionDelete, ID: 99},
+			}},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"mode\":\"all_or_nothing\",\"committed\":false,\"succeeded\":1,\"failed\":1,\"results\":[" +
+				"{\"index\":0,\"action\":\"create\",\"id\":0,\"status\":201,\"response\":{\"message\":\"Operation successfully created.\"}}," +
+				"{\"index\":1,\"action\":\"delete\",\"id\":99,\"status\":404,\"response\":{\"error\":\"Not found.\"}}]}",
+		},
+		{
+			Name: "when an action fails with best effort",
+			Params: dto.OperationBatchRequest{Mode: dto.BatchModeBestEffort, Actions: []dto.OperationBatchAction{
+				{Action: dto.BatchActionCreate, Operation: dto.OperationRequest{}, Invalid: true},
+				{Action: dto.BatchActionUpdate, ID: 3, Operation: operationRequest},
+				{Action: dto.BatchActionDelete, ID: 1},
+			}},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"mode\":\"best_effort\",\"committed\":true,\"succeeded\":1,\"failed\":2,\"results\":[" +
+				"{\"index\":0,\"action\":\"create\",\"id\":0,\"status\":400,\"response\":{\"error\":\"Invalid parameters.\"}}," +
+				"{\"index\":1,\"action\":\"update\",\"id\":3,\"status\":422,\"response\":{\"error\":\"An error occurred in the update of the operation.\"}}," +
+				"{\"index\":2,\"action\":\"delete\",\"id\":1,\"status\":200,\"response\":{\"message\":\"Operation successfully deleted.\"}}]}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := operationService.Batch(dao.User{ID: 1}, tt.Params.(dto.OperationBatchRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
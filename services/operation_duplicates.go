@@ -0,0 +1,174 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DEFAULT_DUPLICATE_DAYS is how far apart, in days, two operations may be
+// dated and still be taken for duplicates when the request does not say.
+const DEFAULT_DUPLICATE_DAYS int = 3
+
+const MAX_DUPLICATE_DAYS int = 30
+
+// DUPLICATE_SIMILARITY is the lowest similarity of the descriptions of two
+// operations for them to be taken for duplicates.
+const DUPLICATE_SIMILARITY float64 = 0.6
+
+// Duplicates lists the pairs of the user's operations that look like the same
+// one recorded twice: same type, amount and currency, close dates and similar
+// descriptions.
+func (u OperationServiceImpl) Duplicates(user dao.User, duplicateFilter dto.OperationDuplicateFilter) (int, interface{}) {
+	duplicates, recordError := u.findDuplicates(user, duplicateFilter)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while looking for duplicated operations."}
+	}
+	return http.StatusOK, duplicates
+}
+
+// Merge keeps the operation and moves the duplicate to the trash. The kept
+// operation gets the descriptions of both, the tags of both and the
+// attachments of the duplicate.
+func (u OperationServiceImpl) Merge(user dao.User, operationID int, mergeRequest dto.OperationMergeRequest) (int, interface{}) {
+	duplicateID, _ := strconv.Atoi(mergeRequest.DuplicateID)
+	code, response, _ := u.inTransaction(gin.H{"error": "An error occurred while merging the operations."}, func(service OperationServiceImpl) (int, interface{}, int) {
+		invalidOperationID, operation := validateOperationID(operationID, user, service.operationRepository)
+		if invalidOperationID {
+			return http.StatusNotFound, gin.H{"error": "Not found."}, 0
+		}
+		invalidDuplicateID, duplicate := validateOperationID(duplicateID, user, service.operationRepository)
+		if invalidDuplicateID {
+			return http.StatusNotFound, gin.H{"error": "Not found."}, 0
+		}
+		if operation.TransferID != nil || duplicate.TransferID != nil {
+			return http.StatusUnprocessableEntity, gin.H{"error": "Transfers cannot be merged."}, 0
+		}
+
+		operationRequest := operationSnapshotRequest(*operationSnapshot(operation))
+		operationRequest.Description = mergeDescriptions(operation.Description, duplicate.Description)
+		operationRequest.Tags = NormalizeTags(append(tagNames(operation.Tags), tagNames(duplicate.Tags)...))
+		code, response := service.updateOperation(user, operationRequest, operationID, dto.AuditActionMerge, "")
+		if code != http.StatusOK {
+			return code, response, 0
+		}
+		if recordError := service.operationRepository.MoveAttachments(duplicate, operation); recordError != nil {
+			return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while merging the operations."}, 0
+		}
+		code, response = service.deleteOperation(user, duplicateID, "")
+		if code != http.StatusOK {
+			return code, response, 0
+		}
+		return http.StatusOK, gin.H{"message": "Operations successfully merged."}, operationID
+	})
+	return code, response
+}
+
+func (u OperationServiceImpl) findDuplicates(user dao.User, duplicateFilter dto.OperationDuplicateFilter) ([]dto.TransformedDuplicate, error) {
+	if duplicateFilter.Days == 0 {
+		duplicateFilter.Days = DEFAULT_DUPLICATE_DAYS
+	}
+	rows, recordError := u.operationRepository.FindDuplicateOperations(user, duplicateFilter)
+	if recordError != nil {
+		return nil, recordError
+	}
+
+	duplicates := []dto.TransformedDuplicate{}
+	for _, row := range rows {
+		similarity := descriptionSimilarity(row.OperationDescription, row.DuplicateDescription)
+		if similarity < DUPLICATE_SIMILARITY {
+			continue
+		}
+		duplicates = append(duplicates, dto.TransformedDuplicate{
+			Type:       row.Type,
+			Amount:     row.Amount,
+			Currency:   row.Currency,
+			Similarity: math.Round(similarity*100) / 100,
+			Operations: []dto.DuplicateOperation{
+				{ID: row.OperationID, Date: row.OperationDate.In(utcLocation), Description: row.OperationDescription},
+				{ID: row.DuplicateID, Date: row.DuplicateDate.In(utcLocation), Description: row.DuplicateDescription},
+			},
+		})
+	}
+	return duplicates, nil
+}
+
+// duplicateIDs returns the ids of the operations the given one looks like a
+// duplicate of. Failing to look for them is not an error for the caller, so
+// no ids are returned then.
+func (u OperationServiceImpl) duplicateIDs(user dao.User, operationID int) []int {
+	duplicates, recordError := u.findDuplicates(user, dto.OperationDuplicateFilter{OperationID: operationID})
+	ids := []int{}
+	if recordError != nil {
+		return ids
+	}
+	for _, duplicate := range duplicates {
+		for _, operation := range duplicate.Operations {
+			if operation.ID != operationID {
+				ids = append(ids, operation.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// descriptionSimilarity compares the descriptions by the pairs of adjacent
+// characters they share once lowercased and stripped of punctuation, from 0
+// for nothing in common to 1 for the same text.
+func descriptionSimilarity(first string, second string) float64 {
+	first, second = normalizeDescription(first), normalizeDescription(second)
+	if first == second {
+		return 1
+	}
+	firstBigrams, secondBigrams := bigrams(first), bigrams(second)
+	if len(firstBigrams) == 0 || len(secondBigrams) == 0 {
+		return 0
+	}
+	remaining := map[string]int{}
+	for _, bigram := range secondBigrams {
+		remaining[bigram]++
+	}
+	shared := 0
+	for _, bigram := range firstBigrams {
+		if remaining[bigram] > 0 {
+			remaining[bigram]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(firstBigrams)+len(secondBigrams))
+}
+
+func normalizeDescription(description string) string {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+func bigrams(text string) []string {
+	runes := []rune(text)
+	pairs := []string{}
+	for index := 0; index+1 < len(runes); index++ {
+		pairs = append(pairs, string(runes[index:index+2]))
+	}
+	return pairs
+}
+
+// mergeDescriptions keeps the description that already contains the other
+// one, and joins them otherwise.
+func mergeDescriptions(kept string, duplicate string) string {
+	normalizedKept, normalizedDuplicate := normalizeDescription(kept), normalizeDescription(duplicate)
+	if strings.Contains(normalizedKept, normalizedDuplicate) {
+		return kept
+	}
+	if strings.Contains(normalizedDuplicate, normalizedKept) {
+		return duplicate
+	}
+	return kept + " / " + duplicate
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationServiceImpl_Duplicates(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the user has duplicated operations",
+			Params:       dto.OperationDuplicateFilter{},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"type\":\"expense\",\"amount\":\"50.00\",\"currency\":\"ARS\",\"similarity\":0.69,\"operations\":[" +
+				"{\"id\":7,\"date\":\"2023-10-20T15:04:05Z\",\"description\":\"Coffee shop\"}," +
+				"{\"id\":8,\"date\":\"2023-10-21T15:04:05Z\",\"description\":\"COFFEE SHOP downtown\"}]}]",
+		},
+		{
+			Name:         "when the user has no duplicated operations",
+			Params:       dto.OperationDuplicateFilter{Days: 7},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+		{
+			Name:         "when there is an error looking for duplicated operations",
+			Params:       dto.OperationDuplicateFilter{},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while looking for duplicated operations.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			user := dao.User{ID: 1}
+
+			if tt.Name == "when the user has no duplicated operations" {
+				user = dao.User{ID: 2}
+			} else if tt.Name == "when there is an error looking for duplicated operations" {
+				user = dao.User{ID: 3}
+			}
+
+			code, response := operationService.Duplicates(user, tt.Params.(dto.OperationDuplicateFilter))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestOperationServiceImpl_Merge(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the operations are merged",
+			Params:       dto.OperationMergeRequest{DuplicateID: "1"},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operations successfully merged.\"}",
+		},
+		{
+			Name:         "when the duplicate is not found",
+			Params:       dto.OperationMergeRequest{DuplicateID: "2"},
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the duplicate is a side of a transfer",
+			Params:       dto.OperationMergeRequest{DuplicateID: "5"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Transfers cannot be merged.\"}",
+		},
+		{
+			Name:         "when the attachments of the duplicate cannot be moved",
+			Params:       dto.OperationMergeRequest{DuplicateID: "7"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while merging the operations.\"}",
+		},
+		{
+			Name:         "when the duplicate cannot be deleted",
+			Params:       dto.OperationMergeRequest{DuplicateID: "3"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while deleting the operation.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := operationService.Merge(dao.User{ID: 1}, 10, tt.Params.(dto.OperationMergeRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestDescriptionSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, descriptionSimilarity("Coffee shop", "COFFEE-SHOP"))
+	assert.Equal(t, 1.0, descriptionSimilarity("", " "))
+	assert.Equal(t, 0.0, descriptionSimilarity("Coffee shop", ""))
+	assert.Greater(t, descriptionSimilarity("Uber trip", "UBER *TRIP 1234"), DUPLICATE_SIMILARITY)
+	assert.Less(t, descriptionSimilarity("Coffee shop", "Supermarket"), DUPLICATE_SIMILARITY)
+}
+
+func TestMergeDescriptions(t *testing.T) {
+	assert.Equal(t, "Coffee shop downtown", mergeDescriptions("Coffee shop downtown", "coffee shop"))
+	assert.Equal(t, "COFFEE SHOP downtown", mergeDescriptions("Coffee shop", "COFFEE SHOP downtown"))
+	assert.Equal(t, "Coffee shop", mergeDescriptions("Coffee shop", ""))
+	assert.Equal(t, "Coffee shop / Bakery", mergeDescriptions("Coffee shop", "Bakery"))
+}
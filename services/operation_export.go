@@ -0,0 +1,253 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ofxDateLayout string = "20060102150405"
+
+// MAX_OFX_NAME_LENGTH is the length the OFX specification allows for the name
+// of a transaction, longer descriptions go whole in the memo.
+const MAX_OFX_NAME_LENGTH int = 32
+
+// operationExporter writes the exported operations in one format. Nothing is
+// written until the first operation or the close, so an error reading the
+// operations can still be answered as usual.
+type operationExporter interface {
+	Write(row dto.OperationExportRow) error
+	Close() error
+}
+
+// Export writes the filtered operations to the writer as they are read from
+// the database, oldest first.
+func (u OperationServiceImpl) Export(user dao.User, exportRequest dto.OperationExportRequest, writer io.Writer) error {
+	var exporter operationExporter
+	switch exportRequest.Format {
+	case dto.ExportFormatJSONLines:
+		bufferedWriter := bufio.NewWriter(writer)
+		exporter = &jsonLinesOperationExporter{writer: bufferedWriter, encoder: json.NewEncoder(bufferedWriter)}
+	case dto.ExportFormatOFX:
+		exporter = &ofxOperationExporter{writer: bufio.NewWriter(writer), user: user, filter: exportRequest.OperationFilter}
+	default:
+		exporter = newCSVOperationExporter(writer, exportRequest.DecimalSeparator, exportRequest.Delimiter)
+	}
+
+	err := u.operationRepository.StreamOperationsByFilter(user, exportRequest.OperationFilter, exporter.Write)
+	if err != nil {
+		return err
+	}
+	return exporter.Close()
+}
+
+// csvOperationExporter writes a header row and one row per operation, in the
+// columns the CSV import reads back: the type keeps expenses apart from incomes
+// and the quote csvTextCell adds is dropped. With a decimal comma the fields are
+// separated by semicolons unless another delimiter is asked for.
+type csvOperationExporter struct {
+	writer           *csv.Writer
+	decimalSeparator string
+	started          bool
+}
+
+func newCSVOperationExporter(writer io.Writer, decimalSeparator string, delimiter string) *csvOperationExporter {
+	csvWriter := csv.NewWriter(writer)
+	if delimiter != "" {
+		csvWriter.Comma = []rune(delimiter)[0]
+	} else if decimalSeparator == "," {
+		csvWriter.Comma = ';'
+	}
+	return &csvOperationExporter{writer: csvWriter, decimalSeparator: decimalSeparator}
+}
+
+func (e *csvOperationExporter) start() error {
+	e.started = true
+	return e.writer.Write([]string{"id", "date", "type", "amount", "currency", "category", "description", "account_id", "transfer_id", "external_id"})
+}
+
+func (e *csvOperationExporter) Write(row dto.OperationExportRow) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	err := e.writer.Write([]string{
+		strconv.Itoa(row.ID),
+		row.Date.In(utcLocation).Format(DATE_LAYOUT),
+		row.Type,
+		formatExportAmount(row.Amount, e.decimalSeparator),
+		row.Currency,
+		csvTextCell(row.CategoryName),
+		csvTextCell(row.Description),
+		optionalID(row.AccountID),
+		optionalID(row.TransferID),
+		csvTextCell(row.ExternalID),
+	})
+	if err != nil {
+		return err
+	}
+	return e.writer.Error()
+}
+
+func (e *csvOperationExporter) Close() error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// csvTextCell keeps a spreadsheet from reading a free text cell as a formula
+// by quoting the ones that start like one.
+func csvTextCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// csvTextValue drops the quote csvTextCell puts before a formula-like cell.
+func csvTextValue(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// jsonLinesOperationExporter writes each operation as a JSON object on its own
+// line.
+type jsonLinesOperationExporter struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (e *jsonLinesOperationExporter) Write(row dto.OperationExportRow) error {
+	return e.encoder.Encode(row)
+}
+
+func (e *jsonLinesOperationExporter) Close() error {
+	return e.writer.Flush()
+}
+
+// ofxOperationExporter writes an OFX 2 bank statement in the user's base
+// currency. Operations in other currencies carry their own currency with a rate
+// of 1, since their amounts are not converted. Transfers are written as XFER,
+// the rest as credits and debits, and the FITID is the bank's one for imported
+// operations and the operation id otherwise.
+type ofxOperationExporter struct {
+	writer  *bufio.Writer
+	user    dao.User
+	filter  dto.OperationFilter
+	started bool
+}
+
+func (e *ofxOperationExporter) currency() string {
+	if e.filter.Currency != "" {
+		return e.filter.Currency
+	}
+	if e.user.BaseCurrency != "" {
+		return e.user.BaseCurrency
+	}
+	return DEFAULT_CURRENCY
+}
+
+func (e *ofxOperationExporter) start() error {
+	e.started = true
+	now := time.Now().In(utcLocation)
+	start, end := time.Unix(0, 0).In(utcLocation), now
+	if e.filter.From != nil {
+		start = e.filter.From.In(utcLocation)
+	}
+	if e.filter.To != nil {
+		end = e.filter.To.In(utcLocation)
+	}
+	accountID := "ALL"
+	if e.filter.AccountID != nil {
+		accountID = strconv.Itoa(*e.filter.AccountID)
+	}
+	_, err := fmt.Fprintf(e.writer, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><DTSERVER>%s</DTSERVER><LANGUAGE>SPA</LANGUAGE></SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<STMTRS><CURDEF>%s</CURDEF>
+<BANKACCTFROM><BANKID>CUENTASCLARAS</BANKID><ACCTID>%s</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>
+<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>
+`, now.Format(ofxDateLayout), e.currency(), accountID, start.Format(ofxDateLayout), end.Format(ofxDateLayout))
+	return err
+}
+
+func (e *ofxOperationExporter) Write(row dto.OperationExportRow) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	transactionType, amount := "CREDIT", row.Amount
+	if row.Type == EXPENSE_TYPE {
+		transactionType, amount = "DEBIT", -row.Amount
+	}
+	if row.TransferID != nil {
+		transactionType = "XFER"
+	}
+	transactionID := row.ExternalID
+	if transactionID == "" {
+		transactionID = strconv.Itoa(row.ID)
+	}
+
+	var transaction strings.Builder
+	fmt.Fprintf(&transaction, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID>",
+		transactionType, row.Date.In(utcLocation).Format(ofxDateLayout), amount.String(), html.EscapeString(transactionID))
+	if name := []rune(row.Description); len(name) > MAX_OFX_NAME_LENGTH {
+		fmt.Fprintf(&transaction, "<NAME>%s</NAME><MEMO>%s</MEMO>", html.EscapeString(string(name[:MAX_OFX_NAME_LENGTH])), html.EscapeString(row.Description))
+	} else if len(name) > 0 {
+		fmt.Fprintf(&transaction, "<NAME>%s</NAME>", html.EscapeString(row.Description))
+	}
+	if row.Currency != "" && row.Currency != e.currency() {
+		fmt.Fprintf(&transaction, "<CURRENCY><CURRATE>1</CURRATE><CURSYM>%s</CURSYM></CURRENCY>", row.Currency)
+	}
+	transaction.WriteString("</STMTTRN>\n")
+	_, err := e.writer.WriteString(transaction.String())
+	return err
+}
+
+func (e *ofxOperationExporter) Close() error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	if _, err := e.writer.WriteString("</BANKTRANLIST>\n</STMTRS></STMTTRNRS></BANKMSGSRSV1>\n</OFX>\n"); err != nil {
+		return err
+	}
+	return e.writer.Flush()
+}
+
+// formatExportAmount writes an amount with two decimals and the given decimal
+// separator, without thousands separators.
+func formatExportAmount(amount money.Amount, decimalSeparator string) string {
+	formatted := amount.String()
+	if decimalSeparator == "," {
+		return strings.Replace(formatted, ".", ",", 1)
+	}
+	return formatted
+}
+
+func optionalID(id *int) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.Itoa(*id)
+}
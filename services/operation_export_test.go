@@ -0,0 +1,115 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationServiceImpl_Export(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+
+	var tests = []struct {
+		name     string
+		request  dto.OperationExportRequest
+		expected string
+	}{
+		{
+			name:    "when the operations are exported as CSV",
+			request: dto.OperationExportRequest{Format: dto.ExportFormatCSV},
+			expected: "id,date,type,amount,currency,category,description,account_id,transfer_id,external_id\n" +
+				"1,2023-10-20,income,1200.50,ARS,Work,\"Salary, October\",1,,\n" +
+				"2,2023-10-21,expense,35.90,USD,Groceries,Supermarket & bakery,1,,A-1\n" +
+				"5,2023-10-22,expense,3500.00,ARS,,,1,1,\n",
+		},
+		{
+			name:    "when the CSV uses a decimal comma",
+			request: dto.OperationExportRequest{DecimalSeparator: ","},
+			expected: "id;date;type;amount;currency;category;description;account_id;transfer_id;external_id\n" +
+				"1;2023-10-20;income;1200,50;ARS;Work;Salary, October;1;;\n" +
+				"2;2023-10-21;expense;35,90;USD;Groceries;Supermarket & bakery;1;;A-1\n" +
+				"5;2023-10-22;expense;3500,00;ARS;;;1;1;\n",
+		},
+		{
+			name:    "when the operations are exported as JSON Lines",
+			request: dto.OperationExportRequest{Format: dto.ExportFormatJSONLines},
+			expected: "{\"id\":1,\"date\":\"2023-10-20T15:04:05Z\",\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"category_id\":1,\"category\":\"Work\",\"account_id\":1,\"transfer_id\":null,\"description\":\"Salary, October\",\"external_id\":\"\"}\n" +
+				"{\"id\":2,\"date\":\"2023-10-21T15:04:05Z\",\"type\":\"expense\",\"amount\":\"35.90\",\"currency\":\"USD\",\"category_id\":5,\"category\":\"Groceries\",\"account_id\":1,\"transfer_id\":null,\"description\":\"Supermarket \\u0026 bakery\",\"external_id\":\"A-1\"}\n" +
+				"{\"id\":5,\"date\":\"2023-10-22T15:04:05Z\",\"type\":\"expense\",\"amount\":\"3500.00\",\"currency\":\"ARS\",\"category_id\":null,\"category\":\"\",\"account_id\":1,\"transfer_id\":1,\"description\":\"\",\"external_id\":\"\"}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			err := operationService.Export(dao.User{ID: 1}, tt.request, &output)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, output.String())
+		})
+	}
+}
+
+func TestCSVTextCell(t *testing.T) {
+	assert.Equal(t, "'=HYPERLINK(\"http://example.com\")", csvTextCell("=HYPERLINK(\"http://example.com\")"))
+	assert.Equal(t, "'+54 11 5555", csvTextCell("+54 11 5555"))
+	assert.Equal(t, "'-2+3", csvTextCell("-2+3"))
+	assert.Equal(t, "'@SUM(A1)", csvTextCell("@SUM(A1)"))
+	assert.Equal(t, "Supermarket - bakery", csvTextCell("Supermarket - bakery"))
+	assert.Equal(t, "", csvTextCell(""))
+
+	assert.Equal(t, "=SUM(A1)", csvTextValue(csvTextCell("=SUM(A1)")))
+	assert.Equal(t, "'quoted'", csvTextValue("'quoted'"))
+}
+
+func TestOperationServiceImpl_ExportImport(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+
+	var output bytes.Buffer
+	err := operationService.Export(dao.User{ID: 1}, dto.OperationExportRequest{DecimalSeparator: ","}, &output)
+	assert.NoError(t, err)
+
+	code, response := operationService.Import(dao.User{ID: 1}, dto.OperationImportRequest{DecimalSeparator: ",", Delimiter: ";", DefaultCategoryID: "1", DryRun: true}, &output)
+	assert.Equal(t, http.StatusOK, code)
+	rows := response.(dto.OperationImportResult).Rows
+	assert.Len(t, rows, 3)
+	for index, expected := range []struct {
+		operation string
+		amount    money.Amount
+		currency  string
+	}{{INCOME_TYPE, money.FromFloat(1200.5), "ARS"}, {EXPENSE_TYPE, money.FromFloat(35.9), "USD"}, {EXPENSE_TYPE, money.FromFloat(3500), "ARS"}} {
+		assert.Equal(t, expected.operation, rows[index].Type)
+		assert.Equal(t, expected.amount, rows[index].Amount)
+		assert.Equal(t, expected.currency, rows[index].Currency)
+	}
+}
+
+func TestOperationServiceImpl_ExportOFX(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+
+	var output bytes.Buffer
+	err := operationService.Export(dao.User{ID: 1, BaseCurrency: "ARS"}, dto.OperationExportRequest{Format: dto.ExportFormatOFX}, &output)
+	assert.NoError(t, err)
+
+	importedOperations, err := parseOFXOperations(&output)
+	assert.NoError(t, err)
+	assert.Len(t, importedOperations, 3)
+	assert.Equal(t, importedOperation{line: 9, date: importedOperations[0].date, operation: INCOME_TYPE, amount: money.FromFloat(1200.5), currency: "ARS", description: "Salary, October", externalID: "1"}, importedOperations[0])
+	assert.Equal(t, importedOperation{line: 10, date: importedOperations[1].date, operation: EXPENSE_TYPE, amount: money.FromFloat(35.9), currency: "USD", description: "Supermarket & bakery", externalID: "A-1"}, importedOperations[1])
+	assert.Equal(t, "2023-10-22", importedOperations[2].date.Format(DATE_LAYOUT))
+	assert.Equal(t, EXPENSE_TYPE, importedOperations[2].operation)
+}
+
+func TestOperationServiceImpl_ExportError(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+
+	var output bytes.Buffer
+	err := operationService.Export(dao.User{ID: 3}, dto.OperationExportRequest{}, &output)
+
+	assert.Error(t, err)
+	assert.Empty(t, output.String())
+}
@@ -0,0 +1,490 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"GoGin-API-CuentasClaras/repository"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MAX_IMPORT_ROWS bounds the operations a single file may carry.
+const MAX_IMPORT_ROWS int = 5000
+
+// MAX_IMPORT_SIZE is the largest file, in bytes, accepted for import.
+const MAX_IMPORT_SIZE int64 = 5 << 20
+
+const DEFAULT_IMPORT_DATE_FORMAT string = "YYYY-MM-DD"
+
+// ImportDateFormats translates the date formats accepted in an import mapping
+// into Go layouts.
+var ImportDateFormats = map[string]string{
+	"YYYY-MM-DD": "2006-01-02",
+	"YYYY/MM/DD": "2006/01/02",
+	"DD/MM/YYYY": "02/01/2006",
+	"DD-MM-YYYY": "02-01-2006",
+	"DD.MM.YYYY": "02.01.2006",
+	"MM/DD/YYYY": "01/02/2006",
+	"RFC3339":    time.RFC3339,
+}
+
+var errInvalidImportMapping = errors.New("invalid import mapping")
+var errTooManyImportRows = errors.New("too many import rows")
+var errImportTooLarge = errors.New("import file too large")
+
+// importedOperation is a row read from an imported file, before its category is
+// resolved and it is checked against the existing operations.
+type importedOperation struct {
+	line        int
+	date        time.Time
+	operation   string
+	amount      money.Amount
+	currency    string
+	description string
+	category    string
+	externalID  string
+	errors      []string
+}
+
+// Import reads the operations of a CSV file, an OFX statement or a QIF file.
+// With a dry run it only reports every row with its validation errors;
+// otherwise the rows that are not already recorded are saved together, and
+// nothing is saved when a row is invalid.
+func (u OperationServiceImpl) Import(user dao.User, importRequest dto.OperationImportRequest, file io.Reader) (int, interface{}) {
+	invalidAccount, account := invalidAccountID(user, importRequest.AccountID, u.accountRepository)
+	if invalidAccount {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid account."}
+	}
+
+	var importedOperations []importedOperation
+	var parseError error
+	format := importRequest.Format
+	switch format {
+	case dto.ImportFormatOFX:
+		importedOperations, parseError = parseOFXOperations(file)
+	case dto.ImportFormatQIF:
+		importedOperations, parseError = parseQIFOperations(file, importRequest)
+	default:
+		format = dto.ImportFormatCSV
+		importedOperations, parseError = parseCSVOperations(file, importRequest)
+	}
+	if errors.Is(parseError, errInvalidImportMapping) {
+		return http.StatusBadRequest, gin.H{"error": "Invalid column mapping."}
+	}
+	if errors.Is(parseError, errTooManyImportRows) {
+		return http.StatusBadRequest, gin.H{"error": "The file has too many rows."}
+	}
+	if errors.Is(parseError, errImportTooLarge) {
+		return http.StatusRequestEntityTooLarge, gin.H{"error": "The file exceeds the maximum size."}
+	}
+	if parseError != nil {
+		return http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid %s file.", strings.ToUpper(format))}
+	}
+
+	return u.importOperations(user, account, importRequest, importedOperations)
+}
+
+func (u OperationServiceImpl) importOperations(user dao.User, account dao.Account, importRequest dto.OperationImportRequest, importedOperations []importedOperation) (int, interface{}) {
+	defaultCategoryID := 0
+	if importRequest.DefaultCategoryID != "" {
+		if invalidCategoryID(importRequest.DefaultCategoryID, u.categoryRepository) {
+			return http.StatusUnprocessableEntity, gin.H{"error": "Invalid category."}
+		}
+		defaultCategoryID, _ = strconv.Atoi(importRequest.DefaultCategoryID)
+	}
+	categoryIDs := u.categoryIDsByName(user)
+	rules, recordError := u.ruleRepository.FindRulesByUser(user)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the import of the operations."}
+	}
+	ruleSet := newRuleSet(rules)
+	payees, recordError := u.payeeRepository.FindPayeesByUser(user)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the import of the operations."}
+	}
+	payeeMatcher := newPayeeMatcher(payees)
+
+	result := dto.OperationImportResult{DryRun: importRequest.DryRun, Rows: []dto.OperationImportRow{}}
+	operations := []dao.Operation{}
+	for _, importedOperation := range importedOperations {
+		categoryID := 0
+		if importedOperation.category != "" {
+			categoryID = categoryIDs[strings.ToLower(importedOperation.category)]
+		}
+		unknownCategory := importedOperation.category != "" && categoryID == 0
+		subject := ruleSubject{
+			Type:        importedOperation.operation,
+			Amount:      importedOperation.amount,
+			Description: importedOperation.description,
+			CategoryID:  categoryID,
+		}
+		ruleSet.fill(&subject)
+		categoryID, importedOperation.description = subject.CategoryID, subject.Description
+		if categoryID == 0 {
+			categoryID = defaultCategoryID
+		}
+		if categoryID == 0 || unknownCategory {
+			importedOperation.errors = append(importedOperation.errors, "Invalid category.")
+		}
+		if importedOperation.currency == "" {
+			importedOperation.currency = accountOperationCurrency(user, account, "")
+		}
+
+		row := dto.OperationImportRow{
+			Line:        importedOperation.line,
+			Type:        importedOperation.operation,
+			Amount:      importedOperation.amount,
+			Currency:    importedOperation.currency,
+			Description: importedOperation.description,
+			Category:    importedOperation.category,
+			ExternalID:  importedOperation.externalID,
+			Status:      dto.ImportRowValid,
+			Errors:      importedOperation.errors,
+		}
+		if !importedOperation.date.IsZero() {
+			row.Date = importedOperation.date.Format(DATE_LAYOUT)
+		}
+		if row.Errors == nil {
+			row.Errors = []string{}
+		}
+		if len(row.Errors) > 0 {
+			row.Status = dto.ImportRowInvalid
+			result.Invalid++
+			result.Rows = append(result.Rows, row)
+			continue
+		}
+
+		operation := dao.Operation{
+			UserID:      uint(user.ID),
+			CategoryID:  &categoryID,
+			AccountID:   &account.ID,
+			Type:        importedOperation.operation,
+			Amount:      importedOperation.amount,
+			Currency:    importedOperation.currency,
+			Date:        importedOperation.date,
+			Description: importedOperation.description,
+			ExternalID:  importedOperation.externalID,
+			PayeeID:     payeeMatcher.match(importedOperation.description),
+		}
+		for _, name := range subject.Tags {
+			operation.Tags = append(operation.Tags, dao.Tag{Name: name})
+		}
+		operations = append(operations, operation)
+		result.Rows = append(result.Rows, row)
+	}
+
+	operations, recordError = u.skipDuplicatedOperations(user, operations, &result)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the import of the operations."}
+	}
+	if importRequest.DryRun {
+		return http.StatusOK, result
+	}
+	if result.Invalid > 0 {
+		return http.StatusUnprocessableEntity, result
+	}
+
+	recordError = u.operationRepository.Transaction(func(repositories repository.OperationRepositories) error {
+		if err := findOrCreateRuleTags(user, operations, repositories.Tags); err != nil {
+			return err
+		}
+		savedOperations, err := repositories.Operations.SaveAll(operations)
+		if err != nil {
+			return err
+		}
+		entries := []dao.AuditEntry{}
+		for _, operation := range savedOperations {
+			entries = append(entries, newAuditEntry(user, dto.AuditRecordOperation, operation.ID, dto.AuditActionCreate, nil, operationSnapshot(operation)))
+		}
+		_, err = repositories.Audits.SaveAll(entries)
+		return err
+	})
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the import of the operations."}
+	}
+	result.Imported = len(operations)
+
+	return http.StatusCreated, result
+}
+
+// findOrCreateRuleTags swaps the tags the rules added to the operations, known
+// only by name, for the user's tags.
+func findOrCreateRuleTags(user dao.User, operations []dao.Operation, tagRepository repository.TagRepository) error {
+	names := []string{}
+	for _, operation := range operations {
+		names = append(names, tagNames(operation.Tags)...)
+	}
+	names = NormalizeTags(names)
+	if len(names) == 0 {
+		return nil
+	}
+	tags, err := tagRepository.FindOrCreateTags(user, names)
+	if err != nil {
+		return err
+	}
+	tagsByName := map[string]dao.Tag{}
+	for _, tag := range tags {
+		tagsByName[tag.Name] = tag
+	}
+	for index := range operations {
+		for tagIndex, tag := range operations[index].Tags {
+			operations[index].Tags[tagIndex] = tagsByName[tag.Name]
+		}
+	}
+	return nil
+}
+
+// skipDuplicatedOperations leaves out the operations already recorded and
+// marks their rows. Operations carrying the bank's transaction id match on it
+// within the account, even when repeated in the same file; the rest match an
+// operation of the same account, day, type, amount and description.
+func (u OperationServiceImpl) skipDuplicatedOperations(user dao.User, operations []dao.Operation, result *dto.OperationImportResult) ([]dao.Operation, error) {
+	if len(operations) == 0 {
+		return operations, nil
+	}
+	from, to := operations[0].Date, operations[0].Date
+	for _, operation := range operations {
+		if operation.Date.Before(from) {
+			from = operation.Date
+		}
+		if operation.Date.After(to) {
+			to = operation.Date
+		}
+	}
+	existingOperations, recordError := u.operationRepository.FindOperationsBetween(user, startOfDay(from), startOfDay(to).AddDate(0, 0, 1))
+	if recordError != nil {
+		return nil, recordError
+	}
+	existingKeys := map[string]bool{}
+	for _, existingOperation := range existingOperations {
+		existingKeys[operationImportKey(existingOperation)] = true
+	}
+	externalIDs := []string{}
+	for _, operation := range operations {
+		if operation.ExternalID != "" {
+			externalIDs = append(externalIDs, operation.ExternalID)
+		}
+	}
+	existingExternalKeys := map[string]bool{}
+	if len(externalIDs) > 0 {
+		externalOperations, recordError := u.operationRepository.FindOperationsByExternalIDs(user, externalIDs)
+		if recordError != nil {
+			return nil, recordError
+		}
+		for _, externalOperation := range externalOperations {
+			existingExternalKeys[operationExternalKey(externalOperation)] = true
+		}
+	}
+
+	newOperations := []dao.Operation{}
+	validRow := 0
+	for index := range result.Rows {
+		if result.Rows[index].Status != dto.ImportRowValid {
+			continue
+		}
+		operation := operations[validRow]
+		validRow++
+		duplicated := existingKeys[operationImportKey(operation)]
+		if operation.ExternalID != "" {
+			duplicated = existingExternalKeys[operationExternalKey(operation)]
+			existingExternalKeys[operationExternalKey(operation)] = true
+		}
+		if duplicated {
+			result.Rows[index].Status = dto.ImportRowDuplicate
+			result.Duplicates++
+			continue
+		}
+		newOperations = append(newOperations, operation)
+	}
+	return newOperations, nil
+}
+
+// categoryIDsByName indexes the default categories and the user's own ones by
+// lowercase name, the user's taking precedence.
+func (u OperationServiceImpl) categoryIDsByName(user dao.User) map[string]int {
+	categoryIDs := map[string]int{}
+	defaultCategories, _ := u.categoryRepository.FindDefaultCategories()
+	userCategories, _ := u.categoryRepository.FindCategoriesByUser(user)
+	for _, category := range append(defaultCategories, userCategories...) {
+		categoryIDs[strings.ToLower(strings.TrimSpace(category.Name))] = category.ID
+	}
+	return categoryIDs
+}
+
+// parseCSVOperations reads the rows of a CSV file whose first row names the
+// columns. The date and amount columns are required, the others are used when
+// present, so a CSV export is read back as it was written. Reading stops as soon as the file has more than MAX_IMPORT_ROWS
+// rows.
+func parseCSVOperations(file io.Reader, importRequest dto.OperationImportRequest) ([]importedOperation, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if importRequest.Delimiter != "" {
+		reader.Comma = []rune(importRequest.Delimiter)[0]
+	}
+	headerRecord, err := reader.Read()
+	if err == io.EOF {
+		return nil, errInvalidImportMapping
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	header := map[string]int{}
+	for index, name := range headerRecord {
+		header[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = index
+	}
+	columns := map[string]int{}
+	for field, mapping := range map[string][2]string{
+		"date":        {importRequest.DateColumn, "date"},
+		"amount":      {importRequest.AmountColumn, "amount"},
+		"description": {importRequest.DescriptionColumn, "description"},
+		"category":    {importRequest.CategoryColumn, "category"},
+		"type":        {importRequest.TypeColumn, "type"},
+		"currency":    {importRequest.CurrencyColumn, "currency"},
+	} {
+		name := strings.ToLower(strings.TrimSpace(mapping[0]))
+		explicit := name != ""
+		if !explicit {
+			name = mapping[1]
+		}
+		index, found := header[name]
+		if found && name != "" {
+			columns[field] = index
+		} else if explicit || field == "date" || field == "amount" {
+			return nil, errInvalidImportMapping
+		}
+	}
+
+	layout := ImportDateFormats[DEFAULT_IMPORT_DATE_FORMAT]
+	if importRequest.DateFormat != "" {
+		layout = ImportDateFormats[importRequest.DateFormat]
+	}
+
+	importedOperations := []importedOperation{}
+	for index := 0; ; index++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if emptyRecord(record) {
+			continue
+		}
+		if len(importedOperations) == MAX_IMPORT_ROWS {
+			return nil, errTooManyImportRows
+		}
+		value := func(field string) string {
+			column, mapped := columns[field]
+			if !mapped || column >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[column])
+		}
+
+		importedOperation := importedOperation{
+			line:        index + 2,
+			description: csvTextValue(value("description")),
+			category:    csvTextValue(value("category")),
+		}
+		importedOperation.date = parseImportDate(value("date"), layout, &importedOperation)
+		amount, amountError := parseImportAmount(value("amount"), importRequest.DecimalSeparator)
+		if amountError != nil || amount == 0 {
+			importedOperation.errors = append(importedOperation.errors, "Invalid amount.")
+		}
+		importedOperation.operation, importedOperation.amount = importedOperationType(amount, importRequest.AmountSign)
+		if _, mapped := columns["type"]; mapped {
+			importedOperation.operation = strings.ToLower(value("type"))
+			if importedOperation.operation != INCOME_TYPE && importedOperation.operation != EXPENSE_TYPE {
+				importedOperation.errors = append(importedOperation.errors, "Invalid type.")
+			}
+		}
+		if currency := strings.ToUpper(value("currency")); currency != "" {
+			importedOperation.currency = currency
+			if !ValidCurrencyCode(currency) {
+				importedOperation.errors = append(importedOperation.errors, "Invalid currency.")
+			}
+		}
+		importedOperations = append(importedOperations, importedOperation)
+	}
+	return importedOperations, nil
+}
+
+func parseImportDate(value string, layout string, importedOperation *importedOperation) time.Time {
+	date, err := time.ParseInLocation(layout, value, utcLocation)
+	if err != nil || date.After(time.Now()) {
+		importedOperation.errors = append(importedOperation.errors, "Invalid date.")
+		return time.Time{}
+	}
+	return date.In(utcLocation)
+}
+
+// parseImportAmount reads an amount written with the given decimal separator,
+// dropping the thousands separators and spaces.
+func parseImportAmount(value string, decimalSeparator string) (money.Amount, error) {
+	thousandsSeparator := ","
+	if decimalSeparator == "," {
+		thousandsSeparator = "."
+	}
+	replacer := strings.NewReplacer(" ", "", "\u00a0", "", thousandsSeparator, "")
+	normalized := replacer.Replace(value)
+	if decimalSeparator == "," {
+		normalized = strings.Replace(normalized, ",", ".", 1)
+	}
+	return money.Parse(normalized)
+}
+
+// importedOperationType derives the type of an operation from the sign of its
+// amount and returns the amount without sign.
+func importedOperationType(amount money.Amount, amountSign string) (string, money.Amount) {
+	expense := amount < 0
+	if amountSign == dto.AmountSignPositiveExpense {
+		expense = amount > 0
+	}
+	if expense {
+		return EXPENSE_TYPE, amount.Abs()
+	}
+	return INCOME_TYPE, amount.Abs()
+}
+
+func operationImportKey(operation dao.Operation) string {
+	accountID := 0
+	if operation.AccountID != nil {
+		accountID = *operation.AccountID
+	}
+	return fmt.Sprintf("%d|%s|%s|%s|%s", accountID, operation.Type, operation.Date.In(utcLocation).Format(DATE_LAYOUT),
+		operation.Amount, strings.ToLower(strings.TrimSpace(operation.Description)))
+}
+
+func operationExternalKey(operation dao.Operation) string {
+	accountID := 0
+	if operation.AccountID != nil {
+		accountID = *operation.AccountID
+	}
+	return fmt.Sprintf("%d|%s", accountID, operation.ExternalID)
+}
+
+func startOfDay(date time.Time) time.Time {
+	date = date.In(utcLocation)
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, utcLocation)
+}
+
+func emptyRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationServiceImpl_Import(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+
+	type importParams struct {
+		request dto.OperationImportRequest
+		file    string
+	}
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name: "when the file is imported successfully",
+			Params: importParams{
+				dto.OperationImportRequest{},
+				"date,amount,description,category\n2023-10-20,-50,Coffee shop,Work\n2023-10-21,1200.5,Salary,work\n",
+			},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"dry_run\":false,\"imported\":1,\"duplicates\":1,\"invalid\":0,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-20\",\"type\":\"expense\",\"amount\":\"50.00\",\"currency\":\"ARS\",\"description\":\"Coffee shop\",\"category\":\"Work\",\"status\":\"duplicate\",\"errors\":[]}," +
+				"{\"line\":3,\"date\":\"2023-10-21\",\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"description\":\"Salary\",\"category\":\"work\",\"status\":\"valid\",\"errors\":[]}]}",
+		},
+		{
+			Name: "when the columns are mapped with a bank format",
+			Params: importParams{
+				dto.OperationImportRequest{DateColumn: "Fecha", AmountColumn: "Importe", DescriptionColumn: "Concepto", DateFormat: "DD/MM/YYYY", DecimalSeparator: ",", Delimiter: ";", AmountSign: dto.AmountSignPositiveExpense, DefaultCategoryID: "1"},
+				"Fecha;Concepto;Importe\n21/10/2023;Supermercado;1.234,56\n22/10/2023;Reintegro;-10,00\n",
+			},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"dry_run\":false,\"imported\":2,\"duplicates\":0,\"invalid\":0,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-21\",\"type\":\"expense\",\"amount\":\"1234.56\",\"currency\":\"ARS\",\"description\":\"Supermercado\",\"category\":\"\",\"status\":\"valid\",\"errors\":[]}," +
+				"{\"line\":3,\"date\":\"2023-10-22\",\"type\":\"income\",\"amount\":\"10.00\",\"currency\":\"ARS\",\"description\":\"Reintegro\",\"category\":\"\",\"status\":\"valid\",\"errors\":[]}]}",
+		},
+		{
+			Name: "when a rule sets the category of a row",
+			Params: importParams{
+				dto.OperationImportRequest{},
+				"date,amount,description\n2023-10-22,-15,UBER TRIP 1234\n2023-10-22,-20,Taxi\n",
+			},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"dry_run\":false,\"imported\":0,\"duplicates\":0,\"invalid\":1,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-22\",\"type\":\"expense\",\"amount\":\"15.00\",\"currency\":\"ARS\",\"description\":\"Uber\",\"category\":\"\",\"status\":\"valid\",\"errors\":[]}," +
+				"{\"line\":3,\"date\":\"2023-10-22\",\"type\":\"expense\",\"amount\":\"20.00\",\"currency\":\"ARS\",\"description\":\"Taxi\",\"category\":\"\",\"status\":\"invalid\",\"errors\":[\"Invalid category.\"]}]}",
+		},
+		{
+			Name: "when it is a dry run with invalid rows",
+			Params: importParams{
+				dto.OperationImportRequest{TypeColumn: "kind", CurrencyColumn: "currency", DryRun: true},
+				"date,amount,kind,currency,category\n2023-10-21,10,expense,usd,Groceries\nyesterday,abc,refund,DOLLAR,Unknown\n",
+			},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"dry_run\":true,\"imported\":0,\"duplicates\":0,\"invalid\":1,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-21\",\"type\":\"expense\",\"amount\":\"10.00\",\"currency\":\"USD\",\"description\":\"\",\"category\":\"Groceries\",\"status\":\"valid\",\"errors\":[]}," +
+				"{\"line\":3,\"date\":\"\",\"type\":\"refund\",\"amount\":\"0.00\",\"currency\":\"DOLLAR\",\"description\":\"\",\"category\":\"Unknown\",\"status\":\"invalid\",\"errors\":[\"Invalid date.\",\"Invalid amount.\",\"Invalid type.\",\"Invalid currency.\",\"Invalid category.\"]}]}",
+		},
+		{
+			Name: "when the file has invalid rows",
+			Params: importParams{
+				dto.OperationImportRequest{},
+				"date,amount,category\n2023-10-21,10,Work\n2023-10-21,10,Unknown\n",
+			},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"dry_run\":false,\"imported\":0,\"duplicates\":0,\"invalid\":1,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-21\",\"type\":\"income\",\"amount\":\"10.00\",\"currency\":\"ARS\",\"description\":\"\",\"category\":\"Work\",\"status\":\"valid\",\"errors\":[]}," +
+				"{\"line\":3,\"date\":\"2023-10-21\",\"type\":\"income\",\"amount\":\"10.00\",\"currency\":\"ARS\",\"description\":\"\",\"category\":\"Unknown\",\"status\":\"invalid\",\"errors\":[\"Invalid category.\"]}]}",
+		},
+		{
+			Name: "when the file was exported with types and quoted cells",
+			Params: importParams{
+				dto.OperationImportRequest{DryRun: true},
+				"id,date,type,amount,currency,category,description\n4,2023-10-21,expense,10,USD,Work,'=1+1\n",
+			},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"dry_run\":true,\"imported\":0,\"duplicates\":0,\"invalid\":0,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-21\",\"type\":\"expense\",\"amount\":\"10.00\",\"currency\":\"USD\",\"description\":\"=1+1\",\"category\":\"Work\",\"status\":\"valid\",\"errors\":[]}]}",
+		},
+		{
+			Name:         "when a mapped column is missing",
+			Params:       importParams{dto.OperationImportRequest{TypeColumn: "kind"}, "date,amount\n2023-10-21,10\n"},
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid column mapping.\"}",
+		},
+		{
+			Name:         "when the file is not a valid CSV",
+			Params:       importParams{dto.OperationImportRequest{}, "date,amount\n\"2023-10-21,10\n"},
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid CSV file.\"}",
+		},
+		{
+			Name:         "when the file has too many rows",
+			Params:       importParams{dto.OperationImportRequest{}, "date,amount\n" + strings.Repeat("2023-10-21,10\n", MAX_IMPORT_ROWS+1)},
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"The file has too many rows.\"}",
+		},
+		{
+			Name:         "when the account is invalid",
+			Params:       importParams{dto.OperationImportRequest{AccountID: "9"}, "date,amount\n2023-10-21,10\n"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid account.\"}",
+		},
+		{
+			Name:         "when the default category is invalid",
+			Params:       importParams{dto.OperationImportRequest{DefaultCategoryID: "2"}, "date,amount\n2023-10-21,10\n"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid category.\"}",
+		},
+		{
+			Name:         "when there is an error in the import of the operations",
+			Params:       importParams{dto.OperationImportRequest{DefaultCategoryID: "1"}, "date,amount,description\n2023-10-21,10,Payment for work\n"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the import of the operations.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			params := tt.Params.(importParams)
+			code, response := operationService.Import(dao.User{ID: 1}, params.request, strings.NewReader(params.file))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestParseImportAmount(t *testing.T) {
+	var tests = []struct {
+		value            string
+		decimalSeparator string
+		expected         money.Amount
+	}{
+		{"1,234.56", "", 123456},
+		{"-1234.5", ".", -123450},
+		{"1.234,56", ",", 123456},
+		{"-10,5", ",", -1050},
+		{"1 000", "", 100000},
+	}
+	for _, tt := range tests {
+		amount, err := parseImportAmount(tt.value, tt.decimalSeparator)
+		assert.NoError(t, err, tt.value)
+		assert.Equal(t, tt.expected, amount, tt.value)
+	}
+	_, err := parseImportAmount("ten", "")
+	assert.Error(t, err)
+}
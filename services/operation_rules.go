@@ -0,0 +1,77 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/repository"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApplyRules runs the user's rules on their recorded operations, the sides of
+// transfers and reconciled operations aside. With a preview it only reports what each operation would
+// get; otherwise the changes are saved together and recorded in the history of
+// the operations as updates.
+func (u OperationServiceImpl) ApplyRules(user dao.User, applyRequest dto.RuleApplyRequest) (int, interface{}) {
+	errorResponse := gin.H{"error": "An error occurred while applying the rules."}
+	rules, recordError := u.ruleRepository.FindRulesByUser(user)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, errorResponse
+	}
+	ruleSet := newRuleSet(rules)
+
+	result := dto.RuleApplyResult{Preview: applyRequest.Preview, Operations: []dto.RuleApplyOperation{}}
+	subjects := map[int]ruleSubject{}
+	filter := dto.OperationFilter{Sort: dto.SortDateAsc, Limit: repository.MAX_OPERATIONS_LIMIT}
+	for {
+		operations, nextCursor, recordError := u.operationRepository.FindOperationsByFilter(user, filter)
+		if recordError != nil {
+			return http.StatusUnprocessableEntity, errorResponse
+		}
+		for _, operation := range operations {
+			if operation.TransferID != nil || operation.Status == dto.OperationStatusReconciled {
+				continue
+			}
+			subject := operationRuleSubject(operation)
+			ruleIDs := ruleSet.apply(&subject)
+			changes := ruleChanges(operation, subject)
+			if len(changes) == 0 {
+				continue
+			}
+			subjects[operation.ID] = subject
+			result.Operations = append(result.Operations, dto.RuleApplyOperation{ID: operation.ID, Rules: ruleIDs, Changes: changes})
+		}
+		if nextCursor == "" {
+			break
+		}
+		filter.Cursor = nextCursor
+	}
+	result.Changed = len(result.Operations)
+	if applyRequest.Preview || result.Changed == 0 {
+		return http.StatusOK, result
+	}
+
+	code, response, _ := u.inTransaction(errorResponse, func(service OperationServiceImpl) (int, interface{}, int) {
+		for _, change := range result.Operations {
+			invalidOperationID, operation := validateOperationID(change.ID, user, service.operationRepository)
+			if invalidOperationID {
+				return http.StatusUnprocessableEntity, errorResponse, 0
+			}
+			subject := subjects[change.ID]
+			operationRequest := operationSnapshotRequest(*operationSnapshot(operation))
+			if subject.CategoryID != 0 {
+				operationRequest.CategoryID = strconv.Itoa(subject.CategoryID)
+			}
+			operationRequest.Description = subject.Description
+			operationRequest.Tags = subject.Tags
+			code, response := service.updateOperation(user, operationRequest, change.ID, dto.AuditActionUpdate, "")
+			if code != http.StatusOK {
+				return code, response, 0
+			}
+		}
+		return http.StatusOK, result, 0
+	})
+	return code, response
+}
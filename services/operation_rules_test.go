@@ -0,0 +1,55 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"testing"
+)
+
+func TestOperationServiceImpl_ApplyRules(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the rules are previewed",
+			Params:       dto.RuleApplyRequest{Preview: true},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"preview\":true,\"changed\":1,\"operations\":[{\"id\":10,\"rules\":[1],\"changes\":[{\"field\":\"description\",\"before\":\"UBER TRIP 1234\",\"after\":\"Uber\"},{\"field\":\"tags\",\"before\":[],\"after\":[\"transport\"]}]}]}",
+		},
+		{
+			Name:         "when the rules are applied",
+			Params:       dto.RuleApplyRequest{},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"preview\":false,\"changed\":1,\"operations\":[{\"id\":10,\"rules\":[1],\"changes\":[{\"field\":\"description\",\"before\":\"UBER TRIP 1234\",\"after\":\"Uber\"},{\"field\":\"tags\",\"before\":[],\"after\":[\"transport\"]}]}]}",
+		},
+		{
+			Name:         "when no operation is changed by the rules",
+			Params:       dto.RuleApplyRequest{},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"preview\":false,\"changed\":0,\"operations\":[]}",
+		},
+		{
+			Name:         "when there is an error applying the rules",
+			Params:       dto.RuleApplyRequest{},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while applying the rules.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			user := dao.User{ID: 4}
+
+			if tt.Name == "when no operation is changed by the rules" {
+				user = dao.User{ID: 2}
+			} else if tt.Name == "when there is an error applying the rules" {
+				user = dao.User{ID: 3}
+			}
+
+			code, response := operationService.ApplyRules(user, tt.Params.(dto.RuleApplyRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
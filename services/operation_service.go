@@ -3,47 +3,117 @@ package services
 import (
 	"GoGin-API-CuentasClaras/dao"
 	dto "GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"GoGin-API-CuentasClaras/patch"
 	"GoGin-API-CuentasClaras/repository"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type OperationService interface {
-	Index(user dao.User) (int, []dto.TransformedOperation)
+	Index(user dao.User, operationFilter dto.OperationFilter) (int, interface{})
+	Search(user dao.User, searchRequest dto.OperationSearchRequest) (int, interface{})
 	Show(user dao.User, operationID int) (int, interface{})
 	Create(user dao.User, operationRequest dto.OperationRequest) (int, interface{})
-	Update(user dao.User, operationRequest dto.OperationRequest, operationID int) (int, interface{})
-	Delete(user dao.User, operationID int) (int, interface{})
+	Update(user dao.User, operationRequest dto.OperationRequest, operationID int, ifMatch string) (int, interface{})
+	Patch(user dao.User, operationID int, ifMatch string, applyPatch func(operationRequest dto.OperationRequest) (dto.OperationRequest, error)) (int, interface{})
+	Delete(user dao.User, operationID int, ifMatch string) (int, interface{})
+	Import(user dao.User, importRequest dto.OperationImportRequest, file io.Reader) (int, interface{})
+	Export(user dao.User, exportRequest dto.OperationExportRequest, writer io.Writer) error
+	Batch(user dao.User, batchRequest dto.OperationBatchRequest) (int, interface{})
+	History(user dao.User, operationID int) (int, interface{})
+	Revert(user dao.User, operationID int, entryID int) (int, interface{})
+	ApplyRules(user dao.User, applyRequest dto.RuleApplyRequest) (int, interface{})
+	Duplicates(user dao.User, duplicateFilter dto.OperationDuplicateFilter) (int, interface{})
+	Merge(user dao.User, operationID int, mergeRequest dto.OperationMergeRequest) (int, interface{})
 }
 
 type OperationServiceImpl struct {
 	operationRepository repository.OperationRepository
 	categoryRepository  repository.CategoryRepository
+	accountRepository   repository.AccountRepository
+	transferRepository  repository.TransferRepository
+	tagRepository       repository.TagRepository
+	auditRepository     repository.AuditRepository
+	ruleRepository      repository.RuleRepository
+	payeeRepository     repository.PayeeRepository
 }
 
 var createCategoryOperation dao.Category
 
-func (u OperationServiceImpl) Index(user dao.User) (int, []dto.TransformedOperation) {
-	operations, _ := u.operationRepository.FindOperationsByUser(user)
+var errOperationChangeFailed = errors.New("operation change failed")
+
+func (u OperationServiceImpl) Index(user dao.User, operationFilter dto.OperationFilter) (int, interface{}) {
+	operations, nextCursor, recordError := u.operationRepository.FindOperationsByFilter(user, operationFilter)
+	if errors.Is(recordError, repository.ErrInvalidCursor) {
+		return http.StatusBadRequest, gin.H{"error": "Invalid cursor."}
+	}
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while listing the operations."}
+	}
+
 	transformedResponse := []dto.TransformedOperation{}
 	for _, operation := range operations {
-		category, _ := u.categoryRepository.FindCategoryByOperation(operation)
 		transformed := dto.TransformedOperation{
-			ID:     operation.ID,
-			Type:   operation.Type,
-			Amount: operation.Amount,
-			Date:   operation.Date.In(utcLocation),
+			ID:         operation.ID,
+			Type:       operation.Type,
+			Amount:     operation.Amount,
+			Currency:   operation.Currency,
+			Date:       operation.Date.In(utcLocation),
+			AccountID:  operation.AccountID,
+			TransferID: operation.TransferID,
+			Status:     operation.Status,
+			PayeeID:    operation.PayeeID,
 			Category: dto.TransformedCategory{
-				Name:  category.Name,
-				Color: category.Color,
+				Name:  operation.Category.Name,
+				Color: operation.Category.Color,
 			},
+			Tags: tagNames(operation.Tags),
 		}
 		transformedResponse = append(transformedResponse, transformed)
 	}
 
+	return http.StatusOK, dto.PaginatedOperations{
+		Operations: transformedResponse,
+		NextCursor: nextCursor,
+	}
+}
+
+func (u OperationServiceImpl) Search(user dao.User, searchRequest dto.OperationSearchRequest) (int, interface{}) {
+	rows, recordError := u.operationRepository.SearchOperations(user, searchRequest)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while searching the operations."}
+	}
+
+	transformedResponse := []dto.TransformedSearchOperation{}
+	for _, row := range rows {
+		transformedResponse = append(transformedResponse, dto.TransformedSearchOperation{
+			ID:         row.ID,
+			Type:       row.Type,
+			Amount:     row.Amount,
+			Currency:   row.Currency,
+			Date:       row.Date.In(utcLocation),
+			AccountID:  row.AccountID,
+			TransferID: row.TransferID,
+			Category: dto.TransformedCategory{
+				Name:  row.CategoryName,
+				Color: row.CategoryColor,
+			},
+			Description: row.Description,
+			Rank:        row.Rank,
+			Highlights: dto.OperationHighlights{
+				Description: row.DescriptionHighlight,
+				Category:    row.CategoryHighlight,
+			},
+		})
+	}
 	return http.StatusOK, transformedResponse
 }
 
@@ -54,11 +124,20 @@ func (u OperationServiceImpl) Show(user dao.User, operationID int) (int, interfa
 		return http.StatusNotFound, gin.H{"error": "Not found."}
 	}
 
+	return http.StatusOK, transformShowOperation(operation)
+}
+
+func transformShowOperation(operation dao.Operation) dto.TransformedShowOperation {
 	TransformedOperation := dto.TransformedShowOperation{
 		ID:          operation.ID,
 		Type:        operation.Type,
 		Amount:      operation.Amount,
+		Currency:    operation.Currency,
 		Date:        operation.Date.In(utcLocation),
+		AccountID:   operation.AccountID,
+		TransferID:  operation.TransferID,
+		Status:      operation.Status,
+		PayeeID:     operation.PayeeID,
 		Description: operation.Description,
 		Category: dto.TransformedShowCategory{
 			Name:        operation.Category.Name,
@@ -66,58 +145,212 @@ func (u OperationServiceImpl) Show(user dao.User, operationID int) (int, interfa
 			Description: operation.Category.Description,
 			IsDefault:   operation.Category.IsDefault,
 		},
+		Splits: []dto.TransformedSplit{},
+		Tags:   tagNames(operation.Tags),
+	}
+	for _, split := range operation.Splits {
+		TransformedOperation.Splits = append(TransformedOperation.Splits, dto.TransformedSplit{
+			Category: dto.TransformedCategory{
+				Name:  split.Category.Name,
+				Color: split.Category.Color,
+			},
+			Amount: split.Amount,
+			Note:   split.Note,
+		})
 	}
 
-	return http.StatusOK, TransformedOperation
+	return TransformedOperation
 }
 
+// Create creates the operation, warning in the response when it looks like a
+// duplicate of one already recorded.
 func (u OperationServiceImpl) Create(user dao.User, operationRequest dto.OperationRequest) (int, interface{}) {
+	code, response, operationID := u.inTransaction(gin.H{"error": "An error occurred in the creation of the operation."}, func(service OperationServiceImpl) (int, interface{}, int) {
+		return service.createOperation(user, operationRequest)
+	})
+	if code != http.StatusCreated {
+		return code, response
+	}
+	if duplicateIDs := u.duplicateIDs(user, operationID); len(duplicateIDs) > 0 {
+		return code, gin.H{
+			"message":       "Operation successfully created.",
+			"warning":       "The operation looks like a duplicate.",
+			"duplicate_ids": duplicateIDs,
+		}
+	}
+	return code, response
+}
+
+// createOperation creates the operation, after the user's rules fill in its
+// category, tags and description and its payee is matched from the
+// description when none is given, and also returns its id, zero when it is
+// not created.
+func (u OperationServiceImpl) createOperation(user dao.User, operationRequest dto.OperationRequest) (int, interface{}, int) {
+	rules, recordError := u.ruleRepository.FindRulesByUser(user)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the operation."}, 0
+	}
+	operationRequest = newRuleSet(rules).applyToRequest(operationRequest)
+
 	if invalidCategoryID(operationRequest.CategoryID, u.categoryRepository) {
-		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid category."}
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid category."}, 0
+	}
+
+	invalidAccount, account := invalidAccountID(user, operationRequest.AccountID, u.accountRepository)
+	if invalidAccount {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid account."}, 0
+	}
+
+	invalidPayee, payeeID := invalidPayeeID(user, operationRequest.PayeeID, u.payeeRepository)
+	if invalidPayee {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid payee."}, 0
+	}
+	if payeeID == nil {
+		payees, recordError := u.payeeRepository.FindPayeesByUser(user)
+		if recordError != nil {
+			return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the operation."}, 0
+		}
+		payeeID = newPayeeMatcher(payees).match(operationRequest.Description)
+	}
+
+	splits, splitsError := buildOperationSplits(operationRequest, u.categoryRepository)
+	if splitsError != nil {
+		return http.StatusUnprocessableEntity, splitsError, 0
+	}
+
+	tags, recordError := u.tagRepository.FindOrCreateTags(user, NormalizeTags(operationRequest.Tags))
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the operation."}, 0
 	}
 
 	dateOperation, _ := time.Parse(time.RFC3339, operationRequest.Date)
 
 	operationDao := dao.Operation{
+		Splits:      splits,
+		Tags:        tags,
 		Type:        operationRequest.Type,
 		Amount:      operationRequest.Amount,
+		Currency:    accountOperationCurrency(user, account, operationRequest.Currency),
 		Date:        dateOperation,
+		AccountID:   &account.ID,
 		Category:    createCategoryOperation,
 		Description: operationRequest.Description,
+		Status:      operationRequest.Status,
+		PayeeID:     payeeID,
 		UserID:      uint(user.ID),
 	}
+	if operationDao.Status == "" {
+		operationDao.Status = dto.OperationStatusPending
+	}
 
-	_, recordError := u.operationRepository.Save(&operationDao)
+	_, recordError = u.operationRepository.Save(&operationDao)
+	if recordError == nil {
+		recordError = u.audit(newAuditEntry(user, dto.AuditRecordOperation, operationDao.ID, dto.AuditActionCreate, nil, operationSnapshot(operationDao)))
+	}
 	if recordError != nil {
-		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the operation."}
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the operation."}, 0
 	}
 
-	return http.StatusCreated, gin.H{"message": "Operation successfully created."}
+	return http.StatusCreated, gin.H{"message": "Operation successfully created."}, operationDao.ID
 }
 
-func (u OperationServiceImpl) Update(user dao.User, operationRequest dto.OperationRequest, operationID int) (int, interface{}) {
+// Update saves the operation as requested. A non empty ifMatch must match the
+// ETag of the stored operation.
+func (u OperationServiceImpl) Update(user dao.User, operationRequest dto.OperationRequest, operationID int, ifMatch string) (int, interface{}) {
+	code, response, _ := u.inTransaction(gin.H{"error": "An error occurred in the update of the operation."}, func(service OperationServiceImpl) (int, interface{}, int) {
+		code, response := service.updateOperation(user, operationRequest, operationID, dto.AuditActionUpdate, ifMatch)
+		return code, response, operationID
+	})
+	return code, response
+}
+
+// updateOperation saves the operation as requested and records the change
+// under the given audit action. It is saved only if it did not change since
+// it was loaded, and since the ETag in ifMatch when one is given. Reconciled
+// operations are not changed.
+func (u OperationServiceImpl) updateOperation(user dao.User, operationRequest dto.OperationRequest, operationID int, action string, ifMatch string) (int, interface{}) {
 	invalidOperationID, operation := validateOperationID(operationID, user, u.operationRepository)
 	if invalidOperationID {
 		return http.StatusNotFound, gin.H{"error": "Not found."}
 	}
+	if operationModified(operation, ifMatch) {
+		return http.StatusPreconditionFailed, gin.H{"error": "The operation has been modified."}
+	}
+	if operation.Status == dto.OperationStatusReconciled {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Reconciled operations cannot be changed."}
+	}
+
+	if operation.TransferID != nil {
+		return u.updateTransferOperation(user, operationRequest, operation, action)
+	}
 
 	if invalidCategoryID(operationRequest.CategoryID, u.categoryRepository) {
 		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid category."}
 	}
 
+	accountID := operationRequest.AccountID
+	if accountID == "" && operation.AccountID != nil {
+		accountID = strconv.Itoa(*operation.AccountID)
+	}
+	invalidAccount, account := invalidAccountID(user, accountID, u.accountRepository)
+	if invalidAccount {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid account."}
+	}
+
+	// An operation updated without a payee keeps the one it has.
+	invalidPayee, payeeID := invalidPayeeID(user, operationRequest.PayeeID, u.payeeRepository)
+	if invalidPayee {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid payee."}
+	}
+	if payeeID == nil {
+		payeeID = operation.PayeeID
+	}
+
+	splits, splitsError := buildOperationSplits(operationRequest, u.categoryRepository)
+	if splitsError != nil {
+		return http.StatusUnprocessableEntity, splitsError
+	}
+
+	// Tags left out of the request are kept, an empty list clears them.
+	tags := operation.Tags
+	if operationRequest.Tags != nil {
+		var recordError error
+		tags, recordError = u.tagRepository.FindOrCreateTags(user, NormalizeTags(operationRequest.Tags))
+		if recordError != nil {
+			return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the operation."}
+		}
+	}
+
 	dateOperation, _ := time.Parse(time.RFC3339, operationRequest.Date)
 
+	// The bank id and the schedule that created the operation are kept, so it
+	// is still recognized when its statement is imported again.
 	operationDao := dao.Operation{
-		ID:          operation.ID,
-		Type:        operationRequest.Type,
-		Amount:      operationRequest.Amount,
-		Date:        dateOperation,
-		Category:    createCategoryOperation,
-		Description: operationRequest.Description,
-		UserID:      uint(user.ID),
+		ID:                   operation.ID,
+		Splits:               splits,
+		Tags:                 tags,
+		Type:                 operationRequest.Type,
+		Amount:               operationRequest.Amount,
+		Currency:             accountOperationCurrency(user, account, operationRequest.Currency),
+		Date:                 dateOperation,
+		AccountID:            &account.ID,
+		Category:             createCategoryOperation,
+		Description:          operationRequest.Description,
+		Status:               operationRequest.Status,
+		PayeeID:              payeeID,
+		UserID:               uint(user.ID),
+		Version:              operation.Version,
+		ExternalID:           operation.ExternalID,
+		RecurringOperationID: operation.RecurringOperationID,
 	}
 
 	_, recordError := u.operationRepository.Update(&operationDao)
+	if errors.Is(recordError, repository.ErrVersionConflict) {
+		return http.StatusPreconditionFailed, gin.H{"error": "The operation has been modified."}
+	}
+	if recordError == nil {
+		recordError = u.audit(newAuditEntry(user, dto.AuditRecordOperation, operation.ID, action, operationSnapshot(operation), operationSnapshot(operationDao)))
+	}
 	if recordError != nil {
 		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the operation."}
 	}
@@ -125,14 +358,92 @@ func (u OperationServiceImpl) Update(user dao.User, operationRequest dto.Operati
 	return http.StatusOK, gin.H{"message": "Operation successfully updated."}
 }
 
-func (u OperationServiceImpl) Delete(user dao.User, operationID int) (int, interface{}) {
+// Patch changes only the fields the patch sets. The stored operation is turned
+// into a request, the patch is applied to it and the result is saved as an
+// update, returning the updated operation.
+func (u OperationServiceImpl) Patch(user dao.User, operationID int, ifMatch string, applyPatch func(operationRequest dto.OperationRequest) (dto.OperationRequest, error)) (int, interface{}) {
+	code, response, _ := u.inTransaction(gin.H{"error": "An error occurred in the update of the operation."}, func(service OperationServiceImpl) (int, interface{}, int) {
+		invalidOperationID, operation := validateOperationID(operationID, user, service.operationRepository)
+		if invalidOperationID {
+			return http.StatusNotFound, gin.H{"error": "Not found."}, 0
+		}
+		if operationModified(operation, ifMatch) {
+			return http.StatusPreconditionFailed, gin.H{"error": "The operation has been modified."}, 0
+		}
+		if operation.Status == dto.OperationStatusReconciled {
+			return http.StatusUnprocessableEntity, gin.H{"error": "Reconciled operations cannot be changed."}, 0
+		}
+
+		operationRequest, patchError := applyPatch(operationSnapshotRequest(*operationSnapshot(operation)))
+		if errors.Is(patchError, patch.ErrTestFailed) {
+			return http.StatusConflict, gin.H{"error": "The patch test failed."}, 0
+		}
+		if patchError != nil {
+			return http.StatusBadRequest, gin.H{"error": "Invalid parameters."}, 0
+		}
+
+		code, response := service.updateOperation(user, operationRequest, operationID, dto.AuditActionUpdate, ifMatch)
+		if code != http.StatusOK {
+			return code, response, 0
+		}
+		code, response = service.Show(user, operationID)
+		return code, response, operationID
+	})
+	return code, response
+}
+
+func (u OperationServiceImpl) Delete(user dao.User, operationID int, ifMatch string) (int, interface{}) {
+	code, response, _ := u.inTransaction(gin.H{"error": "An error occurred while deleting the operation."}, func(service OperationServiceImpl) (int, interface{}, int) {
+		code, response := service.deleteOperation(user, operationID, ifMatch)
+		return code, response, operationID
+	})
+	return code, response
+}
+
+// deleteOperation moves the operation to the trash. Deleting a side of a
+// transfer deletes the whole transfer, so both sides are recorded as deleted.
+// Reconciled operations, and transfers with a reconciled side, are kept.
+func (u OperationServiceImpl) deleteOperation(user dao.User, operationID int, ifMatch string) (int, interface{}) {
 	invalidOperationID, operation := validateOperationID(operationID, user, u.operationRepository)
 
 	if invalidOperationID {
 		return http.StatusNotFound, gin.H{"error": "Not found."}
 	}
+	if operationModified(operation, ifMatch) {
+		return http.StatusPreconditionFailed, gin.H{"error": "The operation has been modified."}
+	}
+	if operation.Status == dto.OperationStatusReconciled {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Reconciled operations cannot be changed."}
+	}
+
+	if operation.TransferID != nil {
+		transfer, recordError := u.transferRepository.FindTransferByUserAndId(user, *operation.TransferID)
+		if recordError == nil && transferReconciled(transfer) {
+			return http.StatusUnprocessableEntity, gin.H{"error": "Reconciled operations cannot be changed."}
+		}
+		if recordError == nil {
+			_, recordError = u.transferRepository.Delete(&transfer)
+		}
+		if recordError == nil {
+			entries := []dao.AuditEntry{}
+			for _, transferOperation := range transfer.Operations {
+				entries = append(entries, newAuditEntry(user, dto.AuditRecordOperation, transferOperation.ID, dto.AuditActionDelete, operationSnapshot(transferOperation), nil))
+			}
+			recordError = u.audit(entries...)
+		}
+		if recordError != nil {
+			return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while deleting the operation."}
+		}
+		return http.StatusOK, gin.H{"message": "Operation successfully deleted."}
+	}
 
 	_, recordError := u.operationRepository.Delete(&operation)
+	if errors.Is(recordError, repository.ErrVersionConflict) {
+		return http.StatusPreconditionFailed, gin.H{"error": "The operation has been modified."}
+	}
+	if recordError == nil {
+		recordError = u.audit(newAuditEntry(user, dto.AuditRecordOperation, operation.ID, dto.AuditActionDelete, operationSnapshot(operation), nil))
+	}
 	if recordError != nil {
 		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while deleting the operation."}
 	}
@@ -140,6 +451,233 @@ func (u OperationServiceImpl) Delete(user dao.User, operationID int) (int, inter
 	return http.StatusOK, gin.H{"message": "Operation successfully deleted."}
 }
 
+// History lists the changes of the operation, the latest first. It is kept
+// after the operation is deleted.
+func (u OperationServiceImpl) History(user dao.User, operationID int) (int, interface{}) {
+	entries, recordError := u.auditRepository.FindAuditEntriesByRecord(user, dto.AuditRecordOperation, operationID)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while listing the history of the operation."}
+	}
+	if len(entries) == 0 {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	return http.StatusOK, formatAuditEntries(entries)
+}
+
+// Revert brings the operation back to the version it had right after the
+// given change. The revert is itself recorded as a change.
+func (u OperationServiceImpl) Revert(user dao.User, operationID int, entryID int) (int, interface{}) {
+	entry, recordError := u.auditRepository.FindAuditEntryByRecordAndId(user, dto.AuditRecordOperation, operationID, entryID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	var version dto.OperationSnapshot
+	if entry.After == nil || json.Unmarshal([]byte(*entry.After), &version) != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "The version cannot be restored."}
+	}
+
+	code, response, _ := u.inTransaction(gin.H{"error": "An error occurred in the update of the operation."}, func(service OperationServiceImpl) (int, interface{}, int) {
+		code, response := service.updateOperation(user, operationSnapshotRequest(version), operationID, dto.AuditActionRevert, "")
+		return code, response, operationID
+	})
+	if code == http.StatusOK {
+		return code, gin.H{"message": "Operation successfully reverted."}
+	}
+	return code, response
+}
+
+// inTransaction makes the change with the service bound to one transaction, so
+// that the change and its audit entries are saved together or not at all.
+func (u OperationServiceImpl) inTransaction(errorResponse gin.H, change func(service OperationServiceImpl) (int, interface{}, int)) (int, interface{}, int) {
+	var code, id int
+	var response interface{}
+	recordError := u.operationRepository.Transaction(func(repositories repository.OperationRepositories) error {
+		code, response, id = change(u.withRepositories(repositories))
+		if code >= http.StatusBadRequest {
+			return errOperationChangeFailed
+		}
+		return nil
+	})
+	if recordError != nil && !errors.Is(recordError, errOperationChangeFailed) {
+		return http.StatusUnprocessableEntity, errorResponse, 0
+	}
+	return code, response, id
+}
+
+func (u OperationServiceImpl) audit(entries ...dao.AuditEntry) error {
+	_, recordError := u.auditRepository.SaveAll(entries)
+	return recordError
+}
+
+// operationSnapshotRequest turns a version of an operation back into the
+// request that saves it.
+func operationSnapshotRequest(version dto.OperationSnapshot) dto.OperationRequest {
+	operationRequest := dto.OperationRequest{
+		Type:        version.Type,
+		Amount:      version.Amount,
+		Currency:    version.Currency,
+		Date:        version.Date.In(utcLocation).Format(time.RFC3339Nano),
+		Description: version.Description,
+		Tags:        version.Tags,
+		Status:      version.Status,
+	}
+	if version.CategoryID != nil {
+		operationRequest.CategoryID = strconv.Itoa(*version.CategoryID)
+	}
+	if version.AccountID != nil {
+		operationRequest.AccountID = strconv.Itoa(*version.AccountID)
+	}
+	if version.PayeeID != nil {
+		operationRequest.PayeeID = strconv.Itoa(*version.PayeeID)
+	}
+	for _, split := range version.Splits {
+		operationRequest.Splits = append(operationRequest.Splits, dto.SplitRequest{
+			CategoryID: strconv.Itoa(split.CategoryID),
+			Amount:     split.Amount,
+			Note:       split.Note,
+		})
+	}
+	if operationRequest.Splits == nil {
+		operationRequest.Splits = []dto.SplitRequest{}
+	}
+	if operationRequest.Tags == nil {
+		operationRequest.Tags = []string{}
+	}
+	return operationRequest
+}
+
+// updateTransferOperation edits one side of a transfer and carries the date and
+// description over to the other side. The amount is shared by both sides when
+// they are in the same currency.
+func (u OperationServiceImpl) updateTransferOperation(user dao.User, operationRequest dto.OperationRequest, operation dao.Operation, action string) (int, interface{}) {
+	transfer, recordError := u.transferRepository.FindTransferByUserAndId(user, *operation.TransferID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	if transferReconciled(transfer) {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Reconciled operations cannot be changed."}
+	}
+
+	sameCurrency := true
+	for _, transferOperation := range transfer.Operations {
+		sameCurrency = sameCurrency && transferOperation.Currency == operation.Currency
+	}
+	if operation.Type == EXPENSE_TYPE || sameCurrency {
+		transfer.Amount = operationRequest.Amount
+	}
+	if operation.Type == INCOME_TYPE || sameCurrency {
+		transfer.ToAmount = operationRequest.Amount
+	}
+	transfer.Date, _ = time.Parse(time.RFC3339, operationRequest.Date)
+	transfer.Description = operationRequest.Description
+	syncTransferOperations(&transfer)
+	for index := range transfer.Operations {
+		if transfer.Operations[index].ID == operation.ID {
+			transfer.Operations[index].Version = operation.Version
+			if operationRequest.Status != "" {
+				transfer.Operations[index].Status = operationRequest.Status
+			}
+		}
+	}
+
+	_, recordError = u.transferRepository.Update(&transfer)
+	if errors.Is(recordError, repository.ErrVersionConflict) {
+		return http.StatusPreconditionFailed, gin.H{"error": "The operation has been modified."}
+	}
+	if recordError == nil {
+		updatedOperation := operation
+		for _, transferOperation := range transfer.Operations {
+			if transferOperation.ID == operation.ID {
+				updatedOperation.Amount = transferOperation.Amount
+				updatedOperation.Date = transferOperation.Date
+				updatedOperation.Description = transferOperation.Description
+				updatedOperation.Status = transferOperation.Status
+			}
+		}
+		recordError = u.audit(newAuditEntry(user, dto.AuditRecordOperation, operation.ID, action, operationSnapshot(operation), operationSnapshot(updatedOperation)))
+	}
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the operation."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Operation successfully updated."}
+}
+
+func operationCurrency(user dao.User, currency string) string {
+	if currency == "" {
+		return userBaseCurrency(user)
+	}
+	return strings.ToUpper(currency)
+}
+
+// accountOperationCurrency defaults the currency of an operation to the one of
+// its account.
+func accountOperationCurrency(user dao.User, account dao.Account, currency string) string {
+	if currency == "" {
+		currency = account.Currency
+	}
+	return operationCurrency(user, currency)
+}
+
+// invalidAccountID resolves the account of an operation, falling back to the
+// user's default account when none is given.
+func invalidAccountID(user dao.User, accountID string, accountRepository repository.AccountRepository) (bool, dao.Account) {
+	if accountID == "" {
+		account, errFindAccount := accountRepository.FindDefaultAccount(user)
+		return errFindAccount != nil, account
+	}
+	accountIdInt, errParseInt := strconv.Atoi(accountID)
+	if errParseInt != nil {
+		return true, dao.Account{}
+	}
+	account, errFindAccount := accountRepository.FindAccountByUserAndId(user, accountIdInt)
+	return errFindAccount != nil, account
+}
+
+// buildOperationSplits validates the category of every split and that the splits
+// add up to the amount of the operation.
+func buildOperationSplits(operationRequest dto.OperationRequest, categoryRepository repository.CategoryRepository) ([]dao.OperationSplit, gin.H) {
+	splits := []dao.OperationSplit{}
+	var total money.Amount
+	for _, splitRequest := range operationRequest.Splits {
+		categoryIdInt, errParseInt := strconv.Atoi(splitRequest.CategoryID)
+		if errParseInt != nil {
+			return nil, gin.H{"error": "Invalid category."}
+		}
+		category, errFindCategory := categoryRepository.FindCategoryById(categoryIdInt)
+		if errFindCategory != nil {
+			return nil, gin.H{"error": "Invalid category."}
+		}
+		splits = append(splits, dao.OperationSplit{
+			CategoryID: category.ID,
+			Amount:     splitRequest.Amount,
+			Note:       splitRequest.Note,
+		})
+		total += splitRequest.Amount
+	}
+	if len(splits) > 0 && total != operationRequest.Amount {
+		return nil, gin.H{"error": "The splits must add up to the amount of the operation."}
+	}
+	return splits, nil
+}
+
+// invalidPayeeID resolves the payee of an operation, which is optional.
+func invalidPayeeID(user dao.User, payeeID string, payeeRepository repository.PayeeRepository) (bool, *int) {
+	if payeeID == "" {
+		return false, nil
+	}
+	payeeIdInt, errParseInt := strconv.Atoi(payeeID)
+	if errParseInt != nil {
+		return true, nil
+	}
+	payee, errFindPayee := payeeRepository.FindPayeeByUserAndId(user, payeeIdInt)
+	if errFindPayee != nil {
+		return true, nil
+	}
+	return false, &payee.ID
+}
+
 func invalidCategoryID(categoryID string, categoryRepository repository.CategoryRepository) bool {
 	if categoryID == "" {
 		return true
@@ -153,14 +691,26 @@ func invalidCategoryID(categoryID string, categoryRepository repository.Category
 	return errFindCategory != nil
 }
 
+// operationModified reports whether the operation no longer matches the ETag
+// in ifMatch. An empty ifMatch makes the change unconditional.
+func operationModified(operation dao.Operation, ifMatch string) bool {
+	return ifMatch != "" && !ETagMatches(ifMatch, ETag(transformShowOperation(operation)), false)
+}
+
 func validateOperationID(operationID int, user dao.User, operationRepository repository.OperationRepository) (bool, dao.Operation) {
 	operation, errFindOperation := operationRepository.FindOperationByUserAndId(user, operationID)
 	return errFindOperation != nil, operation
 }
 
-func OperationServiceInit(operationRepository repository.OperationRepository, categoryRepository repository.CategoryRepository) *OperationServiceImpl {
+func OperationServiceInit(operationRepository repository.OperationRepository, categoryRepository repository.CategoryRepository, accountRepository repository.AccountRepository, transferRepository repository.TransferRepository, tagRepository repository.TagRepository, auditRepository repository.AuditRepository, ruleRepository repository.RuleRepository, payeeRepository repository.PayeeRepository) *OperationServiceImpl {
 	return &OperationServiceImpl{
 		operationRepository: operationRepository,
 		categoryRepository:  categoryRepository,
+		accountRepository:   accountRepository,
+		transferRepository:  transferRepository,
+		tagRepository:       tagRepository,
+		auditRepository:     auditRepository,
+		ruleRepository:      ruleRepository,
+		payeeRepository:     payeeRepository,
 	}
 }
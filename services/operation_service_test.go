@@ -3,11 +3,16 @@ package services
 import (
 	dao "GoGin-API-CuentasClaras/dao"
 	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"GoGin-API-CuentasClaras/patch"
+	"GoGin-API-CuentasClaras/repository"
 	testhelpers "GoGin-API-CuentasClaras/test_helpers"
 	"errors"
 	"net/http"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 type MockOperationRepositoryOperations struct{}
@@ -18,14 +23,16 @@ func (u MockOperationRepositoryOperations) FindOperationsByUser(user dao.User) (
 	if user.ID == 1 {
 		operations := []dao.Operation{}
 		user.Operations = append(operations, dao.Operation{
-			ID:     1,
-			Type:   "income",
-			Amount: 1200.5,
-			Date:   date,
+			ID:       1,
+			Type:     "income",
+			Amount:   money.FromFloat(1200.5),
+			Currency: "ARS",
+			Date:     date,
 			Category: dao.Category{
 				Name:  "Work",
 				Color: "#fdg123",
 			},
+			Tags: []dao.Tag{{ID: 1, Name: "reimbursable"}},
 		})
 	} else if user.ID == 2 {
 		user.Operations = []dao.Operation{}
@@ -33,15 +40,38 @@ func (u MockOperationRepositoryOperations) FindOperationsByUser(user dao.User) (
 	return user.Operations, nil
 }
 
+func (u MockOperationRepositoryOperations) FindOperationsByFilter(user dao.User, filter dto.OperationFilter) ([]dao.Operation, string, error) {
+	if filter.Cursor == "invalid" {
+		return nil, "", repository.ErrInvalidCursor
+	}
+	if user.ID == 3 {
+		return nil, "", errors.New("Database error.")
+	}
+	if filter.Cursor != "" {
+		return []dao.Operation{}, "", nil
+	}
+	if user.ID == 4 {
+		operation, _ := u.FindOperationByUserAndId(user, 10)
+		return []dao.Operation{operation}, "", nil
+	}
+	operations, _ := u.FindOperationsByUser(user)
+	nextCursor := ""
+	if len(operations) > 0 {
+		nextCursor = "next"
+	}
+	return operations, nextCursor, nil
+}
+
 func (u MockOperationRepositoryOperations) FindOperationByUserAndId(user dao.User, operationID int) (dao.Operation, error) {
 	date, _ := time.Parse(time.RFC3339, "2023-10-23T21:33:03.73297-03:00")
 
 	if operationID == 1 || operationID == 3 {
 		return dao.Operation{
-			ID:     operationID,
-			Type:   "income",
-			Amount: 1200.5,
-			Date:   date,
+			ID:       operationID,
+			Type:     "income",
+			Amount:   money.FromFloat(1200.5),
+			Currency: "ARS",
+			Date:     date,
 			Category: dao.Category{
 				Name:        "Work",
 				Color:       "#fdg123",
@@ -49,23 +79,130 @@ func (u MockOperationRepositoryOperations) FindOperationByUserAndId(user dao.Use
 				IsDefault:   true,
 			},
 			Description: "Salario",
+			Splits: []dao.OperationSplit{
+				{ID: 1, CategoryID: 1, Category: dao.Category{Name: "Work", Color: "#fdg123"}, Amount: money.FromFloat(1000), Note: "Salary"},
+				{ID: 2, CategoryID: 4, Category: dao.Category{Name: "Bonus", Color: "#6495ed"}, Amount: money.FromFloat(200.5), Note: "Bonus"},
+			},
+			Tags: []dao.Tag{{ID: 1, Name: "reimbursable"}, {ID: 2, Name: "vacation-2026"}},
 		}, nil
+	} else if operationID == 7 {
+		categoryID := 1
+		return dao.Operation{ID: 7, Type: "expense", Amount: money.FromFloat(35.9), Currency: "ARS", Date: date, CategoryID: &categoryID, Version: 2}, nil
+	} else if operationID == 10 {
+		categoryID := 1
+		return dao.Operation{ID: 10, Type: "expense", Amount: money.FromFloat(15), Currency: "ARS", Date: date, CategoryID: &categoryID, Description: "UBER TRIP 1234", Tags: []dao.Tag{}}, nil
+	} else if operationID == 12 {
+		categoryID := 1
+		return dao.Operation{ID: 12, Type: "expense", Amount: money.FromFloat(80), Currency: "ARS", Date: date, CategoryID: &categoryID, Status: dto.OperationStatusReconciled}, nil
+	} else if operationID == 5 || operationID == 6 {
+		transferID := map[int]int{5: 1, 6: 3}[operationID]
+		return dao.Operation{ID: operationID, Type: "expense", Amount: money.FromFloat(3500), Currency: "ARS", Date: date, TransferID: &transferID}, nil
 	} else {
 		return dao.Operation{}, errors.New("Operation not found.")
 	}
 }
 
+func (u MockOperationRepositoryOperations) FindOperationsBetween(user dao.User, from time.Time, to time.Time) ([]dao.Operation, error) {
+	if user.ID == 3 {
+		return nil, errors.New("Database error.")
+	}
+	date, _ := time.Parse(time.RFC3339, "2023-10-20T15:04:05Z")
+	accountID := 1
+	return []dao.Operation{
+		{ID: 7, Type: "expense", Amount: money.FromFloat(50), Currency: "ARS", Date: date, AccountID: &accountID, Description: "Coffee shop"},
+	}, nil
+}
+
+func (u MockOperationRepositoryOperations) FindOperationsByExternalIDs(user dao.User, externalIDs []string) ([]dao.Operation, error) {
+	date, _ := time.Parse(time.RFC3339, "2023-10-18T00:00:00Z")
+	accountID := 1
+	return []dao.Operation{
+		{ID: 8, Type: "expense", Amount: money.FromFloat(120.25), Currency: "ARS", Date: date, AccountID: &accountID, Description: "Supermarket", ExternalID: "20231018001"},
+	}, nil
+}
+
+func (u MockOperationRepositoryOperations) StreamOperationsByFilter(user dao.User, filter dto.OperationFilter, callback func(dto.OperationExportRow) error) error {
+	if user.ID == 3 {
+		return errors.New("Database error.")
+	}
+	date, _ := time.Parse(time.RFC3339, "2023-10-20T15:04:05Z")
+	workID, groceriesID, accountID, transferID := 1, 5, 1, 1
+	rows := []dto.OperationExportRow{
+		{ID: 1, Date: date, Type: "income", Amount: money.FromFloat(1200.5), Currency: "ARS", CategoryID: &workID, CategoryName: "Work", AccountID: &accountID, Description: "Salary, October"},
+		{ID: 2, Date: date.AddDate(0, 0, 1), Type: "expense", Amount: money.FromFloat(35.9), Currency: "USD", CategoryID: &groceriesID, CategoryName: "Groceries", AccountID: &accountID, Description: "Supermarket & bakery", ExternalID: "A-1"},
+		{ID: 5, Date: date.AddDate(0, 0, 2), Type: "expense", Amount: money.FromFloat(3500), Currency: "ARS", AccountID: &accountID, TransferID: &transferID},
+	}
+	for _, row := range rows {
+		if err := callback(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u MockOperationRepositoryOperations) SearchOperations(user dao.User, search dto.OperationSearchRequest) ([]dto.OperationSearchRow, error) {
+	if user.ID == 3 {
+		return nil, errors.New("Database error.")
+	}
+	if user.ID == 2 {
+		return []dto.OperationSearchRow{}, nil
+	}
+	date, _ := time.Parse(time.RFC3339, "2023-10-20T15:04:05Z")
+	accountID := 1
+	return []dto.OperationSearchRow{
+		{ID: 9, Type: "expense", Amount: money.FromFloat(8500), Currency: "ARS", Date: date, AccountID: &accountID, Description: "Pago al plomero", CategoryName: "Home", CategoryColor: "#6495ed", Rank: 0.0607927, DescriptionHighlight: "Pago al <mark>plomero</mark>", CategoryHighlight: "Home repairs"},
+	}, nil
+}
+
+func (u MockOperationRepositoryOperations) FindDuplicateOperations(user dao.User, filter dto.OperationDuplicateFilter) ([]dto.OperationDuplicateRow, error) {
+	if user.ID == 3 {
+		return nil, errors.New("Database error.")
+	}
+	date, _ := time.Parse(time.RFC3339, "2023-10-20T15:04:05Z")
+	if filter.OperationID == 11 {
+		return []dto.OperationDuplicateRow{
+			{OperationID: 7, DuplicateID: 11, Type: "expense", Amount: money.FromFloat(50), Currency: "ARS", OperationDate: date, DuplicateDate: date, OperationDescription: "Coffee shop", DuplicateDescription: "Coffee shop"},
+		}, nil
+	}
+	if user.ID == 2 || filter.OperationID != 0 {
+		return []dto.OperationDuplicateRow{}, nil
+	}
+	return []dto.OperationDuplicateRow{
+		{OperationID: 7, DuplicateID: 8, Type: "expense", Amount: money.FromFloat(50), Currency: "ARS", OperationDate: date, DuplicateDate: date.AddDate(0, 0, 1), OperationDescription: "Coffee shop", DuplicateDescription: "COFFEE SHOP downtown"},
+		{OperationID: 1, DuplicateID: 3, Type: "income", Amount: money.FromFloat(1200.5), Currency: "ARS", OperationDate: date, DuplicateDate: date, OperationDescription: "Salario", DuplicateDescription: "Refund"},
+	}, nil
+}
+
+func (u MockOperationRepositoryOperations) SaveAll(operations []dao.Operation) ([]dao.Operation, error) {
+	for _, operation := range operations {
+		if operation.Description == "Payment for work" {
+			return nil, errors.New("Invalid operation.")
+		}
+	}
+	return operations, nil
+}
+
 func (u MockOperationRepositoryOperations) Save(operation *dao.Operation) (dao.Operation, error) {
 	if operation.Description == "Payment for work" {
 		return dao.Operation{}, errors.New("Invalid operation.")
 	}
-	return dao.Operation{}, nil
+	if operation.Description == "CARREFOUR 123" && (operation.PayeeID == nil || *operation.PayeeID != 1) {
+		return dao.Operation{}, errors.New("Invalid payee.")
+	}
+	operation.ID = 10
+	if operation.Description == "Coffee shop" {
+		operation.ID = 11
+	}
+	return *operation, nil
 }
 
 func (u MockOperationRepositoryOperations) Update(operation *dao.Operation) (dao.Operation, error) {
 	if operation.ID == 3 {
 		return dao.Operation{}, errors.New("Invalid operation.")
 	}
+	if operation.Version == 2 {
+		return dao.Operation{}, repository.ErrVersionConflict
+	}
 	return dao.Operation{}, nil
 }
 
@@ -73,9 +210,23 @@ func (u MockOperationRepositoryOperations) Delete(operation *dao.Operation) (dao
 	if operation.ID == 3 {
 		return dao.Operation{}, errors.New("Invalid operation.")
 	}
+	if operation.Version == 2 {
+		return dao.Operation{}, repository.ErrVersionConflict
+	}
 	return dao.Operation{}, nil
 }
 
+func (u MockOperationRepositoryOperations) MoveAttachments(from dao.Operation, to dao.Operation) error {
+	if from.ID == 7 {
+		return errors.New("Attachments not moved.")
+	}
+	return nil
+}
+
+func (u MockOperationRepositoryOperations) Transaction(callback func(repositories repository.OperationRepositories) error) error {
+	return callback(repository.OperationRepositories{Operations: u, Transfers: &MockTransferRepository{}, Tags: &MockTagRepository{}, Audits: &MockAuditRepository{}})
+}
+
 type MockCategoryRepositoryOperations struct{}
 
 func (u MockCategoryRepositoryOperations) FindCategoryByOperation(operation dao.Operation) (dao.Category, error) {
@@ -94,11 +245,15 @@ func (u MockCategoryRepositoryOperations) FindCategoryById(id int) (dao.Category
 }
 
 func (u MockCategoryRepositoryOperations) FindCategoriesByUser(user dao.User) ([]dao.Category, error) {
-	return []dao.Category{}, nil
+	return []dao.Category{{ID: 4, Name: "Groceries"}}, nil
+}
+
+func (u MockCategoryRepositoryOperations) Transaction(callback func(repositories repository.CategoryRepositories) error) error {
+	return callback(repository.CategoryRepositories{Categories: u, Audits: &MockAuditRepository{}})
 }
 
 func (u MockCategoryRepositoryOperations) FindDefaultCategories() ([]dao.Category, error) {
-	return []dao.Category{}, nil
+	return []dao.Category{{ID: 1, Name: "Work"}, {ID: 5, Name: "Groceries"}}, nil
 }
 
 func (u MockCategoryRepositoryOperations) FindCategoryByUserAndId(user dao.User, categoryID int) (dao.Category, error) {
@@ -119,20 +274,32 @@ func (u MockCategoryRepositoryOperations) Delete(category *dao.Category) (dao.Ca
 func TestOperationServiceImpl_Index(t *testing.T) {
 	operationRepository := &MockOperationRepositoryOperations{}
 	categoryRepository := &MockCategoryRepositoryOperations{}
-	operationService := OperationServiceInit(operationRepository, categoryRepository)
+	operationService := OperationServiceInit(operationRepository, categoryRepository, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
 
 	var tests = []testhelpers.TestInterfaceStructure{
 		{
 			Name:         "when the user has operations",
-			Params:       "",
+			Params:       dto.OperationFilter{},
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "[{\"id\":1,\"type\":\"income\",\"amount\":1200.5,\"date\":\"2023-10-24T00:33:03.73297Z\",\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"}}]",
+			ExpectedBody: "{\"operations\":[{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-24T00:33:03.73297Z\",\"account_id\":null,\"transfer_id\":null,\"status\":\"\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"tags\":[\"reimbursable\"]}],\"next_cursor\":\"next\"}",
 		},
 		{
 			Name:         "when the user has no operations",
-			Params:       "",
+			Params:       dto.OperationFilter{},
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "[]",
+			ExpectedBody: "{\"operations\":[],\"next_cursor\":\"\"}",
+		},
+		{
+			Name:         "when the cursor is invalid",
+			Params:       dto.OperationFilter{Cursor: "invalid"},
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid cursor.\"}",
+		},
+		{
+			Name:         "when there is an error listing the operations",
+			Params:       dto.OperationFilter{},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while listing the operations.\"}",
 		},
 	}
 	for _, tt := range tests {
@@ -141,9 +308,51 @@ func TestOperationServiceImpl_Index(t *testing.T) {
 
 			if tt.Name == "when the user has no operations" {
 				user = dao.User{ID: 2}
+			} else if tt.Name == "when there is an error listing the operations" {
+				user = dao.User{ID: 3}
 			}
 
-			code, response := operationService.Index(user)
+			code, response := operationService.Index(user, tt.Params.(dto.OperationFilter))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestOperationServiceImpl_Search(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when operations match the search",
+			Params:       dto.OperationSearchRequest{Query: "plomero"},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":9,\"type\":\"expense\",\"amount\":\"8500.00\",\"currency\":\"ARS\",\"date\":\"2023-10-20T15:04:05Z\",\"account_id\":1,\"transfer_id\":null,\"category\":{\"name\":\"Home\",\"color\":\"#6495ed\"},\"description\":\"Pago al plomero\",\"rank\":0.0607927,\"highlights\":{\"description\":\"Pago al \\u003cmark\\u003eplomero\\u003c/mark\\u003e\",\"category\":\"Home repairs\"}}]",
+		},
+		{
+			Name:         "when no operation matches the search",
+			Params:       dto.OperationSearchRequest{Query: "plumber"},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+		{
+			Name:         "when there is an error searching the operations",
+			Params:       dto.OperationSearchRequest{Query: "plomero"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while searching the operations.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			user := dao.User{ID: 1}
+
+			if tt.Name == "when no operation matches the search" {
+				user = dao.User{ID: 2}
+			} else if tt.Name == "when there is an error searching the operations" {
+				user = dao.User{ID: 3}
+			}
+
+			code, response := operationService.Search(user, tt.Params.(dto.OperationSearchRequest))
 
 			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
 		})
@@ -153,14 +362,14 @@ func TestOperationServiceImpl_Index(t *testing.T) {
 func TestOperationServiceImpl_Show(t *testing.T) {
 	operationRepository := &MockOperationRepositoryOperations{}
 	categoryRepository := &MockCategoryRepositoryOperations{}
-	operationService := OperationServiceInit(operationRepository, categoryRepository)
+	operationService := OperationServiceInit(operationRepository, categoryRepository, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
 
 	var tests = []testhelpers.TestInterfaceStructure{
 		{
 			Name:         "when the operation is found",
 			Params:       "",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "{\"id\":1,\"type\":\"income\",\"amount\":1200.5,\"date\":\"2023-10-24T00:33:03.73297Z\",\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\",\"description\":\"Work\",\"is_default\":true},\"description\":\"Salario\"}",
+			ExpectedBody: "{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-24T00:33:03.73297Z\",\"account_id\":null,\"transfer_id\":null,\"status\":\"\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\",\"description\":\"Work\",\"is_default\":true},\"description\":\"Salario\",\"splits\":[{\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"amount\":\"1000.00\",\"note\":\"Salary\"},{\"category\":{\"name\":\"Bonus\",\"color\":\"#6495ed\"},\"amount\":\"200.50\",\"note\":\"Bonus\"}],\"tags\":[\"reimbursable\",\"vacation-2026\"]}",
 		},
 		{
 			Name:         "when the operation is not found",
@@ -187,25 +396,91 @@ func TestOperationServiceImpl_Show(t *testing.T) {
 func TestOperationServiceImpl_Create(t *testing.T) {
 	operationRepository := &MockOperationRepositoryOperations{}
 	categoryRepository := &MockCategoryRepositoryOperations{}
-	operationService := OperationServiceInit(operationRepository, categoryRepository)
+	operationService := OperationServiceInit(operationRepository, categoryRepository, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
 	validDate := time.Now().Add(-time.Hour).Format(time.RFC3339)
 
 	var tests = []testhelpers.TestInterfaceStructure{
 		{
 			Name:         "when the operation is created successfully",
-			Params:       dto.OperationRequest{Type: "income", Amount: 200.50, Date: validDate, Description: "Payment for services", CategoryID: "1"},
+			Params:       dto.OperationRequest{Type: "income", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for services", CategoryID: "1"},
 			ExpectedCode: http.StatusCreated,
 			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
 		},
 		{
 			Name:         "when the operation has invalid category ID",
-			Params:       dto.OperationRequest{Type: "income", Amount: 200.50, Date: validDate, Description: "Payment for services", CategoryID: "2"},
+			Params:       dto.OperationRequest{Type: "income", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for services", CategoryID: "2"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid category.\"}",
+		},
+		{
+			Name:         "when a rule sets the category of the operation",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(15), Date: validDate, Description: "UBER TRIP 1234"},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
+		},
+		{
+			Name:         "when a rule matches an operation with a category",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(15), Date: validDate, Description: "UBER TRIP 1234", CategoryID: "2"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid category.\"}",
+		},
+		{
+			Name:         "when the payee is matched from the description",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(35), Date: validDate, Description: "CARREFOUR 123", CategoryID: "1"},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
+		},
+		{
+			Name:         "when the operation has invalid payee ID",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(35), Date: validDate, Description: "Supermarket", CategoryID: "1", PayeeID: "9"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid payee.\"}",
+		},
+		{
+			Name:         "when the operation looks like a duplicate",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(50), Date: validDate, Description: "Coffee shop", CategoryID: "1"},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"duplicate_ids\":[7],\"message\":\"Operation successfully created.\",\"warning\":\"The operation looks like a duplicate.\"}",
+		},
+		{
+			Name:         "when the operation has invalid account ID",
+			Params:       dto.OperationRequest{Type: "income", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for services", CategoryID: "1", AccountID: "9"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid account.\"}",
+		},
+		{
+			Name:         "when the operation is split across categories",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(200.50), Date: validDate, Description: "Supermarket", CategoryID: "1", Splits: []dto.SplitRequest{{CategoryID: "1", Amount: money.FromFloat(150.25)}, {CategoryID: "1", Amount: money.FromFloat(50.25), Note: "Cleaning"}}},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
+		},
+		{
+			Name:         "when a split has an invalid category",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(200.50), Date: validDate, Description: "Supermarket", CategoryID: "1", Splits: []dto.SplitRequest{{CategoryID: "2", Amount: money.FromFloat(200.50)}}},
 			ExpectedCode: http.StatusUnprocessableEntity,
 			ExpectedBody: "{\"error\":\"Invalid category.\"}",
 		},
+		{
+			Name:         "when the splits do not add up to the amount",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(200.50), Date: validDate, Description: "Supermarket", CategoryID: "1", Splits: []dto.SplitRequest{{CategoryID: "1", Amount: money.FromFloat(150)}, {CategoryID: "1", Amount: money.FromFloat(50)}}},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The splits must add up to the amount of the operation.\"}",
+		},
+		{
+			Name:         "when the operation has tags",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(200.50), Date: validDate, Description: "Hotel", CategoryID: "1", Tags: []string{"Vacation-2026", "reimbursable"}},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Operation successfully created.\"}",
+		},
+		{
+			Name:         "when the tags cannot be saved",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(200.50), Date: validDate, Description: "Hotel", CategoryID: "1", Tags: []string{"invalid"}},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the creation of the operation.\"}",
+		},
 		{
 			Name:         "when there is an error in the creation of the operation",
-			Params:       dto.OperationRequest{Type: "expense", Amount: 200.50, Date: validDate, Description: "Payment for work", CategoryID: "1"},
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for work", CategoryID: "1"},
 			ExpectedCode: http.StatusUnprocessableEntity,
 			ExpectedBody: "{\"error\":\"An error occurred in the creation of the operation.\"}",
 		},
@@ -222,35 +497,90 @@ func TestOperationServiceImpl_Create(t *testing.T) {
 func TestOperationServiceImpl_Update(t *testing.T) {
 	operationRepository := &MockOperationRepositoryOperations{}
 	categoryRepository := &MockCategoryRepositoryOperations{}
-	operationService := OperationServiceInit(operationRepository, categoryRepository)
+	operationService := OperationServiceInit(operationRepository, categoryRepository, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
 	validDate := time.Now().Add(-time.Hour).Format(time.RFC3339)
 
 	var tests = []testhelpers.TestInterfaceStructure{
 		{
 			Name:         "when the operation is updated successfully",
-			Params:       dto.OperationRequest{Type: "income", Amount: 200.50, Date: validDate, Description: "Payment for services", CategoryID: "1"},
+			Params:       dto.OperationRequest{Type: "income", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for services", CategoryID: "1"},
 			ExpectedCode: http.StatusOK,
 			ExpectedBody: "{\"message\":\"Operation successfully updated.\"}",
 		},
+		{
+			Name:         "when the tags of the operation are cleared",
+			Params:       dto.OperationRequest{Type: "income", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for services", CategoryID: "1", Tags: []string{}},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully updated.\"}",
+		},
+		{
+			Name:         "when the tags cannot be saved",
+			Params:       dto.OperationRequest{Type: "income", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for services", CategoryID: "1", Tags: []string{"invalid"}},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the operation.\"}",
+		},
 		{
 			Name:         "when the operation is not found",
-			Params:       dto.OperationRequest{Type: "income", Amount: 200.50, Date: validDate, Description: "Payment for services", CategoryID: "1"},
+			Params:       dto.OperationRequest{Type: "income", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for services", CategoryID: "1"},
 			ExpectedCode: http.StatusNotFound,
 			ExpectedBody: "{\"error\":\"Not found.\"}",
 		},
 		{
 			Name:         "when the operation has invalid category ID",
-			Params:       dto.OperationRequest{Type: "income", Amount: 200.50, Date: validDate, Description: "Payment for services", CategoryID: "2"},
+			Params:       dto.OperationRequest{Type: "income", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for services", CategoryID: "2"},
 			ExpectedCode: http.StatusUnprocessableEntity,
 			ExpectedBody: "{\"error\":\"Invalid category.\"}",
 		},
+		{
+			Name:         "when the operation has invalid account ID",
+			Params:       dto.OperationRequest{Type: "income", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for services", CategoryID: "1", AccountID: "9"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid account.\"}",
+		},
 		{
 			Name:         "when there is an error in the update of the operation",
-			Params:       dto.OperationRequest{Type: "expense", Amount: 200.50, Date: validDate, Description: "Payment for work", CategoryID: "1"},
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for work", CategoryID: "1"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the operation.\"}",
+		},
+		{
+			Name:         "when the operation is a side of a transfer",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(3600), Date: validDate, Description: "Savings"},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully updated.\"}",
+		},
+		{
+			Name:         "when there is an error in the update of the transfer",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(3600), Date: validDate, Description: "Savings"},
 			ExpectedCode: http.StatusUnprocessableEntity,
 			ExpectedBody: "{\"error\":\"An error occurred in the update of the operation.\"}",
 		},
+		{
+			Name:         "when the operation matches the ETag",
+			Params:       dto.OperationRequest{Type: "income", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for services", CategoryID: "1"},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully updated.\"}",
+		},
+		{
+			Name:         "when the operation does not match the ETag",
+			Params:       dto.OperationRequest{Type: "income", Amount: money.FromFloat(200.50), Date: validDate, Description: "Payment for services", CategoryID: "1"},
+			ExpectedCode: http.StatusPreconditionFailed,
+			ExpectedBody: "{\"error\":\"The operation has been modified.\"}",
+		},
+		{
+			Name:         "when the operation is changed by another request",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(40), Date: validDate, Description: "Supermarket", CategoryID: "1"},
+			ExpectedCode: http.StatusPreconditionFailed,
+			ExpectedBody: "{\"error\":\"The operation has been modified.\"}",
+		},
+		{
+			Name:         "when the operation is reconciled",
+			Params:       dto.OperationRequest{Type: "expense", Amount: money.FromFloat(90), Date: validDate, Description: "Supermarket", CategoryID: "1"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Reconciled operations cannot be changed.\"}",
+		},
 	}
+	_, operation := operationService.Show(dao.User{ID: 1}, 1)
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
 			operation_id := 1
@@ -263,17 +593,125 @@ func TestOperationServiceImpl_Update(t *testing.T) {
 				operation_id = 3
 			}
 
-			code, response := operationService.Update(dao.User{ID: 1}, tt.Params.(dto.OperationRequest), operation_id)
+			if tt.Name == "when the operation is a side of a transfer" {
+				operation_id = 5
+			}
+
+			if tt.Name == "when there is an error in the update of the transfer" {
+				operation_id = 6
+			}
+
+			if tt.Name == "when the operation is changed by another request" {
+				operation_id = 7
+			}
+
+			if tt.Name == "when the operation is reconciled" {
+				operation_id = 12
+			}
+
+			ifMatch := ""
+			if tt.Name == "when the operation matches the ETag" {
+				ifMatch = ETag(operation)
+			} else if tt.Name == "when the operation does not match the ETag" {
+				ifMatch = `"0f1e2d3c"`
+			}
+
+			code, response := operationService.Update(dao.User{ID: 1}, tt.Params.(dto.OperationRequest), operation_id, ifMatch)
 
 			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
 		})
 	}
 }
 
+func TestOperationServiceImpl_Patch(t *testing.T) {
+	operationRepository := &MockOperationRepositoryOperations{}
+	categoryRepository := &MockCategoryRepositoryOperations{}
+	operationService := OperationServiceInit(operationRepository, categoryRepository, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+	setAmount := func(operationRequest dto.OperationRequest) (dto.OperationRequest, error) {
+		operationRequest.Amount = money.FromFloat(1500)
+		operationRequest.CategoryID = "1"
+		operationRequest.Splits = nil
+		return operationRequest, nil
+	}
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the operation is patched successfully",
+			Params:       setAmount,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-24T00:33:03.73297Z\",\"account_id\":null,\"transfer_id\":null,\"status\":\"\",\"payee_id\":null,\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\",\"description\":\"Work\",\"is_default\":true},\"description\":\"Salario\",\"splits\":[{\"category\":{\"name\":\"Work\",\"color\":\"#fdg123\"},\"amount\":\"1000.00\",\"note\":\"Salary\"},{\"category\":{\"name\":\"Bonus\",\"color\":\"#6495ed\"},\"amount\":\"200.50\",\"note\":\"Bonus\"}],\"tags\":[\"reimbursable\",\"vacation-2026\"]}",
+		},
+		{
+			Name:         "when the operation is not found",
+			Params:       setAmount,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name: "when the patch is invalid",
+			Params: func(operationRequest dto.OperationRequest) (dto.OperationRequest, error) {
+				return operationRequest, patch.ErrInvalidPatch
+			},
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid parameters.\"}",
+		},
+		{
+			Name: "when the patch test fails",
+			Params: func(operationRequest dto.OperationRequest) (dto.OperationRequest, error) {
+				return operationRequest, patch.ErrTestFailed
+			},
+			ExpectedCode: http.StatusConflict,
+			ExpectedBody: "{\"error\":\"The patch test failed.\"}",
+		},
+		{
+			Name: "when the stored splits are kept with an invalid category",
+			Params: func(operationRequest dto.OperationRequest) (dto.OperationRequest, error) {
+				return operationRequest, nil
+			},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid category.\"}",
+		},
+		{
+			Name:         "when there is an error in the update of the operation",
+			Params:       setAmount,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the operation.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			operation_id := 1
+
+			if tt.Name == "when the operation is not found" {
+				operation_id = 2
+			}
+
+			if tt.Name == "when there is an error in the update of the operation" {
+				operation_id = 3
+			}
+
+			var storedRequest dto.OperationRequest
+			applyPatch := tt.Params.(func(dto.OperationRequest) (dto.OperationRequest, error))
+			code, response := operationService.Patch(dao.User{ID: 1}, operation_id, "", func(operationRequest dto.OperationRequest) (dto.OperationRequest, error) {
+				storedRequest = operationRequest
+				return applyPatch(operationRequest)
+			})
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+			if operation_id != 2 {
+				assert.Equal(t, "Salario", storedRequest.Description)
+				assert.Equal(t, "2023-10-24T00:33:03.73297Z", storedRequest.Date)
+				assert.Equal(t, []string{"reimbursable", "vacation-2026"}, storedRequest.Tags)
+				assert.Len(t, storedRequest.Splits, 2)
+			}
+		})
+	}
+}
+
 func TestOperationServiceImpl_Delete(t *testing.T) {
 	operationRepository := &MockOperationRepositoryOperations{}
 	categoryRepository := &MockCategoryRepositoryOperations{}
-	operationService := OperationServiceInit(operationRepository, categoryRepository)
+	operationService := OperationServiceInit(operationRepository, categoryRepository, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
 
 	var tests = []testhelpers.TestInterfaceStructure{
 		{
@@ -294,6 +732,36 @@ func TestOperationServiceImpl_Delete(t *testing.T) {
 			ExpectedCode: http.StatusUnprocessableEntity,
 			ExpectedBody: "{\"error\":\"An error occurred while deleting the operation.\"}",
 		},
+		{
+			Name:         "when the operation is a side of a transfer",
+			Params:       "",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully deleted.\"}",
+		},
+		{
+			Name:         "when there is an error while deleting the transfer",
+			Params:       "",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while deleting the operation.\"}",
+		},
+		{
+			Name:         "when the operation does not match the ETag",
+			Params:       `"0f1e2d3c"`,
+			ExpectedCode: http.StatusPreconditionFailed,
+			ExpectedBody: "{\"error\":\"The operation has been modified.\"}",
+		},
+		{
+			Name:         "when the operation is changed by another request",
+			Params:       "",
+			ExpectedCode: http.StatusPreconditionFailed,
+			ExpectedBody: "{\"error\":\"The operation has been modified.\"}",
+		},
+		{
+			Name:         "when the operation is reconciled",
+			Params:       "",
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Reconciled operations cannot be changed.\"}",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -303,9 +771,17 @@ func TestOperationServiceImpl_Delete(t *testing.T) {
 				operation_id = 2
 			} else if tt.Name == "when there is an error while deleting the operation" {
 				operation_id = 3
+			} else if tt.Name == "when the operation is a side of a transfer" {
+				operation_id = 5
+			} else if tt.Name == "when there is an error while deleting the transfer" {
+				operation_id = 6
+			} else if tt.Name == "when the operation is changed by another request" {
+				operation_id = 7
+			} else if tt.Name == "when the operation is reconciled" {
+				operation_id = 12
 			}
 
-			code, response := operationService.Delete(dao.User{ID: 1}, operation_id)
+			code, response := operationService.Delete(dao.User{ID: 1}, operation_id, tt.Params.(string))
 
 			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
 		})
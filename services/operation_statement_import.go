@@ -0,0 +1,243 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dto"
+	"bufio"
+	"errors"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidStatement = errors.New("invalid statement")
+
+var ofxTransactionPattern = regexp.MustCompile(`(?is)<STMTTRN>(.*?)</STMTTRN>`)
+var ofxElementPattern = regexp.MustCompile(`(?i)<([A-Z0-9.]+)>([^<\r\n]*)`)
+var ofxCurrencyPattern = regexp.MustCompile(`(?i)<CURDEF>\s*([A-Z]{3})`)
+
+// qifTransactionTypes are the QIF sections holding bank transactions, other
+// sections such as account or category lists are skipped.
+var qifTransactionTypes = map[string]bool{
+	"bank":  true,
+	"cash":  true,
+	"ccard": true,
+	"oth a": true,
+	"oth l": true,
+}
+
+// parseOFXOperations reads the transactions of an OFX statement, either the
+// SGML of version 1 or the XML of version 2. Credits are incomes and debits
+// expenses, other transaction types follow the sign of the amount. The FITID
+// of each transaction is kept to recognize it when imported again.
+func parseOFXOperations(file io.Reader) ([]importedOperation, error) {
+	content, err := io.ReadAll(io.LimitReader(file, MAX_IMPORT_SIZE+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > MAX_IMPORT_SIZE {
+		return nil, errImportTooLarge
+	}
+	statement := string(content)
+	if !strings.Contains(strings.ToUpper(statement), "<OFX>") {
+		return nil, errInvalidStatement
+	}
+	statementCurrency := ""
+	if match := ofxCurrencyPattern.FindStringSubmatch(statement); match != nil {
+		statementCurrency = strings.ToUpper(match[1])
+	}
+
+	transactions := ofxTransactionPattern.FindAllStringSubmatchIndex(statement, MAX_IMPORT_ROWS+1)
+	if len(transactions) > MAX_IMPORT_ROWS {
+		return nil, errTooManyImportRows
+	}
+	importedOperations := []importedOperation{}
+	for _, bounds := range transactions {
+		fields := map[string]string{}
+		for _, element := range ofxElementPattern.FindAllStringSubmatch(statement[bounds[2]:bounds[3]], -1) {
+			fields[strings.ToUpper(element[1])] = html.UnescapeString(strings.TrimSpace(element[2]))
+		}
+
+		importedOperation := importedOperation{
+			line:        strings.Count(statement[:bounds[0]], "\n") + 1,
+			currency:    statementCurrency,
+			description: statementDescription(fields["NAME"], fields["MEMO"]),
+			externalID:  fields["FITID"],
+		}
+		postedDate := fields["DTPOSTED"]
+		if len(postedDate) > 8 {
+			postedDate = postedDate[:8]
+		}
+		importedOperation.date = parseImportDate(postedDate, "20060102", &importedOperation)
+
+		decimalSeparator := "."
+		if strings.Contains(fields["TRNAMT"], ",") && !strings.Contains(fields["TRNAMT"], ".") {
+			decimalSeparator = ","
+		}
+		amount, amountError := parseImportAmount(fields["TRNAMT"], decimalSeparator)
+		if amountError != nil || amount == 0 {
+			importedOperation.errors = append(importedOperation.errors, "Invalid amount.")
+		}
+		importedOperation.operation, importedOperation.amount = importedOperationType(amount, dto.AmountSignNegativeExpense)
+		switch strings.ToUpper(fields["TRNTYPE"]) {
+		case "CREDIT":
+			importedOperation.operation = INCOME_TYPE
+		case "DEBIT":
+			importedOperation.operation = EXPENSE_TYPE
+		}
+
+		if currency := strings.ToUpper(fields["CURSYM"]); currency != "" {
+			importedOperation.currency = currency
+		}
+		if importedOperation.currency != "" && !ValidCurrencyCode(importedOperation.currency) {
+			importedOperation.errors = append(importedOperation.errors, "Invalid currency.")
+		}
+		importedOperations = append(importedOperations, importedOperation)
+	}
+	return importedOperations, nil
+}
+
+// parseQIFOperations reads the transactions of a QIF file. Dates are taken as
+// month first unless the request's date format starts with the day, and the
+// category is the top level of the L field. Transfers to other QIF accounts
+// are left without category. Reading stops as soon as the file has more than
+// MAX_IMPORT_ROWS transactions.
+func parseQIFOperations(file io.Reader, importRequest dto.OperationImportRequest) ([]importedOperation, error) {
+	scanner := bufio.NewScanner(file)
+	dayFirst := strings.HasPrefix(importRequest.DateFormat, "DD")
+
+	importedOperations := []importedOperation{}
+	transactionSection, sectionFound := false, false
+	fields := map[byte]string{}
+	recordLine, lineNumber := 0, 0
+	closeRecord := func() {
+		if transactionSection && len(fields) > 0 {
+			importedOperations = append(importedOperations, qifOperation(recordLine, fields, dayFirst, importRequest.DecimalSeparator))
+		}
+		fields = map[byte]string{}
+	}
+	for len(importedOperations) <= MAX_IMPORT_ROWS && scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if lineNumber == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		switch line[0] {
+		case '!':
+			closeRecord()
+			header := strings.ToLower(strings.TrimSpace(line))
+			if strings.HasPrefix(header, "!type:") {
+				transactionSection = qifTransactionTypes[strings.TrimPrefix(header, "!type:")]
+				sectionFound = sectionFound || transactionSection
+			} else if !strings.HasPrefix(header, "!option") && !strings.HasPrefix(header, "!clear") {
+				transactionSection = false
+			}
+		case '^':
+			closeRecord()
+		default:
+			if len(fields) == 0 {
+				recordLine = lineNumber
+			}
+			if _, found := fields[line[0]]; !found {
+				fields[line[0]] = strings.TrimSpace(line[1:])
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	closeRecord()
+	if len(importedOperations) > MAX_IMPORT_ROWS {
+		return nil, errTooManyImportRows
+	}
+	if !sectionFound {
+		return nil, errInvalidStatement
+	}
+	return importedOperations, nil
+}
+
+func qifOperation(line int, fields map[byte]string, dayFirst bool, decimalSeparator string) importedOperation {
+	importedOperation := importedOperation{
+		line:        line,
+		description: statementDescription(fields['P'], fields['M']),
+	}
+	if category := fields['L']; category != "" && !strings.HasPrefix(category, "[") {
+		category = strings.SplitN(category, "/", 2)[0]
+		importedOperation.category = strings.TrimSpace(strings.SplitN(category, ":", 2)[0])
+	}
+
+	date, dateError := parseQIFDate(fields['D'], dayFirst)
+	if dateError != nil || date.After(time.Now()) {
+		importedOperation.errors = append(importedOperation.errors, "Invalid date.")
+	} else {
+		importedOperation.date = date
+	}
+
+	value, found := fields['T']
+	if !found {
+		value = fields['U']
+	}
+	amount, amountError := parseImportAmount(value, decimalSeparator)
+	if amountError != nil || amount == 0 {
+		importedOperation.errors = append(importedOperation.errors, "Invalid amount.")
+	}
+	importedOperation.operation, importedOperation.amount = importedOperationType(amount, dto.AmountSignNegativeExpense)
+	return importedOperation
+}
+
+// parseQIFDate reads the dates written by the different QIF exporters, such as
+// 10/18/2023, 10/18'23, 18.10.2023 or 2023-10-18. Two digit years after an
+// apostrophe or below 70 belong to this century.
+func parseQIFDate(value string, dayFirst bool) (time.Time, error) {
+	value = strings.ReplaceAll(value, " ", "")
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return r == '/' || r == '-' || r == '.' || r == '\''
+	})
+	if len(parts) != 3 {
+		return time.Time{}, errInvalidStatement
+	}
+	numbers := [3]int{}
+	for index, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, err
+		}
+		numbers[index] = number
+	}
+
+	year, month, day := numbers[2], numbers[0], numbers[1]
+	if len(parts[0]) == 4 {
+		year, month, day = numbers[0], numbers[1], numbers[2]
+	} else if dayFirst {
+		month, day = numbers[1], numbers[0]
+	}
+	if len(parts[0]) != 4 && len(parts[2]) <= 2 {
+		if strings.Contains(value, "'") || year < 70 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, utcLocation)
+	if date.Day() != day || int(date.Month()) != month {
+		return time.Time{}, errInvalidStatement
+	}
+	return date, nil
+}
+
+// statementDescription joins the payee and the memo of a transaction, leaving
+// out the memo when it only repeats the payee.
+func statementDescription(payee string, memo string) string {
+	if payee == "" || strings.EqualFold(payee, memo) {
+		return memo
+	}
+	if memo == "" {
+		return payee
+	}
+	return payee + " - " + memo
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ofxStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>ARS
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20231018120000.000[-3:ART]
+<TRNAMT>-120.25
+<FITID>20231018001
+<NAME>Supermarket
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20231020
+<TRNAMT>1500.00
+<FITID>20231020001
+<NAME>Salary &amp; bonus
+<MEMO>October
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20231020
+<TRNAMT>1500.00
+<FITID>20231020001
+<NAME>Salary &amp; bonus
+<MEMO>October
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const qifStatement = `!Type:Bank
+D10/21'23
+T-1,234.56
+PSupermercado
+LGroceries:Food
+^
+D10/22/2023
+T250.00
+PRefund
+MGroceries refund
+L[Savings]
+^
+D13/40/2023
+Tabc
+^
+`
+
+func TestOperationServiceImpl_ImportStatements(t *testing.T) {
+	operationService := OperationServiceInit(&MockOperationRepositoryOperations{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{}, &MockTransferRepository{}, &MockTagRepository{}, &MockAuditRepository{}, &MockRuleRepository{}, &MockPayeeRepository{})
+
+	type importParams struct {
+		request dto.OperationImportRequest
+		file    string
+	}
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when an OFX statement is imported again",
+			Params:       importParams{dto.OperationImportRequest{Format: dto.ImportFormatOFX, DefaultCategoryID: "1"}, ofxStatement},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"dry_run\":false,\"imported\":1,\"duplicates\":2,\"invalid\":0,\"rows\":[" +
+				"{\"line\":9,\"date\":\"2023-10-18\",\"type\":\"expense\",\"amount\":\"120.25\",\"currency\":\"ARS\",\"description\":\"Supermarket\",\"category\":\"\",\"external_id\":\"20231018001\",\"status\":\"duplicate\",\"errors\":[]}," +
+				"{\"line\":16,\"date\":\"2023-10-20\",\"type\":\"income\",\"amount\":\"1500.00\",\"currency\":\"ARS\",\"description\":\"Salary \\u0026 bonus - October\",\"category\":\"\",\"external_id\":\"20231020001\",\"status\":\"valid\",\"errors\":[]}," +
+				"{\"line\":24,\"date\":\"2023-10-20\",\"type\":\"income\",\"amount\":\"1500.00\",\"currency\":\"ARS\",\"description\":\"Salary \\u0026 bonus - October\",\"category\":\"\",\"external_id\":\"20231020001\",\"status\":\"duplicate\",\"errors\":[]}]}",
+		},
+		{
+			Name:         "when a QIF file is checked with a dry run",
+			Params:       importParams{dto.OperationImportRequest{Format: dto.ImportFormatQIF, DefaultCategoryID: "1", DryRun: true}, qifStatement},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"dry_run\":true,\"imported\":0,\"duplicates\":0,\"invalid\":1,\"rows\":[" +
+				"{\"line\":2,\"date\":\"2023-10-21\",\"type\":\"expense\",\"amount\":\"1234.56\",\"currency\":\"ARS\",\"description\":\"Supermercado\",\"category\":\"Groceries\",\"status\":\"valid\",\"errors\":[]}," +
+				"{\"line\":7,\"date\":\"2023-10-22\",\"type\":\"income\",\"amount\":\"250.00\",\"currency\":\"ARS\",\"description\":\"Refund - Groceries refund\",\"category\":\"\",\"status\":\"valid\",\"errors\":[]}," +
+				"{\"line\":13,\"date\":\"\",\"type\":\"income\",\"amount\":\"0.00\",\"currency\":\"ARS\",\"description\":\"\",\"category\":\"\",\"status\":\"invalid\",\"errors\":[\"Invalid date.\",\"Invalid amount.\"]}]}",
+		},
+		{
+			Name:         "when an OFX statement has too many transactions",
+			Params:       importParams{dto.OperationImportRequest{Format: dto.ImportFormatOFX}, "<OFX>" + strings.Repeat("<STMTTRN><TRNAMT>10</STMTTRN>", MAX_IMPORT_ROWS+1)},
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"The file has too many rows.\"}",
+		},
+		{
+			Name:         "when a QIF file has too many transactions",
+			Params:       importParams{dto.OperationImportRequest{Format: dto.ImportFormatQIF}, "!Type:Bank\n" + strings.Repeat("D10/21'23\nT10\n^\n", MAX_IMPORT_ROWS+1)},
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"The file has too many rows.\"}",
+		},
+		{
+			Name:         "when an OFX statement exceeds the maximum size",
+			Params:       importParams{dto.OperationImportRequest{Format: dto.ImportFormatOFX}, "<OFX>" + strings.Repeat(" ", int(MAX_IMPORT_SIZE))},
+			ExpectedCode: http.StatusRequestEntityTooLarge,
+			ExpectedBody: "{\"error\":\"The file exceeds the maximum size.\"}",
+		},
+		{
+			Name:         "when the file is not an OFX statement",
+			Params:       importParams{dto.OperationImportRequest{Format: dto.ImportFormatOFX}, "date,amount\n2023-10-21,10\n"},
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid OFX file.\"}",
+		},
+		{
+			Name:         "when the file is not a QIF file",
+			Params:       importParams{dto.OperationImportRequest{Format: dto.ImportFormatQIF}, "date,amount\n2023-10-21,10\n"},
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "{\"error\":\"Invalid QIF file.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			params := tt.Params.(importParams)
+			code, response := operationService.Import(dao.User{ID: 1}, params.request, strings.NewReader(params.file))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestParseQIFDate(t *testing.T) {
+	var tests = []struct {
+		value    string
+		dayFirst bool
+		expected string
+	}{
+		{"10/18/2023", false, "2023-10-18"},
+		{"10/18'23", false, "2023-10-18"},
+		{" 1/ 5/98", false, "1998-01-05"},
+		{"18.10.2023", true, "2023-10-18"},
+		{"2023-10-18", true, "2023-10-18"},
+	}
+	for _, tt := range tests {
+		date, err := parseQIFDate(tt.value, tt.dayFirst)
+		assert.NoError(t, err, tt.value)
+		assert.Equal(t, tt.expected, date.Format(time.DateOnly), tt.value)
+	}
+	_, err := parseQIFDate("02/30/2023", false)
+	assert.Error(t, err)
+}
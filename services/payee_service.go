@@ -0,0 +1,242 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/repository"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const MAX_PAYEE_NAME_LENGTH int = 100
+
+const MAX_PAYEE_ALIASES int = 20
+
+type PayeeService interface {
+	Index(user dao.User) (int, interface{})
+	Show(user dao.User, payeeID int) (int, interface{})
+	Create(user dao.User, payeeRequest dto.PayeeRequest) (int, interface{})
+	Update(user dao.User, payeeRequest dto.PayeeRequest, payeeID int) (int, interface{})
+	Delete(user dao.User, payeeID int) (int, interface{})
+	Merge(user dao.User, payeeID int, mergeRequest dto.PayeeMergeRequest) (int, interface{})
+	Totals(user dao.User, totalsFilter dto.PayeeTotalsFilter) (int, interface{})
+}
+
+type PayeeServiceImpl struct {
+	payeeRepository repository.PayeeRepository
+}
+
+func (u PayeeServiceImpl) Index(user dao.User) (int, interface{}) {
+	payees, recordError := u.payeeRepository.FindPayeesByUser(user)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while listing the payees."}
+	}
+	transformedResponse := []dto.TransformedPayee{}
+	for _, payee := range payees {
+		transformedResponse = append(transformedResponse, transformPayee(payee))
+	}
+	return http.StatusOK, transformedResponse
+}
+
+func (u PayeeServiceImpl) Show(user dao.User, payeeID int) (int, interface{}) {
+	payee, recordError := u.payeeRepository.FindPayeeByUserAndId(user, payeeID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, transformPayee(payee)
+}
+
+// Create creates the payee. Its name and aliases cannot match another payee's,
+// so that a description matches a single payee.
+func (u PayeeServiceImpl) Create(user dao.User, payeeRequest dto.PayeeRequest) (int, interface{}) {
+	payeeDao := buildPayee(user, payeeRequest)
+	code, response := u.validatePayee(user, payeeDao, gin.H{"error": "An error occurred in the creation of the payee."})
+	if code != http.StatusOK {
+		return code, response
+	}
+
+	_, recordError := u.payeeRepository.Save(&payeeDao)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the payee."}
+	}
+
+	return http.StatusCreated, gin.H{"message": "Payee successfully created."}
+}
+
+func (u PayeeServiceImpl) Update(user dao.User, payeeRequest dto.PayeeRequest, payeeID int) (int, interface{}) {
+	payee, recordError := u.payeeRepository.FindPayeeByUserAndId(user, payeeID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	payeeDao := buildPayee(user, payeeRequest)
+	payeeDao.ID = payee.ID
+	code, response := u.validatePayee(user, payeeDao, gin.H{"error": "An error occurred in the update of the payee."})
+	if code != http.StatusOK {
+		return code, response
+	}
+
+	_, recordError = u.payeeRepository.Update(&payeeDao)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the payee."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Payee successfully updated."}
+}
+
+// Delete deletes the payee; its operations are kept without one.
+func (u PayeeServiceImpl) Delete(user dao.User, payeeID int) (int, interface{}) {
+	payee, recordError := u.payeeRepository.FindPayeeByUserAndId(user, payeeID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	_, recordError = u.payeeRepository.Delete(&payee)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while deleting the payee."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Payee successfully deleted."}
+}
+
+// Merge merges another payee into this one: its operations move here, and its
+// name and aliases become aliases of this payee, which keeps matching the
+// descriptions the other one did.
+func (u PayeeServiceImpl) Merge(user dao.User, payeeID int, mergeRequest dto.PayeeMergeRequest) (int, interface{}) {
+	payee, recordError := u.payeeRepository.FindPayeeByUserAndId(user, payeeID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	merged, recordError := u.payeeRepository.FindPayeeByUserAndId(user, mergeRequest.PayeeID)
+	if recordError != nil || merged.ID == payee.ID {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid payee."}
+	}
+
+	aliases := append(payeeAliases(payee), merged.Name)
+	aliases = append(aliases, payeeAliases(merged)...)
+	payee.Aliases = strings.Join(normalizePayeeAliases(payee.Name, aliases), ",")
+	if recordError := u.payeeRepository.Merge(&payee, &merged); recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while merging the payees."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Payees successfully merged."}
+}
+
+// Totals returns what was spent on every payee, by currency.
+func (u PayeeServiceImpl) Totals(user dao.User, totalsFilter dto.PayeeTotalsFilter) (int, interface{}) {
+	rows, recordError := u.payeeRepository.FindPayeeTotals(user, totalsFilter)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while listing the payee totals."}
+	}
+	if rows == nil {
+		rows = []dto.PayeeTotalRow{}
+	}
+	return http.StatusOK, rows
+}
+
+// validatePayee checks that none of the names the payee matches on belongs to
+// another of the user's payees.
+func (u PayeeServiceImpl) validatePayee(user dao.User, payee dao.Payee, errorResponse gin.H) (int, interface{}) {
+	payees, recordError := u.payeeRepository.FindPayeesByUser(user)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, errorResponse
+	}
+	terms := map[string]bool{}
+	for _, term := range newPayeeMatcher([]dao.Payee{payee}) {
+		terms[term.term] = true
+	}
+	for _, term := range newPayeeMatcher(payees) {
+		if term.payeeID != payee.ID && terms[term.term] {
+			return http.StatusUnprocessableEntity, gin.H{"error": "The payee already exists."}
+		}
+	}
+	return http.StatusOK, nil
+}
+
+// payeeTerm is a name or alias of a payee as descriptions are matched against
+// it.
+type payeeTerm struct {
+	payeeID int
+	term    string
+}
+
+// payeeMatcher matches descriptions to the user's payees by their names and
+// aliases.
+type payeeMatcher []payeeTerm
+
+func newPayeeMatcher(payees []dao.Payee) payeeMatcher {
+	matcher := payeeMatcher{}
+	for _, payee := range payees {
+		for _, name := range append([]string{payee.Name}, payeeAliases(payee)...) {
+			if term := normalizeDescription(name); term != "" {
+				matcher = append(matcher, payeeTerm{payeeID: payee.ID, term: term})
+			}
+		}
+	}
+	return matcher
+}
+
+// match returns the payee with the longest name or alias found as whole words
+// in the description, ignoring case and punctuation, or nil when none is.
+func (matcher payeeMatcher) match(description string) *int {
+	normalized := " " + normalizeDescription(description) + " "
+	var payeeID *int
+	longest := 0
+	for index := range matcher {
+		term := matcher[index]
+		if len(term.term) > longest && strings.Contains(normalized, " "+term.term+" ") {
+			payeeID = &matcher[index].payeeID
+			longest = len(term.term)
+		}
+	}
+	return payeeID
+}
+
+// normalizePayeeAliases trims the aliases and drops the empty ones and the
+// ones that repeat the name or another alias.
+func normalizePayeeAliases(name string, aliases []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{normalizeDescription(name): true}
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		term := normalizeDescription(alias)
+		if term == "" || seen[term] {
+			continue
+		}
+		seen[term] = true
+		normalized = append(normalized, alias)
+	}
+	return normalized
+}
+
+func payeeAliases(payee dao.Payee) []string {
+	if payee.Aliases == "" {
+		return []string{}
+	}
+	return strings.Split(payee.Aliases, ",")
+}
+
+func buildPayee(user dao.User, payeeRequest dto.PayeeRequest) dao.Payee {
+	name := strings.TrimSpace(payeeRequest.Name)
+	return dao.Payee{
+		UserID:  uint(user.ID),
+		Name:    name,
+		Aliases: strings.Join(normalizePayeeAliases(name, payeeRequest.Aliases), ","),
+	}
+}
+
+func transformPayee(payee dao.Payee) dto.TransformedPayee {
+	return dto.TransformedPayee{
+		ID:      payee.ID,
+		Name:    payee.Name,
+		Aliases: payeeAliases(payee),
+	}
+}
+
+func PayeeServiceInit(payeeRepository repository.PayeeRepository) *PayeeServiceImpl {
+	return &PayeeServiceImpl{
+		payeeRepository: payeeRepository,
+	}
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MockPayeeRepository struct{}
+
+func (u MockPayeeRepository) FindPayeesByUser(user dao.User) ([]dao.Payee, error) {
+	if user.ID == 3 {
+		return nil, errors.New("Database error.")
+	}
+	if user.ID != 1 {
+		return []dao.Payee{}, nil
+	}
+	return []dao.Payee{
+		{ID: 1, Name: "Carrefour", Aliases: "Carrefour Express,CARREFOUR MARKET"},
+		{ID: 2, Name: "Uber"},
+	}, nil
+}
+
+func (u MockPayeeRepository) FindPayeeByUserAndId(user dao.User, payeeID int) (dao.Payee, error) {
+	payees, _ := u.FindPayeesByUser(user)
+	for _, payee := range payees {
+		if payee.ID == payeeID {
+			return payee, nil
+		}
+	}
+	return dao.Payee{}, errors.New("Payee not found.")
+}
+
+func (u MockPayeeRepository) FindPayeeTotals(user dao.User, filter dto.PayeeTotalsFilter) ([]dto.PayeeTotalRow, error) {
+	if user.ID == 3 {
+		return nil, errors.New("Database error.")
+	}
+	if user.ID != 1 {
+		return nil, nil
+	}
+	return []dto.PayeeTotalRow{
+		{PayeeID: 1, Name: "Carrefour", Currency: "ARS", Total: money.FromFloat(35400.5), Count: 3},
+		{PayeeID: 2, Name: "Uber", Currency: "ARS", Total: money.FromFloat(4200), Count: 2},
+	}, nil
+}
+
+func (u MockPayeeRepository) Save(payee *dao.Payee) (dao.Payee, error) {
+	if payee.Name == "Invalid" {
+		return dao.Payee{}, errors.New("Invalid payee.")
+	}
+	return *payee, nil
+}
+
+func (u MockPayeeRepository) Update(payee *dao.Payee) (dao.Payee, error) {
+	if payee.Name == "Invalid" {
+		return dao.Payee{}, errors.New("Invalid payee.")
+	}
+	return *payee, nil
+}
+
+func (u MockPayeeRepository) Delete(payee *dao.Payee) (dao.Payee, error) {
+	if payee.ID == 2 {
+		return dao.Payee{}, errors.New("Invalid payee.")
+	}
+	return *payee, nil
+}
+
+func (u MockPayeeRepository) Merge(payee *dao.Payee, merged *dao.Payee) error {
+	if payee.Aliases != "Carrefour Express,CARREFOUR MARKET,Uber" {
+		return errors.New("Invalid payee.")
+	}
+	return nil
+}
+
+func TestPayeeServiceImpl_Index(t *testing.T) {
+	payeeService := PayeeServiceInit(&MockPayeeRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the user has payees",
+			Params:       dao.User{ID: 1},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"name\":\"Carrefour\",\"aliases\":[\"Carrefour Express\",\"CARREFOUR MARKET\"]},{\"id\":2,\"name\":\"Uber\",\"aliases\":[]}]",
+		},
+		{
+			Name:         "when the user has no payees",
+			Params:       dao.User{ID: 2},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+		{
+			Name:         "when there is an error listing the payees",
+			Params:       dao.User{ID: 3},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while listing the payees.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := payeeService.Index(tt.Params.(dao.User))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestPayeeServiceImpl_Create(t *testing.T) {
+	payeeService := PayeeServiceInit(&MockPayeeRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the payee is created successfully",
+			Params:       dto.PayeeRequest{Name: "Disco", Aliases: []string{"Disco 24hs", " disco "}},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Payee successfully created.\"}",
+		},
+		{
+			Name:         "when an alias belongs to another payee",
+			Params:       dto.PayeeRequest{Name: "Hipermercado", Aliases: []string{"carrefour-express"}},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The payee already exists.\"}",
+		},
+		{
+			Name:         "when there is an error in the creation of the payee",
+			Params:       dto.PayeeRequest{Name: "Invalid"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the creation of the payee.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := payeeService.Create(dao.User{ID: 1}, tt.Params.(dto.PayeeRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestPayeeServiceImpl_Update(t *testing.T) {
+	payeeService := PayeeServiceInit(&MockPayeeRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the payee keeps its own aliases",
+			Params:       dto.PayeeRequest{Name: "Carrefour", Aliases: []string{"Carrefour Express", "Carrefour Maxi"}},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Payee successfully updated.\"}",
+		},
+		{
+			Name:         "when the payee takes the name of another payee",
+			Params:       dto.PayeeRequest{Name: "UBER"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The payee already exists.\"}",
+		},
+		{
+			Name:         "when the payee is not found",
+			Params:       dto.PayeeRequest{Name: "Carrefour"},
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error in the update of the payee",
+			Params:       dto.PayeeRequest{Name: "Invalid"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the payee.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			payeeID := 1
+			if tt.Name == "when the payee is not found" {
+				payeeID = 9
+			}
+
+			code, response := payeeService.Update(dao.User{ID: 1}, tt.Params.(dto.PayeeRequest), payeeID)
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestPayeeServiceImpl_Delete(t *testing.T) {
+	payeeService := PayeeServiceInit(&MockPayeeRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the payee is deleted",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Payee successfully deleted.\"}",
+		},
+		{
+			Name:         "when the payee is not found",
+			Params:       9,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error while deleting the payee",
+			Params:       2,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while deleting the payee.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := payeeService.Delete(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestPayeeServiceImpl_Merge(t *testing.T) {
+	payeeService := PayeeServiceInit(&MockPayeeRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the payees are merged",
+			Params:       dto.PayeeMergeRequest{PayeeID: 2},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Payees successfully merged.\"}",
+		},
+		{
+			Name:         "when the merged payee is not found",
+			Params:       dto.PayeeMergeRequest{PayeeID: 9},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid payee.\"}",
+		},
+		{
+			Name:         "when the payee is not found",
+			Params:       dto.PayeeMergeRequest{PayeeID: 2},
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error while merging the payees",
+			Params:       dto.PayeeMergeRequest{PayeeID: 1},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while merging the payees.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			payeeID := 1
+			if tt.Name == "when the payee is not found" {
+				payeeID = 9
+			} else if tt.Name == "when there is an error while merging the payees" {
+				payeeID = 2
+			}
+
+			code, response := payeeService.Merge(dao.User{ID: 1}, payeeID, tt.Params.(dto.PayeeMergeRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestPayeeServiceImpl_Totals(t *testing.T) {
+	payeeService := PayeeServiceInit(&MockPayeeRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the user spent on payees",
+			Params:       dao.User{ID: 1},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"payee_id\":1,\"name\":\"Carrefour\",\"currency\":\"ARS\",\"total\":\"35400.50\",\"count\":3},{\"payee_id\":2,\"name\":\"Uber\",\"currency\":\"ARS\",\"total\":\"4200.00\",\"count\":2}]",
+		},
+		{
+			Name:         "when the user has no payee spending",
+			Params:       dao.User{ID: 2},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+		{
+			Name:         "when there is an error listing the totals",
+			Params:       dao.User{ID: 3},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while listing the payee totals.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := payeeService.Totals(tt.Params.(dao.User), dto.PayeeTotalsFilter{})
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestPayeeMatcherMatch(t *testing.T) {
+	payees, _ := MockPayeeRepository{}.FindPayeesByUser(dao.User{ID: 1})
+	matcher := newPayeeMatcher(payees)
+
+	assert.Equal(t, 1, *matcher.match("CARREFOUR 123"))
+	assert.Equal(t, 1, *matcher.match("Compra carrefour express, Palermo"))
+	assert.Equal(t, 2, *matcher.match("UBER *TRIP"))
+	assert.Nil(t, matcher.match("Uberlandia hotel"))
+	assert.Nil(t, matcher.match(""))
+}
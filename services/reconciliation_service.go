@@ -0,0 +1,241 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"GoGin-API-CuentasClaras/repository"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReconciliationService interface {
+	Index(user dao.User) (int, interface{})
+	Show(user dao.User, reconciliationID int) (int, interface{})
+	Create(user dao.User, reconciliationRequest dto.ReconciliationRequest) (int, interface{})
+	Tick(user dao.User, reconciliationID int, tickRequest dto.ReconciliationTickRequest) (int, interface{})
+	Untick(user dao.User, reconciliationID int, operationID int) (int, interface{})
+	Finalize(user dao.User, reconciliationID int) (int, interface{})
+}
+
+type ReconciliationServiceImpl struct {
+	reconciliationRepository repository.ReconciliationRepository
+	accountRepository        repository.AccountRepository
+}
+
+func (u ReconciliationServiceImpl) Index(user dao.User) (int, interface{}) {
+	reconciliations, recordError := u.reconciliationRepository.FindReconciliationsByUser(user)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while listing the reconciliations."}
+	}
+	transformedResponse := []dto.TransformedReconciliation{}
+	for _, reconciliation := range reconciliations {
+		transformedResponse = append(transformedResponse, transformReconciliation(reconciliation))
+	}
+	return http.StatusOK, transformedResponse
+}
+
+func (u ReconciliationServiceImpl) Show(user dao.User, reconciliationID int) (int, interface{}) {
+	reconciliation, recordError := u.reconciliationRepository.FindReconciliationByUserAndId(user, reconciliationID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return u.reconciliationDetail(user, reconciliation)
+}
+
+// Create opens a reconciliation of an account against a statement. An account
+// has at most one open reconciliation at a time.
+func (u ReconciliationServiceImpl) Create(user dao.User, reconciliationRequest dto.ReconciliationRequest) (int, interface{}) {
+	account, recordError := u.accountRepository.FindAccountByUserAndId(user, reconciliationRequest.AccountID)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid account."}
+	}
+	reconciliations, recordError := u.reconciliationRepository.FindReconciliationsByUser(user)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the reconciliation."}
+	}
+	for _, reconciliation := range reconciliations {
+		if reconciliation.AccountID == account.ID && reconciliation.Status == dto.ReconciliationStatusOpen {
+			return http.StatusUnprocessableEntity, gin.H{"error": "The account already has an open reconciliation."}
+		}
+	}
+
+	statementDate, _ := time.Parse(DATE_LAYOUT, reconciliationRequest.StatementDate)
+	reconciliationDao := dao.Reconciliation{
+		UserID:         uint(user.ID),
+		AccountID:      account.ID,
+		StatementDate:  statementDate,
+		ClosingBalance: reconciliationRequest.ClosingBalance,
+		Status:         dto.ReconciliationStatusOpen,
+	}
+	_, recordError = u.reconciliationRepository.Save(&reconciliationDao)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the reconciliation."}
+	}
+
+	return http.StatusCreated, gin.H{"message": "Reconciliation successfully created."}
+}
+
+// Tick clears the operations against the statement. Only operations of the
+// account dated up to the statement and not reconciled before can be ticked.
+func (u ReconciliationServiceImpl) Tick(user dao.User, reconciliationID int, tickRequest dto.ReconciliationTickRequest) (int, interface{}) {
+	code, response, reconciliation, operations := u.openReconciliation(user, reconciliationID)
+	if code != http.StatusOK {
+		return code, response
+	}
+	candidates := map[int]bool{}
+	for _, operation := range operations {
+		candidates[operation.ID] = true
+	}
+	for _, operationID := range tickRequest.OperationIDs {
+		if !candidates[operationID] {
+			return http.StatusUnprocessableEntity, gin.H{"error": "Invalid operation."}
+		}
+	}
+
+	if recordError := u.reconciliationRepository.TickOperations(reconciliation, tickRequest.OperationIDs); recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the reconciliation."}
+	}
+	return u.reconciliationDetail(user, reconciliation)
+}
+
+// Untick takes an operation back out of the reconciliation.
+func (u ReconciliationServiceImpl) Untick(user dao.User, reconciliationID int, operationID int) (int, interface{}) {
+	code, response, reconciliation, operations := u.openReconciliation(user, reconciliationID)
+	if code != http.StatusOK {
+		return code, response
+	}
+	if !reconciliationTicked(reconciliation, operations, operationID) {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid operation."}
+	}
+
+	if recordError := u.reconciliationRepository.UntickOperations(reconciliation, []int{operationID}); recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the reconciliation."}
+	}
+	return u.reconciliationDetail(user, reconciliation)
+}
+
+// Finalize reconciles the ticked operations, which can no longer be changed,
+// once the cleared balance matches the closing balance of the statement.
+func (u ReconciliationServiceImpl) Finalize(user dao.User, reconciliationID int) (int, interface{}) {
+	code, response, reconciliation, _ := u.openReconciliation(user, reconciliationID)
+	if code != http.StatusOK {
+		return code, response
+	}
+	code, response = u.reconciliationDetail(user, reconciliation)
+	if code != http.StatusOK {
+		return code, response
+	}
+	if response.(dto.TransformedReconciliationDetail).Difference != 0 {
+		return http.StatusUnprocessableEntity, gin.H{"error": "The reconciliation does not balance."}
+	}
+
+	if recordError := u.reconciliationRepository.Finalize(&reconciliation); recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the reconciliation."}
+	}
+	return http.StatusOK, gin.H{"message": "Reconciliation successfully finalized."}
+}
+
+// openReconciliation finds a reconciliation that can still be changed, along
+// with its operations.
+func (u ReconciliationServiceImpl) openReconciliation(user dao.User, reconciliationID int) (int, interface{}, dao.Reconciliation, []dao.Operation) {
+	reconciliation, recordError := u.reconciliationRepository.FindReconciliationByUserAndId(user, reconciliationID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}, reconciliation, nil
+	}
+	if reconciliation.Status != dto.ReconciliationStatusOpen {
+		return http.StatusUnprocessableEntity, gin.H{"error": "The reconciliation is finalized."}, reconciliation, nil
+	}
+	operations, recordError := u.reconciliationRepository.FindReconciliationOperations(reconciliation)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the reconciliation."}, reconciliation, nil
+	}
+	return http.StatusOK, nil, reconciliation, operations
+}
+
+func (u ReconciliationServiceImpl) reconciliationDetail(user dao.User, reconciliation dao.Reconciliation) (int, interface{}) {
+	errorResponse := gin.H{"error": "An error occurred while showing the reconciliation."}
+	account, recordError := u.accountRepository.FindAccountByUserAndId(user, reconciliation.AccountID)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, errorResponse
+	}
+	reconciledBalance, recordError := u.reconciliationRepository.FindReconciledBalance(reconciliation)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, errorResponse
+	}
+	operations, recordError := u.reconciliationRepository.FindReconciliationOperations(reconciliation)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, errorResponse
+	}
+
+	transformed := transformReconciliationDetail(reconciliation)
+	transformed.ClearedBalance = account.OpeningBalance + reconciledBalance
+	transformed.Operations = []dto.ReconciliationOperation{}
+	for _, operation := range operations {
+		ticked := operation.ReconciliationID != nil && *operation.ReconciliationID == reconciliation.ID
+		if ticked {
+			transformed.ClearedBalance += signedAmount(operation)
+		}
+		transformed.Operations = append(transformed.Operations, dto.ReconciliationOperation{
+			ID:          operation.ID,
+			Type:        operation.Type,
+			Amount:      operation.Amount,
+			Date:        operation.Date.In(utcLocation),
+			Description: operation.Description,
+			Status:      operation.Status,
+			Ticked:      ticked,
+		})
+	}
+	transformed.Difference = reconciliation.ClosingBalance - transformed.ClearedBalance
+	return http.StatusOK, transformed
+}
+
+func reconciliationTicked(reconciliation dao.Reconciliation, operations []dao.Operation, operationID int) bool {
+	for _, operation := range operations {
+		if operation.ID == operationID && operation.ReconciliationID != nil && *operation.ReconciliationID == reconciliation.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// signedAmount is the amount of the operation as it moves the balance of its
+// account.
+func signedAmount(operation dao.Operation) money.Amount {
+	if operation.Type == EXPENSE_TYPE {
+		return -operation.Amount
+	}
+	return operation.Amount
+}
+
+func transformReconciliation(reconciliation dao.Reconciliation) dto.TransformedReconciliation {
+	return dto.TransformedReconciliation{
+		ID:             reconciliation.ID,
+		AccountID:      reconciliation.AccountID,
+		StatementDate:  reconciliation.StatementDate.In(utcLocation).Format(DATE_LAYOUT),
+		ClosingBalance: reconciliation.ClosingBalance,
+		Status:         reconciliation.Status,
+		FinalizedAt:    reconciliation.FinalizedAt,
+	}
+}
+
+func transformReconciliationDetail(reconciliation dao.Reconciliation) dto.TransformedReconciliationDetail {
+	transformed := transformReconciliation(reconciliation)
+	return dto.TransformedReconciliationDetail{
+		ID:             transformed.ID,
+		AccountID:      transformed.AccountID,
+		StatementDate:  transformed.StatementDate,
+		ClosingBalance: transformed.ClosingBalance,
+		Status:         transformed.Status,
+		FinalizedAt:    transformed.FinalizedAt,
+	}
+}
+
+func ReconciliationServiceInit(reconciliationRepository repository.ReconciliationRepository, accountRepository repository.AccountRepository) *ReconciliationServiceImpl {
+	return &ReconciliationServiceImpl{
+		reconciliationRepository: reconciliationRepository,
+		accountRepository:        accountRepository,
+	}
+}
@@ -0,0 +1,315 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type MockReconciliationRepository struct{}
+
+func (u MockReconciliationRepository) FindReconciliationsByUser(user dao.User) ([]dao.Reconciliation, error) {
+	if user.ID == 3 {
+		return nil, errors.New("Database error.")
+	}
+	if user.ID == 2 {
+		return []dao.Reconciliation{}, nil
+	}
+	statementDate, _ := time.Parse(time.RFC3339, "2023-10-31T00:00:00Z")
+	previousStatementDate, _ := time.Parse(time.RFC3339, "2023-09-30T00:00:00Z")
+	finalizedAt, _ := time.Parse(time.RFC3339, "2023-10-05T12:00:00Z")
+	return []dao.Reconciliation{
+		{ID: 1, AccountID: 1, StatementDate: statementDate, ClosingBalance: money.FromFloat(1350), Status: dto.ReconciliationStatusOpen},
+		{ID: 2, AccountID: 1, StatementDate: previousStatementDate, ClosingBalance: money.FromFloat(500), Status: dto.ReconciliationStatusFinalized, FinalizedAt: &finalizedAt},
+		{ID: 3, AccountID: 2, StatementDate: statementDate, ClosingBalance: money.FromFloat(100), Status: dto.ReconciliationStatusOpen},
+		{ID: 4, AccountID: 4, StatementDate: statementDate, Status: dto.ReconciliationStatusOpen},
+	}, nil
+}
+
+func (u MockReconciliationRepository) FindReconciliationByUserAndId(user dao.User, reconciliationID int) (dao.Reconciliation, error) {
+	reconciliations, _ := u.FindReconciliationsByUser(user)
+	for _, reconciliation := range reconciliations {
+		if reconciliation.ID == reconciliationID {
+			return reconciliation, nil
+		}
+	}
+	return dao.Reconciliation{}, errors.New("Reconciliation not found.")
+}
+
+func (u MockReconciliationRepository) FindReconciliationOperations(reconciliation dao.Reconciliation) ([]dao.Operation, error) {
+	date, _ := time.Parse(time.RFC3339, "2023-10-20T15:04:05Z")
+	reconciliationID := reconciliation.ID
+	switch reconciliation.ID {
+	case 1:
+		return []dao.Operation{
+			{ID: 20, Type: "income", Amount: money.FromFloat(1000), Date: date, Description: "Salary", Status: dto.OperationStatusCleared, ReconciliationID: &reconciliationID},
+			{ID: 21, Type: "expense", Amount: money.FromFloat(150), Date: date, Description: "Supermarket", Status: dto.OperationStatusCleared, ReconciliationID: &reconciliationID},
+			{ID: 23, Type: "expense", Amount: money.FromFloat(30), Date: date, Description: "Coffee shop", Status: dto.OperationStatusPending},
+		}, nil
+	case 3:
+		return []dao.Operation{
+			{ID: 22, Type: "expense", Amount: money.FromFloat(20), Date: date, Description: "Bank fee", Status: dto.OperationStatusPending},
+		}, nil
+	}
+	return []dao.Operation{}, nil
+}
+
+func (u MockReconciliationRepository) FindReconciledBalance(reconciliation dao.Reconciliation) (money.Amount, error) {
+	return 0, nil
+}
+
+func (u MockReconciliationRepository) Save(reconciliation *dao.Reconciliation) (dao.Reconciliation, error) {
+	if reconciliation.ClosingBalance < 0 {
+		return dao.Reconciliation{}, errors.New("Invalid reconciliation.")
+	}
+	return *reconciliation, nil
+}
+
+func (u MockReconciliationRepository) TickOperations(reconciliation dao.Reconciliation, operationIDs []int) error {
+	if reconciliation.AccountID == 2 {
+		return errors.New("Database error.")
+	}
+	return nil
+}
+
+func (u MockReconciliationRepository) UntickOperations(reconciliation dao.Reconciliation, operationIDs []int) error {
+	return nil
+}
+
+func (u MockReconciliationRepository) Finalize(reconciliation *dao.Reconciliation) error {
+	if reconciliation.AccountID == 4 {
+		return errors.New("Database error.")
+	}
+	return nil
+}
+
+func TestReconciliationServiceImpl_Index(t *testing.T) {
+	reconciliationService := ReconciliationServiceInit(&MockReconciliationRepository{}, &MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the user has reconciliations",
+			Params:       dao.User{ID: 1},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"account_id\":1,\"statement_date\":\"2023-10-31\",\"closing_balance\":\"1350.00\",\"status\":\"open\",\"finalized_at\":null}," +
+				"{\"id\":2,\"account_id\":1,\"statement_date\":\"2023-09-30\",\"closing_balance\":\"500.00\",\"status\":\"finalized\",\"finalized_at\":\"2023-10-05T12:00:00Z\"}," +
+				"{\"id\":3,\"account_id\":2,\"statement_date\":\"2023-10-31\",\"closing_balance\":\"100.00\",\"status\":\"open\",\"finalized_at\":null}," +
+				"{\"id\":4,\"account_id\":4,\"statement_date\":\"2023-10-31\",\"closing_balance\":\"0.00\",\"status\":\"open\",\"finalized_at\":null}]",
+		},
+		{
+			Name:         "when the user has no reconciliations",
+			Params:       dao.User{ID: 2},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+		{
+			Name:         "when there is an error listing the reconciliations",
+			Params:       dao.User{ID: 3},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while listing the reconciliations.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := reconciliationService.Index(tt.Params.(dao.User))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestReconciliationServiceImpl_Show(t *testing.T) {
+	reconciliationService := ReconciliationServiceInit(&MockReconciliationRepository{}, &MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the reconciliation is found",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"account_id\":1,\"statement_date\":\"2023-10-31\",\"closing_balance\":\"1350.00\",\"status\":\"open\",\"finalized_at\":null,\"cleared_balance\":\"1350.00\",\"difference\":\"0.00\",\"operations\":[" +
+				"{\"id\":20,\"type\":\"income\",\"amount\":\"1000.00\",\"date\":\"2023-10-20T15:04:05Z\",\"description\":\"Salary\",\"status\":\"cleared\",\"ticked\":true}," +
+				"{\"id\":21,\"type\":\"expense\",\"amount\":\"150.00\",\"date\":\"2023-10-20T15:04:05Z\",\"description\":\"Supermarket\",\"status\":\"cleared\",\"ticked\":true}," +
+				"{\"id\":23,\"type\":\"expense\",\"amount\":\"30.00\",\"date\":\"2023-10-20T15:04:05Z\",\"description\":\"Coffee shop\",\"status\":\"pending\",\"ticked\":false}]}",
+		},
+		{
+			Name:         "when the reconciliation does not balance",
+			Params:       3,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":3,\"account_id\":2,\"statement_date\":\"2023-10-31\",\"closing_balance\":\"100.00\",\"status\":\"open\",\"finalized_at\":null,\"cleared_balance\":\"0.00\",\"difference\":\"100.00\",\"operations\":[" +
+				"{\"id\":22,\"type\":\"expense\",\"amount\":\"20.00\",\"date\":\"2023-10-20T15:04:05Z\",\"description\":\"Bank fee\",\"status\":\"pending\",\"ticked\":false}]}",
+		},
+		{
+			Name:         "when the reconciliation is not found",
+			Params:       9,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := reconciliationService.Show(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestReconciliationServiceImpl_Create(t *testing.T) {
+	reconciliationService := ReconciliationServiceInit(&MockReconciliationRepository{}, &MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the reconciliation is created successfully",
+			Params:       dto.ReconciliationRequest{AccountID: 3, StatementDate: "2023-10-31", ClosingBalance: money.FromFloat(250)},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Reconciliation successfully created.\"}",
+		},
+		{
+			Name:         "when the account is invalid",
+			Params:       dto.ReconciliationRequest{AccountID: 9, StatementDate: "2023-10-31", ClosingBalance: money.FromFloat(250)},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid account.\"}",
+		},
+		{
+			Name:         "when the account already has an open reconciliation",
+			Params:       dto.ReconciliationRequest{AccountID: 1, StatementDate: "2023-11-30", ClosingBalance: money.FromFloat(250)},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The account already has an open reconciliation.\"}",
+		},
+		{
+			Name:         "when there is an error in the creation of the reconciliation",
+			Params:       dto.ReconciliationRequest{AccountID: 3, StatementDate: "2023-10-31", ClosingBalance: money.FromFloat(-250)},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the creation of the reconciliation.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := reconciliationService.Create(dao.User{ID: 1}, tt.Params.(dto.ReconciliationRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestReconciliationServiceImpl_Tick(t *testing.T) {
+	reconciliationService := ReconciliationServiceInit(&MockReconciliationRepository{}, &MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the operations are ticked off",
+			Params:       dto.ReconciliationTickRequest{OperationIDs: []int{23}},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "",
+		},
+		{
+			Name:         "when an operation is not in the reconciliation",
+			Params:       dto.ReconciliationTickRequest{OperationIDs: []int{23, 22}},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid operation.\"}",
+		},
+		{
+			Name:         "when the reconciliation is finalized",
+			Params:       dto.ReconciliationTickRequest{OperationIDs: []int{23}},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The reconciliation is finalized.\"}",
+		},
+		{
+			Name:         "when the reconciliation is not found",
+			Params:       dto.ReconciliationTickRequest{OperationIDs: []int{23}},
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error in the update of the reconciliation",
+			Params:       dto.ReconciliationTickRequest{OperationIDs: []int{22}},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the reconciliation.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			reconciliationID := 1
+			if tt.Name == "when the reconciliation is finalized" {
+				reconciliationID = 2
+			} else if tt.Name == "when the reconciliation is not found" {
+				reconciliationID = 9
+			} else if tt.Name == "when there is an error in the update of the reconciliation" {
+				reconciliationID = 3
+			}
+
+			code, response := reconciliationService.Tick(dao.User{ID: 1}, reconciliationID, tt.Params.(dto.ReconciliationTickRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestReconciliationServiceImpl_Untick(t *testing.T) {
+	reconciliationService := ReconciliationServiceInit(&MockReconciliationRepository{}, &MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the operation is unticked",
+			Params:       21,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "",
+		},
+		{
+			Name:         "when the operation is not ticked",
+			Params:       23,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid operation.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := reconciliationService.Untick(dao.User{ID: 1}, 1, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestReconciliationServiceImpl_Finalize(t *testing.T) {
+	reconciliationService := ReconciliationServiceInit(&MockReconciliationRepository{}, &MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the reconciliation is finalized",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Reconciliation successfully finalized.\"}",
+		},
+		{
+			Name:         "when the reconciliation does not balance",
+			Params:       3,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The reconciliation does not balance.\"}",
+		},
+		{
+			Name:         "when the reconciliation was already finalized",
+			Params:       2,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The reconciliation is finalized.\"}",
+		},
+		{
+			Name:         "when there is an error in the update of the reconciliation",
+			Params:       4,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the reconciliation.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := reconciliationService.Finalize(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/repository"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const DEFAULT_SCHEDULER_INTERVAL time.Duration = time.Hour
+
+// MAX_CATCH_UP_OCCURRENCES bounds how many occurrences a single recurring
+// operation may produce in one run, the remainder is picked up on the next tick.
+const MAX_CATCH_UP_OCCURRENCES int = 500
+
+type RecurringOperationScheduler interface {
+	Start()
+	RunDue(now time.Time) int
+}
+
+type RecurringOperationSchedulerImpl struct {
+	recurringOperationRepository repository.RecurringOperationRepository
+	interval                     time.Duration
+}
+
+func (u RecurringOperationSchedulerImpl) Start() {
+	go func() {
+		u.RunDue(time.Now())
+		ticker := time.NewTicker(u.interval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			u.RunDue(now)
+		}
+	}()
+	log.Info("Recurring operation scheduler started with interval ", u.interval)
+}
+
+func (u RecurringOperationSchedulerImpl) RunDue(now time.Time) int {
+	recurringOperationIDs, err := u.recurringOperationRepository.FindDueRecurringOperationIDs(now)
+	if err != nil {
+		log.Error("Got and error when find due recurring operations. Error: ", err)
+		return 0
+	}
+
+	total := 0
+	for _, recurringOperationID := range recurringOperationIDs {
+		created, err := u.recurringOperationRepository.GenerateOccurrences(recurringOperationID, now, func(recurringOperation *dao.RecurringOperation) []dao.Operation {
+			return PendingOccurrences(recurringOperation, now)
+		})
+		if err != nil {
+			continue
+		}
+		total += created
+	}
+	if total > 0 {
+		log.Info("Recurring operation scheduler created ", total, " operations")
+	}
+	return total
+}
+
+// PendingOccurrences builds the operations due up to now and advances the
+// schedule state (occurrences created, last and next run) of the recurring operation.
+func PendingOccurrences(recurringOperation *dao.RecurringOperation, now time.Time) []dao.Operation {
+	operations := []dao.Operation{}
+	for recurringOperation.NextRunAt != nil && !recurringOperation.NextRunAt.After(now) && len(operations) < MAX_CATCH_UP_OCCURRENCES {
+		date := *recurringOperation.NextRunAt
+		recurringOperationID := recurringOperation.ID
+		categoryID := recurringOperation.CategoryID
+		operations = append(operations, dao.Operation{
+			UserID:               recurringOperation.UserID,
+			CategoryID:           &categoryID,
+			AccountID:            recurringOperation.AccountID,
+			RecurringOperationID: &recurringOperationID,
+			Type:                 recurringOperation.Type,
+			Amount:               recurringOperation.Amount,
+			Currency:             recurringOperation.Currency,
+			Date:                 date,
+			Description:          recurringOperation.Description,
+		})
+		recurringOperation.OccurrencesCreated++
+		recurringOperation.LastRunAt = &date
+		recurringOperation.NextRunAt = NextOccurrence(*recurringOperation)
+	}
+	return operations
+}
+
+// NextOccurrence returns the first occurrence after the last run that is within
+// the start date, end date and count limits, or nil when the schedule is finished.
+func NextOccurrence(recurringOperation dao.RecurringOperation) *time.Time {
+	if recurringOperation.Count > 0 && recurringOperation.OccurrencesCreated >= recurringOperation.Count {
+		return nil
+	}
+
+	after := recurringOperation.StartDate.Add(-time.Nanosecond)
+	if recurringOperation.LastRunAt != nil && recurringOperation.LastRunAt.After(after) {
+		after = *recurringOperation.LastRunAt
+	}
+
+	for index := approximateOccurrenceIndex(recurringOperation, after); ; index++ {
+		date := occurrenceDate(recurringOperation, index)
+		if !date.After(after) {
+			continue
+		}
+		if recurringOperation.EndDate != nil && date.After(*recurringOperation.EndDate) {
+			return nil
+		}
+		return &date
+	}
+}
+
+func occurrenceDate(recurringOperation dao.RecurringOperation, index int) time.Time {
+	start := recurringOperation.StartDate
+	switch recurringOperation.Frequency {
+	case DAILY_FREQUENCY:
+		return start.AddDate(0, 0, index)
+	case WEEKLY_FREQUENCY:
+		return start.AddDate(0, 0, 7*index)
+	case YEARLY_FREQUENCY:
+		return dateWithClampedDay(start, start.Year()+index, start.Month(), recurringDay(recurringOperation))
+	default:
+		return dateWithClampedDay(start, start.Year(), start.Month()+time.Month(index), recurringDay(recurringOperation))
+	}
+}
+
+func approximateOccurrenceIndex(recurringOperation dao.RecurringOperation, after time.Time) int {
+	start := recurringOperation.StartDate
+	if !after.After(start) {
+		return 0
+	}
+	var index int
+	switch recurringOperation.Frequency {
+	case DAILY_FREQUENCY:
+		index = int(after.Sub(start).Hours() / 24)
+	case WEEKLY_FREQUENCY:
+		index = int(after.Sub(start).Hours() / (24 * 7))
+	case YEARLY_FREQUENCY:
+		index = after.Year() - start.Year()
+	default:
+		index = (after.Year()-start.Year())*12 + int(after.Month()) - int(start.Month())
+	}
+	if index > 1 {
+		return index - 1
+	}
+	return 0
+}
+
+func recurringDay(recurringOperation dao.RecurringOperation) int {
+	if recurringOperation.DayOfMonth > 0 {
+		return recurringOperation.DayOfMonth
+	}
+	return recurringOperation.StartDate.Day()
+}
+
+func dateWithClampedDay(reference time.Time, year int, month time.Month, day int) time.Time {
+	firstOfMonth := time.Date(year, month, 1, reference.Hour(), reference.Minute(), reference.Second(), reference.Nanosecond(), reference.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+func RecurringOperationSchedulerInit(recurringOperationRepository repository.RecurringOperationRepository) *RecurringOperationSchedulerImpl {
+	interval, err := time.ParseDuration(os.Getenv("RECURRING_SCHEDULER_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = DEFAULT_SCHEDULER_INTERVAL
+	}
+	return &RecurringOperationSchedulerImpl{
+		recurringOperationRepository: recurringOperationRepository,
+		interval:                     interval,
+	}
+}
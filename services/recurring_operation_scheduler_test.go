@@ -0,0 +1,109 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseDate(value string) time.Time {
+	date, _ := time.Parse(time.RFC3339, value)
+	return date
+}
+
+func TestNextOccurrence(t *testing.T) {
+	lastRunAt := parseDate("2024-01-31T10:00:00Z")
+	endDate := parseDate("2024-02-15T00:00:00Z")
+
+	var tests = []struct {
+		Name               string
+		RecurringOperation dao.RecurringOperation
+		Expected           string
+	}{
+		{
+			Name:               "when the schedule has not run yet",
+			RecurringOperation: dao.RecurringOperation{Frequency: "monthly", StartDate: parseDate("2024-01-31T10:00:00Z")},
+			Expected:           "2024-01-31T10:00:00Z",
+		},
+		{
+			Name:               "when the monthly day does not exist in the next month",
+			RecurringOperation: dao.RecurringOperation{Frequency: "monthly", StartDate: parseDate("2024-01-31T10:00:00Z"), LastRunAt: &lastRunAt},
+			Expected:           "2024-02-29T10:00:00Z",
+		},
+		{
+			Name:               "when the day of month is before the start day",
+			RecurringOperation: dao.RecurringOperation{Frequency: "monthly", DayOfMonth: 5, StartDate: parseDate("2024-01-20T10:00:00Z")},
+			Expected:           "2024-02-05T10:00:00Z",
+		},
+		{
+			Name:               "when the schedule is weekly",
+			RecurringOperation: dao.RecurringOperation{Frequency: "weekly", StartDate: parseDate("2024-01-03T10:00:00Z"), LastRunAt: &lastRunAt},
+			Expected:           "2024-02-07T10:00:00Z",
+		},
+		{
+			Name:               "when the schedule is yearly",
+			RecurringOperation: dao.RecurringOperation{Frequency: "yearly", StartDate: parseDate("2020-02-29T10:00:00Z"), LastRunAt: &lastRunAt},
+			Expected:           "2024-02-29T10:00:00Z",
+		},
+		{
+			Name:               "when the count has been reached",
+			RecurringOperation: dao.RecurringOperation{Frequency: "daily", StartDate: parseDate("2024-01-01T10:00:00Z"), Count: 3, OccurrencesCreated: 3},
+			Expected:           "",
+		},
+		{
+			Name:               "when the next occurrence is after the end date",
+			RecurringOperation: dao.RecurringOperation{Frequency: "monthly", StartDate: parseDate("2024-01-20T10:00:00Z"), EndDate: &endDate, LastRunAt: &lastRunAt, OccurrencesCreated: 2},
+			Expected:           "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			nextOccurrence := NextOccurrence(tt.RecurringOperation)
+
+			if tt.Expected == "" {
+				assert.Nil(t, nextOccurrence)
+			} else {
+				assert.Equal(t, tt.Expected, nextOccurrence.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestPendingOccurrences(t *testing.T) {
+	startDate := parseDate("2024-01-31T10:00:00Z")
+	recurringOperation := dao.RecurringOperation{
+		ID:          7,
+		UserID:      1,
+		CategoryID:  1,
+		Type:        "expense",
+		Amount:      money.FromFloat(500),
+		Currency:    "ARS",
+		Description: "Rent",
+		Frequency:   "monthly",
+		StartDate:   startDate,
+		Count:       3,
+		NextRunAt:   &startDate,
+	}
+
+	operations := PendingOccurrences(&recurringOperation, parseDate("2024-12-31T00:00:00Z"))
+
+	assert.Len(t, operations, 3)
+	assert.Equal(t, "2024-01-31T10:00:00Z", operations[0].Date.Format(time.RFC3339))
+	assert.Equal(t, "2024-02-29T10:00:00Z", operations[1].Date.Format(time.RFC3339))
+	assert.Equal(t, "2024-03-31T10:00:00Z", operations[2].Date.Format(time.RFC3339))
+	assert.Equal(t, 7, *operations[0].RecurringOperationID)
+	assert.Equal(t, 3, recurringOperation.OccurrencesCreated)
+	assert.Nil(t, recurringOperation.NextRunAt)
+
+	assert.Empty(t, PendingOccurrences(&recurringOperation, parseDate("2025-12-31T00:00:00Z")))
+}
+
+func TestRecurringOperationSchedulerImpl_RunDue(t *testing.T) {
+	scheduler := RecurringOperationSchedulerInit(&MockRecurringOperationRepository{})
+
+	assert.Equal(t, 0, scheduler.RunDue(parseDate("2023-10-15T00:00:00Z")))
+	assert.Equal(t, 2, scheduler.RunDue(parseDate("2023-12-15T00:00:00Z")))
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/repository"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecurringOperationService interface {
+	Index(user dao.User) (int, []dto.TransformedRecurringOperation)
+	Show(user dao.User, recurringOperationID int) (int, interface{})
+	Create(user dao.User, recurringOperationRequest dto.RecurringOperationRequest) (int, interface{})
+	Update(user dao.User, recurringOperationRequest dto.RecurringOperationRequest, recurringOperationID int) (int, interface{})
+	Delete(user dao.User, recurringOperationID int) (int, interface{})
+}
+
+type RecurringOperationServiceImpl struct {
+	recurringOperationRepository repository.RecurringOperationRepository
+	categoryRepository           repository.CategoryRepository
+	accountRepository            repository.AccountRepository
+}
+
+func (u RecurringOperationServiceImpl) Index(user dao.User) (int, []dto.TransformedRecurringOperation) {
+	recurringOperations, _ := u.recurringOperationRepository.FindRecurringOperationsByUser(user)
+	transformedResponse := []dto.TransformedRecurringOperation{}
+	for _, recurringOperation := range recurringOperations {
+		transformedResponse = append(transformedResponse, transformRecurringOperation(recurringOperation))
+	}
+
+	return http.StatusOK, transformedResponse
+}
+
+func (u RecurringOperationServiceImpl) Show(user dao.User, recurringOperationID int) (int, interface{}) {
+	recurringOperation, recordError := u.recurringOperationRepository.FindRecurringOperationByUserAndId(user, recurringOperationID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	return http.StatusOK, transformRecurringOperation(recurringOperation)
+}
+
+func (u RecurringOperationServiceImpl) Create(user dao.User, recurringOperationRequest dto.RecurringOperationRequest) (int, interface{}) {
+	if invalidCategoryID(recurringOperationRequest.CategoryID, u.categoryRepository) {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid category."}
+	}
+
+	invalidAccount, account := invalidAccountID(user, recurringOperationRequest.AccountID, u.accountRepository)
+	if invalidAccount {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid account."}
+	}
+
+	recurringOperationDao := buildRecurringOperation(user, recurringOperationRequest, account)
+	recurringOperationDao.NextRunAt = NextOccurrence(recurringOperationDao)
+
+	_, recordError := u.recurringOperationRepository.Save(&recurringOperationDao)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the recurring operation."}
+	}
+
+	return http.StatusCreated, gin.H{"message": "Recurring operation successfully created."}
+}
+
+func (u RecurringOperationServiceImpl) Update(user dao.User, recurringOperationRequest dto.RecurringOperationRequest, recurringOperationID int) (int, interface{}) {
+	recurringOperation, recordError := u.recurringOperationRepository.FindRecurringOperationByUserAndId(user, recurringOperationID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	if invalidCategoryID(recurringOperationRequest.CategoryID, u.categoryRepository) {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid category."}
+	}
+
+	accountID := recurringOperationRequest.AccountID
+	if accountID == "" && recurringOperation.AccountID != nil {
+		accountID = strconv.Itoa(*recurringOperation.AccountID)
+	}
+	invalidAccount, account := invalidAccountID(user, accountID, u.accountRepository)
+	if invalidAccount {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid account."}
+	}
+
+	recurringOperationDao := buildRecurringOperation(user, recurringOperationRequest, account)
+	recurringOperationDao.ID = recurringOperation.ID
+
+	_, recordError = u.recurringOperationRepository.Update(&recurringOperationDao, NextOccurrence)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the recurring operation."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Recurring operation successfully updated."}
+}
+
+func (u RecurringOperationServiceImpl) Delete(user dao.User, recurringOperationID int) (int, interface{}) {
+	recurringOperation, recordError := u.recurringOperationRepository.FindRecurringOperationByUserAndId(user, recurringOperationID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	_, recordError = u.recurringOperationRepository.Delete(&recurringOperation)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while deleting the recurring operation."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Recurring operation successfully deleted."}
+}
+
+func buildRecurringOperation(user dao.User, recurringOperationRequest dto.RecurringOperationRequest, account dao.Account) dao.RecurringOperation {
+	categoryID, _ := strconv.Atoi(recurringOperationRequest.CategoryID)
+	startDate, _ := time.Parse(time.RFC3339, recurringOperationRequest.StartDate)
+
+	var endDate *time.Time
+	if recurringOperationRequest.EndDate != "" {
+		parsedEndDate, _ := time.Parse(time.RFC3339, recurringOperationRequest.EndDate)
+		endDate = &parsedEndDate
+	}
+
+	return dao.RecurringOperation{
+		UserID:      uint(user.ID),
+		CategoryID:  categoryID,
+		AccountID:   &account.ID,
+		Type:        recurringOperationRequest.Type,
+		Amount:      recurringOperationRequest.Amount,
+		Currency:    accountOperationCurrency(user, account, recurringOperationRequest.Currency),
+		Description: recurringOperationRequest.Description,
+		Frequency:   recurringOperationRequest.Frequency,
+		DayOfMonth:  recurringOperationRequest.DayOfMonth,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Count:       recurringOperationRequest.Count,
+	}
+}
+
+func transformRecurringOperation(recurringOperation dao.RecurringOperation) dto.TransformedRecurringOperation {
+	transformed := dto.TransformedRecurringOperation{
+		ID:          recurringOperation.ID,
+		Type:        recurringOperation.Type,
+		Amount:      recurringOperation.Amount,
+		Currency:    recurringOperation.Currency,
+		Description: recurringOperation.Description,
+		Category: dto.TransformedCategory{
+			Name:  recurringOperation.Category.Name,
+			Color: recurringOperation.Category.Color,
+		},
+		AccountID:          recurringOperation.AccountID,
+		Frequency:          recurringOperation.Frequency,
+		DayOfMonth:         recurringOperation.DayOfMonth,
+		StartDate:          recurringOperation.StartDate.In(utcLocation),
+		Count:              recurringOperation.Count,
+		OccurrencesCreated: recurringOperation.OccurrencesCreated,
+	}
+	if recurringOperation.EndDate != nil {
+		endDate := recurringOperation.EndDate.In(utcLocation)
+		transformed.EndDate = &endDate
+	}
+	if recurringOperation.NextRunAt != nil {
+		nextRunAt := recurringOperation.NextRunAt.In(utcLocation)
+		transformed.NextRunAt = &nextRunAt
+	}
+	return transformed
+}
+
+func RecurringOperationServiceInit(recurringOperationRepository repository.RecurringOperationRepository, categoryRepository repository.CategoryRepository, accountRepository repository.AccountRepository) *RecurringOperationServiceImpl {
+	return &RecurringOperationServiceImpl{
+		recurringOperationRepository: recurringOperationRepository,
+		categoryRepository:           categoryRepository,
+		accountRepository:            accountRepository,
+	}
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type MockRecurringOperationRepository struct{}
+
+func (u MockRecurringOperationRepository) FindRecurringOperationsByUser(user dao.User) ([]dao.RecurringOperation, error) {
+	if user.ID == 2 {
+		return []dao.RecurringOperation{}, nil
+	}
+	recurringOperation, _ := u.FindRecurringOperationByUserAndId(user, 1)
+	return []dao.RecurringOperation{recurringOperation}, nil
+}
+
+func (u MockRecurringOperationRepository) FindRecurringOperationByUserAndId(user dao.User, recurringOperationID int) (dao.RecurringOperation, error) {
+	if recurringOperationID == 2 {
+		return dao.RecurringOperation{}, errors.New("Recurring operation not found.")
+	}
+	startDate, _ := time.Parse(time.RFC3339, "2023-10-01T10:00:00-03:00")
+	nextRunAt := startDate.AddDate(0, 1, 0)
+	return dao.RecurringOperation{
+		ID:          recurringOperationID,
+		Type:        "expense",
+		Amount:      money.FromFloat(500),
+		Currency:    "ARS",
+		Description: "Rent",
+		Category: dao.Category{
+			Name:  "Home",
+			Color: "#6495ed",
+		},
+		Frequency:          "monthly",
+		StartDate:          startDate,
+		OccurrencesCreated: 1,
+		NextRunAt:          &nextRunAt,
+	}, nil
+}
+
+func (u MockRecurringOperationRepository) FindDueRecurringOperationIDs(now time.Time) ([]int, error) {
+	return []int{1}, nil
+}
+
+func (u MockRecurringOperationRepository) Save(recurringOperation *dao.RecurringOperation) (dao.RecurringOperation, error) {
+	if recurringOperation.Description == "Payment for work" {
+		return dao.RecurringOperation{}, errors.New("Invalid recurring operation.")
+	}
+	return *recurringOperation, nil
+}
+
+func (u MockRecurringOperationRepository) Update(recurringOperation *dao.RecurringOperation, nextRun func(recurringOperation dao.RecurringOperation) *time.Time) (dao.RecurringOperation, error) {
+	if recurringOperation.ID == 3 {
+		return dao.RecurringOperation{}, errors.New("Invalid recurring operation.")
+	}
+	recurringOperation.NextRunAt = nextRun(*recurringOperation)
+	return *recurringOperation, nil
+}
+
+func (u MockRecurringOperationRepository) Delete(recurringOperation *dao.RecurringOperation) (dao.RecurringOperation, error) {
+	if recurringOperation.ID == 3 {
+		return dao.RecurringOperation{}, errors.New("Invalid recurring operation.")
+	}
+	return *recurringOperation, nil
+}
+
+func (u MockRecurringOperationRepository) GenerateOccurrences(recurringOperationID int, now time.Time, schedule func(recurringOperation *dao.RecurringOperation) []dao.Operation) (int, error) {
+	recurringOperation, _ := u.FindRecurringOperationByUserAndId(dao.User{ID: 1}, recurringOperationID)
+	return len(schedule(&recurringOperation)), nil
+}
+
+func TestRecurringOperationServiceImpl_Index(t *testing.T) {
+	recurringOperationService := RecurringOperationServiceInit(&MockRecurringOperationRepository{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the user has recurring operations",
+			Params:       dao.User{ID: 1},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"type\":\"expense\",\"amount\":\"500.00\",\"currency\":\"ARS\",\"description\":\"Rent\",\"category\":{\"name\":\"Home\",\"color\":\"#6495ed\"},\"account_id\":null,\"frequency\":\"monthly\",\"day_of_month\":0,\"start_date\":\"2023-10-01T13:00:00Z\",\"end_date\":null,\"count\":0,\"occurrences_created\":1,\"next_run_at\":\"2023-11-01T13:00:00Z\"}]",
+		},
+		{
+			Name:         "when the user has no recurring operations",
+			Params:       dao.User{ID: 2},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := recurringOperationService.Index(tt.Params.(dao.User))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestRecurringOperationServiceImpl_Create(t *testing.T) {
+	recurringOperationService := RecurringOperationServiceInit(&MockRecurringOperationRepository{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the recurring operation is created successfully",
+			Params:       dto.RecurringOperationRequest{Type: "expense", Amount: money.FromFloat(500), Description: "Rent", CategoryID: "1", Frequency: "monthly", StartDate: "2023-10-01T10:00:00Z"},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Recurring operation successfully created.\"}",
+		},
+		{
+			Name:         "when the recurring operation has invalid category ID",
+			Params:       dto.RecurringOperationRequest{Type: "expense", Amount: money.FromFloat(500), Description: "Rent", CategoryID: "2", Frequency: "monthly", StartDate: "2023-10-01T10:00:00Z"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid category.\"}",
+		},
+		{
+			Name:         "when there is an error in the creation of the recurring operation",
+			Params:       dto.RecurringOperationRequest{Type: "expense", Amount: money.FromFloat(500), Description: "Payment for work", CategoryID: "1", Frequency: "monthly", StartDate: "2023-10-01T10:00:00Z"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the creation of the recurring operation.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := recurringOperationService.Create(dao.User{ID: 1}, tt.Params.(dto.RecurringOperationRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestRecurringOperationServiceImpl_Update(t *testing.T) {
+	recurringOperationService := RecurringOperationServiceInit(&MockRecurringOperationRepository{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{})
+	request := dto.RecurringOperationRequest{Type: "expense", Amount: money.FromFloat(550), Description: "Rent", CategoryID: "1", Frequency: "monthly", StartDate: "2023-10-01T10:00:00Z"}
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the recurring operation is updated successfully",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Recurring operation successfully updated.\"}",
+		},
+		{
+			Name:         "when the recurring operation is not found",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error in the update of the recurring operation",
+			Params:       3,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the recurring operation.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := recurringOperationService.Update(dao.User{ID: 1}, request, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestRecurringOperationServiceImpl_Delete(t *testing.T) {
+	recurringOperationService := RecurringOperationServiceInit(&MockRecurringOperationRepository{}, &MockCategoryRepositoryOperations{}, &MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the recurring operation is found",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Recurring operation successfully deleted.\"}",
+		},
+		{
+			Name:         "when the recurring operation is not found",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error while deleting the recurring operation",
+			Params:       3,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while deleting the recurring operation.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := recurringOperationService.Delete(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"GoGin-API-CuentasClaras/repository"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportService interface {
+	Categories(user dao.User, reportFilter dto.CategoryReportFilter) (int, interface{})
+}
+
+type ReportServiceImpl struct {
+	reportingRepository repository.ReportingRepository
+}
+
+// Categories breaks down the expenses, or the incomes, of the period by
+// category, with the share each one takes of the total of its currency.
+// Uncategorized amounts are kept apart from the categories.
+func (u ReportServiceImpl) Categories(user dao.User, reportFilter dto.CategoryReportFilter) (int, interface{}) {
+	if reportFilter.Type == "" {
+		reportFilter.Type = EXPENSE_TYPE
+	}
+	rows, recordError := u.reportingRepository.FindCategoryTotals(user, reportFilter)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while listing the category report."}
+	}
+
+	currencies := []dto.CategoryReportCurrency{}
+	for _, row := range rows {
+		if len(currencies) == 0 || currencies[len(currencies)-1].Currency != row.Currency {
+			currencies = append(currencies, dto.CategoryReportCurrency{Currency: row.Currency, Categories: []dto.CategoryReportLine{}})
+		}
+		currency := &currencies[len(currencies)-1]
+		currency.Total += row.Total
+		if row.CategoryID == nil {
+			currency.Uncategorized.Total += row.Total
+			currency.Uncategorized.Count += row.Count
+			continue
+		}
+		currency.Categories = append(currency.Categories, dto.CategoryReportLine{
+			CategoryID: *row.CategoryID,
+			Name:       row.Name,
+			Color:      row.Color,
+			IsDefault:  row.IsDefault,
+			Total:      row.Total,
+			Count:      row.Count,
+		})
+	}
+	for i := range currencies {
+		currency := &currencies[i]
+		for j := range currency.Categories {
+			currency.Categories[j].Percentage = percentageOf(currency.Categories[j].Total, currency.Total)
+		}
+		currency.Uncategorized.Percentage = percentageOf(currency.Uncategorized.Total, currency.Total)
+	}
+
+	return http.StatusOK, dto.CategoryReportResponse{Type: reportFilter.Type, Currencies: currencies}
+}
+
+// percentageOf returns the share of the amount in the total, rounded to two
+// decimals.
+func percentageOf(amount money.Amount, total money.Amount) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(amount)*10000/float64(total)) / 100
+}
+
+func ReportServiceInit(reportingRepository repository.ReportingRepository) *ReportServiceImpl {
+	return &ReportServiceImpl{
+		reportingRepository: reportingRepository,
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"net/http"
+	"testing"
+)
+
+func TestReportServiceImpl_Categories(t *testing.T) {
+	reportService := ReportServiceInit(&MockReportingRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the expenses are broken down by category",
+			Params:       dto.CategoryReportFilter{},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"type\":\"expense\",\"currencies\":[" +
+				"{\"currency\":\"ARS\",\"total\":\"4500.00\",\"categories\":[" +
+				"{\"category_id\":1,\"name\":\"Work\",\"color\":\"#fdg123\",\"is_default\":true,\"total\":\"3000.00\",\"count\":2,\"percentage\":66.67}," +
+				"{\"category_id\":2,\"name\":\"Custom\",\"color\":\"#6495ed\",\"is_default\":false,\"total\":\"500.00\",\"count\":1,\"percentage\":11.11}]," +
+				"\"uncategorized\":{\"total\":\"1000.00\",\"count\":1,\"percentage\":22.22}}," +
+				"{\"currency\":\"USD\",\"total\":\"10.00\",\"categories\":[" +
+				"{\"category_id\":1,\"name\":\"Work\",\"color\":\"#fdg123\",\"is_default\":true,\"total\":\"10.00\",\"count\":1,\"percentage\":100}]," +
+				"\"uncategorized\":{\"total\":\"0.00\",\"count\":0,\"percentage\":0}}]}",
+		},
+		{
+			Name:         "when the incomes are broken down by category",
+			Params:       dto.CategoryReportFilter{Type: "income"},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"type\":\"income\",\"currencies\":[{\"currency\":\"ARS\",\"total\":\"1200.50\",\"categories\":[" +
+				"{\"category_id\":1,\"name\":\"Work\",\"color\":\"#fdg123\",\"is_default\":true,\"total\":\"1200.50\",\"count\":1,\"percentage\":100}]," +
+				"\"uncategorized\":{\"total\":\"0.00\",\"count\":0,\"percentage\":0}}]}",
+		},
+		{
+			Name:         "when the user has no operations",
+			Params:       dto.CategoryReportFilter{},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"type\":\"expense\",\"currencies\":[]}",
+		},
+		{
+			Name:         "when there is an error listing the totals",
+			Params:       dto.CategoryReportFilter{},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while listing the category report.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			user := dao.User{ID: 1}
+
+			if tt.Name == "when the user has no operations" {
+				user.ID = 2
+			} else if tt.Name == "when there is an error listing the totals" {
+				user.ID = 3
+			}
+
+			code, response := reportService.Categories(user, tt.Params.(dto.CategoryReportFilter))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
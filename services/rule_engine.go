@@ -0,0 +1,157 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ruleSubject is the part of an operation rules match on and change.
+type ruleSubject struct {
+	Type        string
+	Amount      money.Amount
+	Description string
+	CategoryID  int
+	Tags        []string
+}
+
+type compiledRule struct {
+	rule  dao.Rule
+	regex *regexp.Regexp
+}
+
+// ruleSet holds the user's rules in the order they run, with their regular
+// expressions compiled once.
+type ruleSet []compiledRule
+
+func newRuleSet(rules []dao.Rule) ruleSet {
+	set := ruleSet{}
+	for _, rule := range rules {
+		compiled := compiledRule{rule: rule}
+		if rule.DescriptionRegex != "" {
+			regex, err := regexp.Compile(rule.DescriptionRegex)
+			if err != nil {
+				continue
+			}
+			compiled.regex = regex
+		}
+		set = append(set, compiled)
+	}
+	return set
+}
+
+// apply runs the rules on the subject and returns the ids of the ones that
+// match. The category and the description come from the first matching rule
+// that sets them; the tags of every matching rule are added. All rules match
+// on the subject as it was before any of them changed it.
+func (set ruleSet) apply(subject *ruleSubject) []int {
+	original := *subject
+	ruleIDs := []int{}
+	categorySet, descriptionSet := false, false
+	for _, compiled := range set {
+		if !compiled.matches(original) {
+			continue
+		}
+		ruleIDs = append(ruleIDs, compiled.rule.ID)
+		if compiled.rule.SetCategoryID != nil && !categorySet {
+			subject.CategoryID = *compiled.rule.SetCategoryID
+			categorySet = true
+		}
+		if compiled.rule.SetDescription != "" && !descriptionSet {
+			subject.Description = compiled.rule.SetDescription
+			descriptionSet = true
+		}
+		subject.Tags = NormalizeTags(append(subject.Tags, ruleTags(compiled.rule)...))
+	}
+	return ruleIDs
+}
+
+// fill runs the rules on a new operation like apply, but keeps the category the
+// operation already has. Rules only set the category of the ones without one.
+func (set ruleSet) fill(subject *ruleSubject) []int {
+	categoryID := subject.CategoryID
+	ruleIDs := set.apply(subject)
+	if categoryID != 0 {
+		subject.CategoryID = categoryID
+	}
+	return ruleIDs
+}
+
+func (compiled compiledRule) matches(subject ruleSubject) bool {
+	rule := compiled.rule
+	if rule.DescriptionContains != "" && !strings.Contains(strings.ToLower(subject.Description), strings.ToLower(rule.DescriptionContains)) {
+		return false
+	}
+	if compiled.regex != nil && !compiled.regex.MatchString(subject.Description) {
+		return false
+	}
+	if rule.Type != "" && rule.Type != subject.Type {
+		return false
+	}
+	if rule.MinAmount != nil && subject.Amount < *rule.MinAmount {
+		return false
+	}
+	return rule.MaxAmount == nil || subject.Amount <= *rule.MaxAmount
+}
+
+// applyToRequest runs the rules on an operation about to be created, filling
+// in the request with what they set.
+func (set ruleSet) applyToRequest(operationRequest dto.OperationRequest) dto.OperationRequest {
+	categoryID, _ := strconv.Atoi(operationRequest.CategoryID)
+	subject := ruleSubject{
+		Type:        operationRequest.Type,
+		Amount:      operationRequest.Amount,
+		Description: operationRequest.Description,
+		CategoryID:  categoryID,
+		Tags:        operationRequest.Tags,
+	}
+	if len(set.fill(&subject)) == 0 {
+		return operationRequest
+	}
+	if subject.CategoryID != 0 {
+		operationRequest.CategoryID = strconv.Itoa(subject.CategoryID)
+	}
+	operationRequest.Description = subject.Description
+	operationRequest.Tags = subject.Tags
+	return operationRequest
+}
+
+func operationRuleSubject(operation dao.Operation) ruleSubject {
+	subject := ruleSubject{
+		Type:        operation.Type,
+		Amount:      operation.Amount,
+		Description: operation.Description,
+		Tags:        tagNames(operation.Tags),
+	}
+	if operation.CategoryID != nil {
+		subject.CategoryID = *operation.CategoryID
+	}
+	return subject
+}
+
+// ruleChanges lists the fields of the operation the rules changed in the
+// subject.
+func ruleChanges(operation dao.Operation, subject ruleSubject) []dto.AuditChange {
+	changes := []dto.AuditChange{}
+	before := operationRuleSubject(operation)
+	if subject.CategoryID != before.CategoryID {
+		changes = append(changes, dto.AuditChange{Field: "category_id", Before: operation.CategoryID, After: subject.CategoryID})
+	}
+	if subject.Description != before.Description {
+		changes = append(changes, dto.AuditChange{Field: "description", Before: before.Description, After: subject.Description})
+	}
+	if len(subject.Tags) != len(before.Tags) {
+		changes = append(changes, dto.AuditChange{Field: "tags", Before: before.Tags, After: subject.Tags})
+	}
+	return changes
+}
+
+func ruleTags(rule dao.Rule) []string {
+	if rule.AddTags == "" {
+		return []string{}
+	}
+	return strings.Split(rule.AddTags, ",")
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleSetApply(t *testing.T) {
+	transportID, foodID := 2, 3
+	minAmount, maxAmount := money.FromFloat(10), money.FromFloat(100)
+	set := newRuleSet([]dao.Rule{
+		{ID: 1, DescriptionContains: "UBER", SetCategoryID: &transportID, AddTags: "transport"},
+		{ID: 2, DescriptionRegex: `(?i)eats`, Type: "expense", MinAmount: &minAmount, MaxAmount: &maxAmount, SetCategoryID: &foodID, AddTags: "food,delivery", SetDescription: "Uber Eats"},
+		{ID: 3, DescriptionRegex: `(`, SetDescription: "Invalid"},
+		{ID: 4, Type: "income", AddTags: "income"},
+	})
+
+	subject := ruleSubject{Type: "expense", Amount: money.FromFloat(25), Description: "uber eats 1234", Tags: []string{"reimbursable"}}
+	assert.Equal(t, []int{1, 2}, set.apply(&subject))
+	assert.Equal(t, transportID, subject.CategoryID)
+	assert.Equal(t, "Uber Eats", subject.Description)
+	assert.Equal(t, []string{"reimbursable", "transport", "food", "delivery"}, subject.Tags)
+
+	subject = ruleSubject{Type: "expense", Amount: money.FromFloat(250), Description: "Uber Eats"}
+	assert.Equal(t, []int{1}, set.apply(&subject))
+	assert.Equal(t, "Uber Eats", subject.Description)
+
+	subject = ruleSubject{Type: "expense", Amount: money.FromFloat(25), Description: "Coffee shop", CategoryID: 5}
+	assert.Empty(t, set.apply(&subject))
+	assert.Equal(t, ruleSubject{Type: "expense", Amount: money.FromFloat(25), Description: "Coffee shop", CategoryID: 5}, subject)
+}
+
+func TestRuleSetFill(t *testing.T) {
+	transportID := 2
+	set := newRuleSet([]dao.Rule{
+		{ID: 1, DescriptionContains: "UBER", SetCategoryID: &transportID, AddTags: "transport", SetDescription: "Uber"},
+	})
+
+	subject := ruleSubject{Type: "expense", Amount: money.FromFloat(25), Description: "UBER TRIP"}
+	assert.Equal(t, []int{1}, set.fill(&subject))
+	assert.Equal(t, transportID, subject.CategoryID)
+
+	subject = ruleSubject{Type: "expense", Amount: money.FromFloat(25), Description: "UBER TRIP", CategoryID: 5}
+	assert.Equal(t, []int{1}, set.fill(&subject))
+	assert.Equal(t, 5, subject.CategoryID)
+	assert.Equal(t, "Uber", subject.Description)
+	assert.Equal(t, []string{"transport"}, subject.Tags)
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/repository"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RuleService interface {
+	Index(user dao.User) (int, interface{})
+	Show(user dao.User, ruleID int) (int, interface{})
+	Create(user dao.User, ruleRequest dto.RuleRequest) (int, interface{})
+	Update(user dao.User, ruleRequest dto.RuleRequest, ruleID int) (int, interface{})
+	Delete(user dao.User, ruleID int) (int, interface{})
+}
+
+type RuleServiceImpl struct {
+	ruleRepository     repository.RuleRepository
+	categoryRepository repository.CategoryRepository
+}
+
+func (u RuleServiceImpl) Index(user dao.User) (int, interface{}) {
+	rules, recordError := u.ruleRepository.FindRulesByUser(user)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while listing the rules."}
+	}
+	transformedResponse := []dto.TransformedRule{}
+	for _, rule := range rules {
+		transformedResponse = append(transformedResponse, transformRule(rule))
+	}
+	return http.StatusOK, transformedResponse
+}
+
+func (u RuleServiceImpl) Show(user dao.User, ruleID int) (int, interface{}) {
+	rule, recordError := u.ruleRepository.FindRuleByUserAndId(user, ruleID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	return http.StatusOK, transformRule(rule)
+}
+
+func (u RuleServiceImpl) Create(user dao.User, ruleRequest dto.RuleRequest) (int, interface{}) {
+	if ruleRequest.SetCategoryID != "" && invalidCategoryID(ruleRequest.SetCategoryID, u.categoryRepository) {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid category."}
+	}
+
+	ruleDao := buildRule(user, ruleRequest)
+	_, recordError := u.ruleRepository.Save(&ruleDao)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the rule."}
+	}
+
+	return http.StatusCreated, gin.H{"message": "Rule successfully created."}
+}
+
+func (u RuleServiceImpl) Update(user dao.User, ruleRequest dto.RuleRequest, ruleID int) (int, interface{}) {
+	rule, recordError := u.ruleRepository.FindRuleByUserAndId(user, ruleID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	if ruleRequest.SetCategoryID != "" && invalidCategoryID(ruleRequest.SetCategoryID, u.categoryRepository) {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid category."}
+	}
+
+	ruleDao := buildRule(user, ruleRequest)
+	ruleDao.ID = rule.ID
+	_, recordError = u.ruleRepository.Update(&ruleDao)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the rule."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Rule successfully updated."}
+}
+
+func (u RuleServiceImpl) Delete(user dao.User, ruleID int) (int, interface{}) {
+	rule, recordError := u.ruleRepository.FindRuleByUserAndId(user, ruleID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	_, recordError = u.ruleRepository.Delete(&rule)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while deleting the rule."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Rule successfully deleted."}
+}
+
+func buildRule(user dao.User, ruleRequest dto.RuleRequest) dao.Rule {
+	rule := dao.Rule{
+		UserID:              uint(user.ID),
+		Name:                strings.TrimSpace(ruleRequest.Name),
+		Priority:            ruleRequest.Priority,
+		DescriptionContains: ruleRequest.DescriptionContains,
+		DescriptionRegex:    ruleRequest.DescriptionRegex,
+		Type:                ruleRequest.Type,
+		MinAmount:           ruleRequest.MinAmount,
+		MaxAmount:           ruleRequest.MaxAmount,
+		AddTags:             strings.Join(NormalizeTags(ruleRequest.AddTags), ","),
+		SetDescription:      ruleRequest.SetDescription,
+	}
+	if categoryID, err := strconv.Atoi(ruleRequest.SetCategoryID); err == nil {
+		rule.SetCategoryID = &categoryID
+	}
+	return rule
+}
+
+func transformRule(rule dao.Rule) dto.TransformedRule {
+	return dto.TransformedRule{
+		ID:                  rule.ID,
+		Name:                rule.Name,
+		Priority:            rule.Priority,
+		DescriptionContains: rule.DescriptionContains,
+		DescriptionRegex:    rule.DescriptionRegex,
+		Type:                rule.Type,
+		MinAmount:           rule.MinAmount,
+		MaxAmount:           rule.MaxAmount,
+		SetCategoryID:       rule.SetCategoryID,
+		AddTags:             ruleTags(rule),
+		SetDescription:      rule.SetDescription,
+	}
+}
+
+func RuleServiceInit(ruleRepository repository.RuleRepository, categoryRepository repository.CategoryRepository) *RuleServiceImpl {
+	return &RuleServiceImpl{
+		ruleRepository:     ruleRepository,
+		categoryRepository: categoryRepository,
+	}
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type MockRuleRepository struct{}
+
+func (u MockRuleRepository) FindRulesByUser(user dao.User) ([]dao.Rule, error) {
+	if user.ID == 3 {
+		return nil, errors.New("Database error.")
+	}
+	if user.ID != 1 && user.ID != 4 {
+		return []dao.Rule{}, nil
+	}
+	categoryID, minAmount := 1, money.FromFloat(1000)
+	return []dao.Rule{
+		{ID: 1, Name: "Uber rides", DescriptionRegex: `(?i)^uber\b`, Type: "expense", SetCategoryID: &categoryID, AddTags: "transport", SetDescription: "Uber"},
+		{ID: 2, Name: "Salary", Priority: 1, Type: "income", MinAmount: &minAmount, AddTags: "salary"},
+	}, nil
+}
+
+func (u MockRuleRepository) FindRuleByUserAndId(user dao.User, ruleID int) (dao.Rule, error) {
+	rules, _ := u.FindRulesByUser(user)
+	for _, rule := range rules {
+		if rule.ID == ruleID {
+			return rule, nil
+		}
+	}
+	return dao.Rule{}, errors.New("Rule not found.")
+}
+
+func (u MockRuleRepository) Save(rule *dao.Rule) (dao.Rule, error) {
+	if rule.Name == "Payment for work" {
+		return dao.Rule{}, errors.New("Invalid rule.")
+	}
+	return *rule, nil
+}
+
+func (u MockRuleRepository) Update(rule *dao.Rule) (dao.Rule, error) {
+	if rule.Name == "Payment for work" {
+		return dao.Rule{}, errors.New("Invalid rule.")
+	}
+	return *rule, nil
+}
+
+func (u MockRuleRepository) Delete(rule *dao.Rule) (dao.Rule, error) {
+	if rule.ID == 2 {
+		return dao.Rule{}, errors.New("Invalid rule.")
+	}
+	return *rule, nil
+}
+
+func TestRuleServiceImpl_Index(t *testing.T) {
+	ruleService := RuleServiceInit(&MockRuleRepository{}, &MockCategoryRepositoryOperations{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the user has rules",
+			Params:       dao.User{ID: 1},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"name\":\"Uber rides\",\"priority\":0,\"description_contains\":\"\",\"description_regex\":\"(?i)^uber\\\\b\",\"type\":\"expense\",\"min_amount\":null,\"max_amount\":null,\"set_category_id\":1,\"add_tags\":[\"transport\"],\"set_description\":\"Uber\"}," +
+				"{\"id\":2,\"name\":\"Salary\",\"priority\":1,\"description_contains\":\"\",\"description_regex\":\"\",\"type\":\"income\",\"min_amount\":\"1000.00\",\"max_amount\":null,\"set_category_id\":null,\"add_tags\":[\"salary\"],\"set_description\":\"\"}]",
+		},
+		{
+			Name:         "when the user has no rules",
+			Params:       dao.User{ID: 2},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+		{
+			Name:         "when there is an error listing the rules",
+			Params:       dao.User{ID: 3},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while listing the rules.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := ruleService.Index(tt.Params.(dao.User))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestRuleServiceImpl_Show(t *testing.T) {
+	ruleService := RuleServiceInit(&MockRuleRepository{}, &MockCategoryRepositoryOperations{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the rule is found",
+			Params:       2,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":2,\"name\":\"Salary\",\"priority\":1,\"description_contains\":\"\",\"description_regex\":\"\",\"type\":\"income\",\"min_amount\":\"1000.00\",\"max_amount\":null,\"set_category_id\":null,\"add_tags\":[\"salary\"],\"set_description\":\"\"}",
+		},
+		{
+			Name:         "when the rule is not found",
+			Params:       9,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := ruleService.Show(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestRuleServiceImpl_Create(t *testing.T) {
+	ruleService := RuleServiceInit(&MockRuleRepository{}, &MockCategoryRepositoryOperations{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the rule is created successfully",
+			Params:       dto.RuleRequest{Name: "Coffee", DescriptionContains: "coffee", SetCategoryID: "1", AddTags: []string{"Coffee"}},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Rule successfully created.\"}",
+		},
+		{
+			Name:         "when the rule sets an invalid category",
+			Params:       dto.RuleRequest{Name: "Coffee", DescriptionContains: "coffee", SetCategoryID: "2"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid category.\"}",
+		},
+		{
+			Name:         "when there is an error in the creation of the rule",
+			Params:       dto.RuleRequest{Name: "Payment for work", DescriptionContains: "work", SetDescription: "Work"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the creation of the rule.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := ruleService.Create(dao.User{ID: 1}, tt.Params.(dto.RuleRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestRuleServiceImpl_Update(t *testing.T) {
+	ruleService := RuleServiceInit(&MockRuleRepository{}, &MockCategoryRepositoryOperations{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the rule is updated successfully",
+			Params:       dto.RuleRequest{Name: "Uber rides", DescriptionContains: "uber", SetCategoryID: "1"},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Rule successfully updated.\"}",
+		},
+		{
+			Name:         "when the rule is not found",
+			Params:       dto.RuleRequest{Name: "Uber rides", DescriptionContains: "uber", SetCategoryID: "1"},
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the rule sets an invalid category",
+			Params:       dto.RuleRequest{Name: "Uber rides", DescriptionContains: "uber", SetCategoryID: "2"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid category.\"}",
+		},
+		{
+			Name:         "when there is an error in the update of the rule",
+			Params:       dto.RuleRequest{Name: "Payment for work", DescriptionContains: "work", SetDescription: "Work"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the rule.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ruleID := 1
+
+			if tt.Name == "when the rule is not found" {
+				ruleID = 9
+			}
+
+			code, response := ruleService.Update(dao.User{ID: 1}, tt.Params.(dto.RuleRequest), ruleID)
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestRuleServiceImpl_Delete(t *testing.T) {
+	ruleService := RuleServiceInit(&MockRuleRepository{}, &MockCategoryRepositoryOperations{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the rule is deleted successfully",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Rule successfully deleted.\"}",
+		},
+		{
+			Name:         "when the rule is not found",
+			Params:       9,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error deleting the rule",
+			Params:       2,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while deleting the rule.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := ruleService.Delete(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
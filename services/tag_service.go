@@ -0,0 +1,64 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/repository"
+	"net/http"
+	"strings"
+)
+
+const MAX_TAGS int = 20
+const MAX_TAG_LENGTH int = 50
+
+type TagService interface {
+	Index(user dao.User) (int, []dto.TransformedTag)
+}
+
+type TagServiceImpl struct {
+	tagRepository repository.TagRepository
+}
+
+func (u TagServiceImpl) Index(user dao.User) (int, []dto.TransformedTag) {
+	tags, _ := u.tagRepository.FindTagsByUser(user)
+	transformedResponse := []dto.TransformedTag{}
+	for _, tag := range tags {
+		transformedResponse = append(transformedResponse, dto.TransformedTag{
+			ID:              tag.ID,
+			Name:            tag.Name,
+			OperationsCount: tag.OperationsCount,
+		})
+	}
+
+	return http.StatusOK, transformedResponse
+}
+
+// NormalizeTags trims and lowercases the tags and drops the empty and repeated
+// ones, keeping their order.
+func NormalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		name := strings.ToLower(strings.TrimSpace(tag))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
+
+func tagNames(tags []dao.Tag) []string {
+	names := []string{}
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func TagServiceInit(tagRepository repository.TagRepository) *TagServiceImpl {
+	return &TagServiceImpl{
+		tagRepository: tagRepository,
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MockTagRepository struct{}
+
+func (u MockTagRepository) FindTagsByUser(user dao.User) ([]dao.Tag, error) {
+	if user.ID == 1 {
+		return []dao.Tag{
+			{ID: 1, Name: "reimbursable", OperationsCount: 3},
+			{ID: 2, Name: "vacation-2026", OperationsCount: 0},
+		}, nil
+	}
+	return []dao.Tag{}, nil
+}
+
+func (u MockTagRepository) FindOrCreateTags(user dao.User, names []string) ([]dao.Tag, error) {
+	tags := []dao.Tag{}
+	for index, name := range names {
+		if name == "invalid" {
+			return nil, errors.New("Invalid tag.")
+		}
+		tags = append(tags, dao.Tag{ID: index + 1, UserID: uint(user.ID), Name: name})
+	}
+	return tags, nil
+}
+
+func TestTagServiceImpl_Index(t *testing.T) {
+	tagService := TagServiceInit(&MockTagRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the user has tags",
+			Params:       dao.User{ID: 1},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"name\":\"reimbursable\",\"operations_count\":3},{\"id\":2,\"name\":\"vacation-2026\",\"operations_count\":0}]",
+		},
+		{
+			Name:         "when the user has no tags",
+			Params:       dao.User{ID: 2},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := tagService.Index(tt.Params.(dao.User))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	assert.Equal(t, []string{"vacation-2026", "shared"}, NormalizeTags([]string{" Vacation-2026 ", "shared", "", "SHARED"}))
+	assert.Equal(t, []string{}, NormalizeTags(nil))
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/repository"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferService interface {
+	Index(user dao.User) (int, []dto.TransformedTransfer)
+	Show(user dao.User, transferID int) (int, interface{})
+	Create(user dao.User, transferRequest dto.TransferRequest) (int, interface{})
+	Update(user dao.User, transferRequest dto.TransferRequest, transferID int) (int, interface{})
+	Delete(user dao.User, transferID int) (int, interface{})
+}
+
+type TransferServiceImpl struct {
+	transferRepository repository.TransferRepository
+	accountRepository  repository.AccountRepository
+}
+
+func (u TransferServiceImpl) Index(user dao.User) (int, []dto.TransformedTransfer) {
+	transfers, _ := u.transferRepository.FindTransfersByUser(user)
+	transformedResponse := []dto.TransformedTransfer{}
+	for _, transfer := range transfers {
+		transformedResponse = append(transformedResponse, transformTransfer(transfer))
+	}
+
+	return http.StatusOK, transformedResponse
+}
+
+func (u TransferServiceImpl) Show(user dao.User, transferID int) (int, interface{}) {
+	transfer, recordError := u.transferRepository.FindTransferByUserAndId(user, transferID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	return http.StatusOK, transformTransfer(transfer)
+}
+
+func (u TransferServiceImpl) Create(user dao.User, transferRequest dto.TransferRequest) (int, interface{}) {
+	invalidAccounts, fromAccount, toAccount := u.validateTransferAccounts(user, transferRequest)
+	if invalidAccounts {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid account."}
+	}
+
+	transferDao := buildTransfer(user, transferRequest, dao.Transfer{}, fromAccount, toAccount)
+
+	_, recordError := u.transferRepository.Save(&transferDao)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the creation of the transfer."}
+	}
+
+	return http.StatusCreated, gin.H{"message": "Transfer successfully created."}
+}
+
+func (u TransferServiceImpl) Update(user dao.User, transferRequest dto.TransferRequest, transferID int) (int, interface{}) {
+	transfer, recordError := u.transferRepository.FindTransferByUserAndId(user, transferID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	if transferReconciled(transfer) {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Reconciled operations cannot be changed."}
+	}
+
+	invalidAccounts, fromAccount, toAccount := u.validateTransferAccounts(user, transferRequest)
+	if invalidAccounts {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid account."}
+	}
+
+	transferDao := buildTransfer(user, transferRequest, transfer, fromAccount, toAccount)
+
+	_, recordError = u.transferRepository.Update(&transferDao)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the transfer."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Transfer successfully updated."}
+}
+
+func (u TransferServiceImpl) Delete(user dao.User, transferID int) (int, interface{}) {
+	transfer, recordError := u.transferRepository.FindTransferByUserAndId(user, transferID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+	if transferReconciled(transfer) {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Reconciled operations cannot be changed."}
+	}
+
+	_, recordError = u.transferRepository.Delete(&transfer)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while deleting the transfer."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Transfer successfully deleted."}
+}
+
+func (u TransferServiceImpl) validateTransferAccounts(user dao.User, transferRequest dto.TransferRequest) (bool, dao.Account, dao.Account) {
+	fromAccount, errFindFromAccount := u.accountRepository.FindAccountByUserAndId(user, transferRequest.FromAccountID)
+	toAccount, errFindToAccount := u.accountRepository.FindAccountByUserAndId(user, transferRequest.ToAccountID)
+	return errFindFromAccount != nil || errFindToAccount != nil || fromAccount.ID == toAccount.ID, fromAccount, toAccount
+}
+
+// transferReconciled tells whether a side of the transfer is reconciled, which
+// locks the whole transfer.
+func transferReconciled(transfer dao.Transfer) bool {
+	for _, operation := range transfer.Operations {
+		if operation.Status == dto.OperationStatusReconciled {
+			return true
+		}
+	}
+	return false
+}
+
+// buildTransfer applies the request on top of transfer, keeping the IDs of the
+// operations already stored. The amount leaves the origin account as an expense
+// and to_amount, which defaults to the amount, enters the destination as an income.
+func buildTransfer(user dao.User, transferRequest dto.TransferRequest, transfer dao.Transfer, fromAccount dao.Account, toAccount dao.Account) dao.Transfer {
+	date, _ := time.Parse(time.RFC3339, transferRequest.Date)
+	toAmount := transferRequest.ToAmount
+	if toAmount == 0 {
+		toAmount = transferRequest.Amount
+	}
+
+	transfer.UserID = uint(user.ID)
+	transfer.FromAccountID = fromAccount.ID
+	transfer.ToAccountID = toAccount.ID
+	transfer.Amount = transferRequest.Amount
+	transfer.ToAmount = toAmount
+	transfer.Date = date
+	transfer.Description = transferRequest.Description
+
+	outgoing := dao.Operation{Type: EXPENSE_TYPE, AccountID: &transfer.FromAccountID, Currency: operationCurrency(user, fromAccount.Currency)}
+	incoming := dao.Operation{Type: INCOME_TYPE, AccountID: &transfer.ToAccountID, Currency: operationCurrency(user, toAccount.Currency)}
+	for _, operation := range transfer.Operations {
+		if operation.Type == EXPENSE_TYPE {
+			outgoing.ID = operation.ID
+		} else {
+			incoming.ID = operation.ID
+		}
+	}
+	transfer.Operations = []dao.Operation{outgoing, incoming}
+	syncTransferOperations(&transfer)
+
+	return transfer
+}
+
+// syncTransferOperations copies the date, description and amounts of the
+// transfer to both of its operations.
+func syncTransferOperations(transfer *dao.Transfer) {
+	for index := range transfer.Operations {
+		operation := &transfer.Operations[index]
+		operation.UserID = transfer.UserID
+		operation.Date = transfer.Date
+		operation.Description = transfer.Description
+		operation.Amount = transfer.ToAmount
+		if operation.Type == EXPENSE_TYPE {
+			operation.Amount = transfer.Amount
+		}
+	}
+}
+
+func transformTransfer(transfer dao.Transfer) dto.TransformedTransfer {
+	return dto.TransformedTransfer{
+		ID:            transfer.ID,
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		ToAmount:      transfer.ToAmount,
+		Date:          transfer.Date.In(utcLocation),
+		Description:   transfer.Description,
+	}
+}
+
+func TransferServiceInit(transferRepository repository.TransferRepository, accountRepository repository.AccountRepository) *TransferServiceImpl {
+	return &TransferServiceImpl{
+		transferRepository: transferRepository,
+		accountRepository:  accountRepository,
+	}
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MockTransferRepository struct{}
+
+func (u MockTransferRepository) FindTransfersByUser(user dao.User) ([]dao.Transfer, error) {
+	if user.ID == 1 {
+		transfer, _ := u.FindTransferByUserAndId(user, 1)
+		return []dao.Transfer{transfer}, nil
+	}
+	return []dao.Transfer{}, nil
+}
+
+func (u MockTransferRepository) FindTransferByUserAndId(user dao.User, transferID int) (dao.Transfer, error) {
+	date, _ := time.Parse(time.RFC3339, "2023-10-23T21:33:03Z")
+	fromAccountID, toAccountID := 1, 2
+
+	if transferID == 1 || transferID == 3 {
+		return dao.Transfer{
+			ID:            transferID,
+			FromAccountID: fromAccountID,
+			ToAccountID:   toAccountID,
+			Amount:        money.FromFloat(3500),
+			ToAmount:      money.FromFloat(10),
+			Date:          date,
+			Description:   "Savings",
+			Operations: []dao.Operation{
+				{ID: 10, Type: "expense", Amount: money.FromFloat(3500), Currency: "ARS", AccountID: &fromAccountID, TransferID: &transferID, Date: date},
+				{ID: 11, Type: "income", Amount: money.FromFloat(10), Currency: "USD", AccountID: &toAccountID, TransferID: &transferID, Date: date},
+			},
+		}, nil
+	}
+	return dao.Transfer{}, errors.New("Transfer not found.")
+}
+
+func (u MockTransferRepository) Save(transfer *dao.Transfer) (dao.Transfer, error) {
+	if transfer.Description == "Invalid" {
+		return dao.Transfer{}, errors.New("Invalid transfer.")
+	}
+	return *transfer, nil
+}
+
+func (u MockTransferRepository) Update(transfer *dao.Transfer) (dao.Transfer, error) {
+	if transfer.ID == 3 {
+		return dao.Transfer{}, errors.New("Invalid transfer.")
+	}
+	return *transfer, nil
+}
+
+func (u MockTransferRepository) Delete(transfer *dao.Transfer) (dao.Transfer, error) {
+	if transfer.ID == 3 {
+		return dao.Transfer{}, errors.New("Invalid transfer.")
+	}
+	return *transfer, nil
+}
+
+func TestTransferServiceImpl_Index(t *testing.T) {
+	transferService := TransferServiceInit(&MockTransferRepository{}, &MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the user has transfers",
+			Params:       dao.User{ID: 1},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[{\"id\":1,\"from_account_id\":1,\"to_account_id\":2,\"amount\":\"3500.00\",\"to_amount\":\"10.00\",\"date\":\"2023-10-23T21:33:03Z\",\"description\":\"Savings\"}]",
+		},
+		{
+			Name:         "when the user has no transfers",
+			Params:       dao.User{ID: 2},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := transferService.Index(tt.Params.(dao.User))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestTransferServiceImpl_Show(t *testing.T) {
+	transferService := TransferServiceInit(&MockTransferRepository{}, &MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the transfer is found",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"id\":1,\"from_account_id\":1,\"to_account_id\":2,\"amount\":\"3500.00\",\"to_amount\":\"10.00\",\"date\":\"2023-10-23T21:33:03Z\",\"description\":\"Savings\"}",
+		},
+		{
+			Name:         "when the transfer is not found",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := transferService.Show(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestTransferServiceImpl_Create(t *testing.T) {
+	transferService := TransferServiceInit(&MockTransferRepository{}, &MockAccountRepository{})
+	validDate := time.Now().Add(-time.Hour).Format(time.RFC3339)
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the transfer is created successfully",
+			Params:       dto.TransferRequest{FromAccountID: 1, ToAccountID: 2, Amount: money.FromFloat(3500), ToAmount: money.FromFloat(10), Date: validDate, Description: "Savings"},
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: "{\"message\":\"Transfer successfully created.\"}",
+		},
+		{
+			Name:         "when the origin account is invalid",
+			Params:       dto.TransferRequest{FromAccountID: 9, ToAccountID: 2, Amount: money.FromFloat(3500), Date: validDate},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid account.\"}",
+		},
+		{
+			Name:         "when there is an error in the creation of the transfer",
+			Params:       dto.TransferRequest{FromAccountID: 1, ToAccountID: 3, Amount: money.FromFloat(100), Date: validDate, Description: "Invalid"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the creation of the transfer.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := transferService.Create(dao.User{ID: 1}, tt.Params.(dto.TransferRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestTransferServiceImpl_Update(t *testing.T) {
+	transferService := TransferServiceInit(&MockTransferRepository{}, &MockAccountRepository{})
+	validDate := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	request := dto.TransferRequest{FromAccountID: 1, ToAccountID: 2, Amount: money.FromFloat(3600), ToAmount: money.FromFloat(10), Date: validDate, Description: "Savings"}
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the transfer is updated successfully",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Transfer successfully updated.\"}",
+		},
+		{
+			Name:         "when the transfer is not found",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error in the update of the transfer",
+			Params:       3,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the transfer.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := transferService.Update(dao.User{ID: 1}, request, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestTransferServiceImpl_Delete(t *testing.T) {
+	transferService := TransferServiceInit(&MockTransferRepository{}, &MockAccountRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the transfer is deleted successfully",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Transfer successfully deleted.\"}",
+		},
+		{
+			Name:         "when the transfer is not found",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error while deleting the transfer",
+			Params:       3,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while deleting the transfer.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := transferService.Delete(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestBuildTransfer(t *testing.T) {
+	existing, _ := MockTransferRepository{}.FindTransferByUserAndId(dao.User{ID: 1}, 1)
+	request := dto.TransferRequest{FromAccountID: 2, ToAccountID: 1, Amount: money.FromFloat(20), Date: "2023-10-24T10:00:00Z", Description: "Back"}
+	fromAccount := dao.Account{ID: 2, Currency: "USD"}
+	toAccount := dao.Account{ID: 1, Currency: "ARS"}
+
+	transfer := buildTransfer(dao.User{ID: 1}, request, existing, fromAccount, toAccount)
+
+	assert.Equal(t, money.FromFloat(20), transfer.ToAmount)
+	assert.Len(t, transfer.Operations, 2)
+	outgoing, incoming := transfer.Operations[0], transfer.Operations[1]
+	assert.Equal(t, 10, outgoing.ID)
+	assert.Equal(t, "expense", outgoing.Type)
+	assert.Equal(t, 2, *outgoing.AccountID)
+	assert.Equal(t, "USD", outgoing.Currency)
+	assert.Equal(t, 11, incoming.ID)
+	assert.Equal(t, "income", incoming.Type)
+	assert.Equal(t, 1, *incoming.AccountID)
+	assert.Equal(t, "Back", incoming.Description)
+	assert.Equal(t, uint(1), incoming.UserID)
+}
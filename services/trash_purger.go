@@ -0,0 +1,65 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/repository"
+	"os"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const DEFAULT_TRASH_PURGE_INTERVAL time.Duration = 24 * time.Hour
+const DEFAULT_TRASH_RETENTION_DAYS int = 30
+
+type TrashPurger interface {
+	Start()
+	Purge(now time.Time) (int64, int64)
+}
+
+type TrashPurgerImpl struct {
+	trashRepository repository.TrashRepository
+	interval        time.Duration
+	retentionDays   int
+}
+
+func (u TrashPurgerImpl) Start() {
+	go func() {
+		u.Purge(time.Now())
+		ticker := time.NewTicker(u.interval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			u.Purge(now)
+		}
+	}()
+	log.Info("Trash purger started with interval ", u.interval, " and retention of ", u.retentionDays, " days")
+}
+
+// Purge removes for good what was deleted more than the retention days before
+// now, returning how many operations and categories were removed.
+func (u TrashPurgerImpl) Purge(now time.Time) (int64, int64) {
+	operations, categories, err := u.trashRepository.PurgeDeletedBefore(now.AddDate(0, 0, -u.retentionDays))
+	if err != nil {
+		return 0, 0
+	}
+	if operations > 0 || categories > 0 {
+		log.Info("Trash purger removed ", operations, " operations and ", categories, " categories")
+	}
+	return operations, categories
+}
+
+func TrashPurgerInit(trashRepository repository.TrashRepository) *TrashPurgerImpl {
+	interval, err := time.ParseDuration(os.Getenv("TRASH_PURGE_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = DEFAULT_TRASH_PURGE_INTERVAL
+	}
+	retentionDays, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS"))
+	if err != nil || retentionDays <= 0 {
+		retentionDays = DEFAULT_TRASH_RETENTION_DAYS
+	}
+	return &TrashPurgerImpl{
+		trashRepository: trashRepository,
+		interval:        interval,
+		retentionDays:   retentionDays,
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashService interface {
+	Index(user dao.User) (int, interface{})
+	RestoreOperation(user dao.User, operationID int) (int, interface{})
+	RestoreCategory(user dao.User, categoryID int) (int, interface{})
+	PurgeOperation(user dao.User, operationID int) (int, interface{})
+	PurgeCategory(user dao.User, categoryID int) (int, interface{})
+}
+
+type TrashServiceImpl struct {
+	trashRepository repository.TrashRepository
+}
+
+func (u TrashServiceImpl) Index(user dao.User) (int, interface{}) {
+	operations, operationsError := u.trashRepository.FindDeletedOperations(user)
+	categories, categoriesError := u.trashRepository.FindDeletedCategories(user)
+	if operationsError != nil || categoriesError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while listing the trash."}
+	}
+
+	return http.StatusOK, dto.TransformedTrash{
+		Operations: operations,
+		Categories: categories,
+	}
+}
+
+// RestoreOperation brings the operation back unless its category or the
+// category of one of its splits is still in the trash, or its account was
+// deleted.
+func (u TrashServiceImpl) RestoreOperation(user dao.User, operationID int) (int, interface{}) {
+	operation, recordError := u.trashRepository.FindDeletedOperationByUserAndId(user, operationID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	deletedCategories, recordError := u.trashRepository.CountDeletedCategoriesByOperation(operation)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while restoring the operation."}
+	}
+	if deletedCategories > 0 {
+		return http.StatusUnprocessableEntity, gin.H{"error": "The category of the operation is in the trash."}
+	}
+
+	deletedAccounts, recordError := u.trashRepository.CountDeletedAccountsByOperation(operation)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while restoring the operation."}
+	}
+	if deletedAccounts > 0 {
+		return http.StatusUnprocessableEntity, gin.H{"error": "The account of the operation has been deleted."}
+	}
+
+	if _, recordError := u.trashRepository.RestoreOperation(&operation); recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while restoring the operation."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Operation successfully restored."}
+}
+
+func (u TrashServiceImpl) RestoreCategory(user dao.User, categoryID int) (int, interface{}) {
+	category, recordError := u.trashRepository.FindDeletedCategoryByUserAndId(user, categoryID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	if _, recordError := u.trashRepository.RestoreCategory(&category); recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while restoring the category."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Category successfully restored."}
+}
+
+func (u TrashServiceImpl) PurgeOperation(user dao.User, operationID int) (int, interface{}) {
+	operation, recordError := u.trashRepository.FindDeletedOperationByUserAndId(user, operationID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	if _, recordError := u.trashRepository.PurgeOperation(&operation); recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while purging the operation."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Operation successfully purged."}
+}
+
+// PurgeCategory removes the category for good once no operation, deleted or
+// not, is set on it.
+func (u TrashServiceImpl) PurgeCategory(user dao.User, categoryID int) (int, interface{}) {
+	category, recordError := u.trashRepository.FindDeletedCategoryByUserAndId(user, categoryID)
+	if recordError != nil {
+		return http.StatusNotFound, gin.H{"error": "Not found."}
+	}
+
+	references, recordError := u.trashRepository.CountCategoryReferences(category)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while purging the category."}
+	}
+	if references > 0 {
+		return http.StatusUnprocessableEntity, gin.H{"error": "The category is still used by operations."}
+	}
+
+	if _, recordError := u.trashRepository.PurgeCategory(&category); recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while purging the category."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Category successfully purged."}
+}
+
+func TrashServiceInit(trashRepository repository.TrashRepository) *TrashServiceImpl {
+	return &TrashServiceImpl{
+		trashRepository: trashRepository,
+	}
+}
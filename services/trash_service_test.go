@@ -0,0 +1,297 @@
+package services
+
+import (
+	dao "GoGin-API-CuentasClaras/dao"
+	"GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	testhelpers "GoGin-API-CuentasClaras/test_helpers"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var trashDeletedAt = time.Date(2023, 10, 24, 10, 0, 0, 0, time.UTC)
+
+type MockTrashRepository struct {
+	purgedBefore *time.Time
+}
+
+func (u MockTrashRepository) FindDeletedOperations(user dao.User) ([]dto.TrashedOperation, error) {
+	if user.ID == 2 {
+		return nil, errors.New("Invalid trash.")
+	}
+	categoryID := 1
+	return []dto.TrashedOperation{
+		{ID: 1, Type: "income", Amount: money.FromFloat(1200.50), Currency: "ARS", Date: trashDeletedAt, Description: "Salario", CategoryID: &categoryID, DeletedAt: trashDeletedAt},
+	}, nil
+}
+
+func (u MockTrashRepository) FindDeletedCategories(user dao.User) ([]dto.TrashedCategory, error) {
+	return []dto.TrashedCategory{
+		{ID: 2, Name: "Custom", Color: "#6495ed", Description: "Custom", DeletedAt: trashDeletedAt},
+	}, nil
+}
+
+func (u MockTrashRepository) FindDeletedOperationByUserAndId(user dao.User, operationID int) (dao.Operation, error) {
+	if operationID == 2 {
+		return dao.Operation{}, errors.New("Operation not found.")
+	}
+	return dao.Operation{ID: operationID}, nil
+}
+
+func (u MockTrashRepository) FindDeletedCategoryByUserAndId(user dao.User, categoryID int) (dao.Category, error) {
+	if categoryID == 2 {
+		return dao.Category{}, errors.New("Category not found.")
+	}
+	return dao.Category{ID: categoryID}, nil
+}
+
+func (u MockTrashRepository) CountDeletedCategoriesByOperation(operation dao.Operation) (int64, error) {
+	if operation.ID == 3 {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (u MockTrashRepository) CountDeletedAccountsByOperation(operation dao.Operation) (int64, error) {
+	if operation.ID == 5 {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (u MockTrashRepository) CountCategoryReferences(category dao.Category) (int64, error) {
+	if category.ID == 3 {
+		return 2, nil
+	}
+	return 0, nil
+}
+
+func (u MockTrashRepository) RestoreOperation(operation *dao.Operation) (dao.Operation, error) {
+	if operation.ID == 4 {
+		return dao.Operation{}, errors.New("Invalid operation.")
+	}
+	return *operation, nil
+}
+
+func (u MockTrashRepository) RestoreCategory(category *dao.Category) (dao.Category, error) {
+	if category.ID == 4 {
+		return dao.Category{}, errors.New("Invalid category.")
+	}
+	return *category, nil
+}
+
+func (u MockTrashRepository) PurgeOperation(operation *dao.Operation) (dao.Operation, error) {
+	if operation.ID == 4 {
+		return dao.Operation{}, errors.New("Invalid operation.")
+	}
+	return *operation, nil
+}
+
+func (u MockTrashRepository) PurgeCategory(category *dao.Category) (dao.Category, error) {
+	if category.ID == 4 {
+		return dao.Category{}, errors.New("Invalid category.")
+	}
+	return *category, nil
+}
+
+func (u MockTrashRepository) PurgeDeletedBefore(deletedBefore time.Time) (int64, int64, error) {
+	*u.purgedBefore = deletedBefore
+	return 3, 1, nil
+}
+
+func TestTrashServiceImpl_Index(t *testing.T) {
+	trashService := TrashServiceInit(&MockTrashRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the user has deleted operations and categories",
+			Params:       dao.User{ID: 1},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"operations\":[{\"id\":1,\"type\":\"income\",\"amount\":\"1200.50\",\"currency\":\"ARS\",\"date\":\"2023-10-24T10:00:00Z\",\"description\":\"Salario\",\"category_id\":1,\"account_id\":null,\"deleted_at\":\"2023-10-24T10:00:00Z\"}],\"categories\":[{\"id\":2,\"name\":\"Custom\",\"color\":\"#6495ed\",\"description\":\"Custom\",\"deleted_at\":\"2023-10-24T10:00:00Z\"}]}",
+		},
+		{
+			Name:         "when there is an error while listing the trash",
+			Params:       dao.User{ID: 2},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while listing the trash.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := trashService.Index(tt.Params.(dao.User))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestTrashServiceImpl_RestoreOperation(t *testing.T) {
+	trashService := TrashServiceInit(&MockTrashRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the operation is restored",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully restored.\"}",
+		},
+		{
+			Name:         "when the operation is not in the trash",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the category of the operation is in the trash",
+			Params:       3,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The category of the operation is in the trash.\"}",
+		},
+		{
+			Name:         "when the account of the operation has been deleted",
+			Params:       5,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The account of the operation has been deleted.\"}",
+		},
+		{
+			Name:         "when there is an error while restoring the operation",
+			Params:       4,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while restoring the operation.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := trashService.RestoreOperation(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestTrashServiceImpl_RestoreCategory(t *testing.T) {
+	trashService := TrashServiceInit(&MockTrashRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the category is restored",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Category successfully restored.\"}",
+		},
+		{
+			Name:         "when the category is not in the trash",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error while restoring the category",
+			Params:       4,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while restoring the category.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := trashService.RestoreCategory(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestTrashServiceImpl_PurgeOperation(t *testing.T) {
+	trashService := TrashServiceInit(&MockTrashRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the operation is purged",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Operation successfully purged.\"}",
+		},
+		{
+			Name:         "when the operation is not in the trash",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when there is an error while purging the operation",
+			Params:       4,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while purging the operation.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := trashService.PurgeOperation(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestTrashServiceImpl_PurgeCategory(t *testing.T) {
+	trashService := TrashServiceInit(&MockTrashRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the category is purged",
+			Params:       1,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Category successfully purged.\"}",
+		},
+		{
+			Name:         "when the category is not in the trash",
+			Params:       2,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "{\"error\":\"Not found.\"}",
+		},
+		{
+			Name:         "when the category is still used by operations",
+			Params:       3,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The category is still used by operations.\"}",
+		},
+		{
+			Name:         "when there is an error while purging the category",
+			Params:       4,
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while purging the category.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := trashService.PurgeCategory(dao.User{ID: 1}, tt.Params.(int))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestTrashPurgerImpl_Purge(t *testing.T) {
+	t.Setenv("TRASH_RETENTION_DAYS", "7")
+	purgedBefore := time.Time{}
+	trashPurger := TrashPurgerInit(MockTrashRepository{purgedBefore: &purgedBefore})
+
+	operations, categories := trashPurger.Purge(trashDeletedAt)
+
+	assert.Equal(t, int64(3), operations)
+	assert.Equal(t, int64(1), categories)
+	assert.Equal(t, time.Date(2023, 10, 17, 10, 0, 0, 0, time.UTC), purgedBefore)
+}
+
+func TestTrashPurgerInit(t *testing.T) {
+	t.Setenv("TRASH_RETENTION_DAYS", "none")
+	t.Setenv("TRASH_PURGE_INTERVAL", "")
+	trashPurger := TrashPurgerInit(MockTrashRepository{})
+
+	assert.Equal(t, DEFAULT_TRASH_RETENTION_DAYS, trashPurger.retentionDays)
+	assert.Equal(t, DEFAULT_TRASH_PURGE_INTERVAL, trashPurger.interval)
+}
@@ -4,9 +4,13 @@ import (
 	"GoGin-API-CuentasClaras/api/auth"
 	dao "GoGin-API-CuentasClaras/dao"
 	dto "GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
 	"GoGin-API-CuentasClaras/repository"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,13 +19,20 @@ type UserService interface {
 	RegisterUser(registerUserRequest dto.RegisterUserRequest) (int, map[string]any)
 	LoginUser(loginUserRequest dto.LoginRequest) (int, map[string]any)
 	CurrentUser(user dao.User) (int, map[string]any)
-	BalanceUser(user dao.User) (int, interface{})
+	BalanceUser(user dao.User, date time.Time) (int, interface{})
+	UpdateBaseCurrency(user dao.User, baseCurrencyRequest dto.BaseCurrencyRequest) (int, interface{})
+	BalanceHistory(user dao.User, historyFilter dto.BalanceHistoryFilter) (int, interface{})
 }
 
+const MAX_BALANCE_HISTORY_POINTS int = 400
+
 type UserServiceImpl struct {
-	userRepository      repository.UserRepository
-	auth                auth.Auth
-	operationRepository repository.OperationRepository
+	userRepository         repository.UserRepository
+	auth                   auth.Auth
+	operationRepository    repository.OperationRepository
+	exchangeRateRepository repository.ExchangeRateRepository
+	accountRepository      repository.AccountRepository
+	reportingRepository    repository.ReportingRepository
 }
 
 func (u UserServiceImpl) RegisterUser(registerUserRequest dto.RegisterUserRequest) (int, map[string]any) {
@@ -62,25 +73,179 @@ func (u UserServiceImpl) CurrentUser(user dao.User) (int, map[string]any) {
 	return http.StatusOK, gin.H{"email": user.Email, "username": user.Username}
 }
 
-func (u UserServiceImpl) BalanceUser(user dao.User) (int, interface{}) {
-	println("ACAAA ID DE OPERACION: ")
+func (u UserServiceImpl) BalanceUser(user dao.User, date time.Time) (int, interface{}) {
 	operations, _ := u.operationRepository.FindOperationsByUser(user)
-	var balance float32 = 0.0
+	accounts, _ := u.accountRepository.FindAccountsByUser(user)
+	baseCurrency := userBaseCurrency(user)
+
+	balances := map[string]money.Amount{}
+	accountBalances := map[int]money.Amount{}
+	accountCurrencies := map[int]string{}
+	for _, account := range accounts {
+		balances[account.Currency] += account.OpeningBalance
+		accountBalances[account.ID] = account.OpeningBalance
+		accountCurrencies[account.ID] = account.Currency
+	}
+
 	for _, operation := range operations {
-		if operation.Type == INCOME_TYPE {
-			balance += float32(operation.Amount)
-		} else {
-			balance -= float32(operation.Amount)
+		currency := operation.Currency
+		if currency == "" {
+			currency = DEFAULT_CURRENCY
+		}
+		amount := operation.Amount
+		if operation.Type != INCOME_TYPE {
+			amount = -amount
+		}
+		balances[currency] += amount
+
+		if operation.AccountID == nil {
+			continue
+		}
+		accountCurrency, ok := accountCurrencies[*operation.AccountID]
+		if !ok {
+			continue
+		}
+		converted, err := ConvertAmount(u.exchangeRateRepository, user, amount, currency, accountCurrency, date)
+		if err != nil {
+			return http.StatusUnprocessableEntity, gin.H{"error": fmt.Sprintf("Missing exchange rate from %s to %s.", currency, accountCurrency)}
+		}
+		accountBalances[*operation.AccountID] += converted
+	}
+
+	currencies := make([]string, 0, len(balances))
+	for currency := range balances {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	var totalBalance money.Amount
+	currencyBalances := []dto.CurrencyBalance{}
+	for _, currency := range currencies {
+		converted, err := ConvertAmount(u.exchangeRateRepository, user, balances[currency], currency, baseCurrency, date)
+		if err != nil {
+			return http.StatusUnprocessableEntity, gin.H{"error": fmt.Sprintf("Missing exchange rate from %s to %s.", currency, baseCurrency)}
+		}
+		totalBalance += converted
+		currencyBalances = append(currencyBalances, dto.CurrencyBalance{
+			Currency: currency,
+			Balance:  balances[currency].String(),
+		})
+	}
+
+	transformedAccounts := []dto.AccountBalance{}
+	for _, account := range accounts {
+		transformedAccounts = append(transformedAccounts, dto.AccountBalance{
+			ID:       account.ID,
+			Name:     account.Name,
+			Currency: account.Currency,
+			Balance:  accountBalances[account.ID].String(),
+		})
+	}
+
+	return http.StatusOK, dto.BalanceResponse{
+		TotalBalance: totalBalance.String(),
+		BaseCurrency: baseCurrency,
+		Date:         date.Format(DATE_LAYOUT),
+		Balances:     currencyBalances,
+		Accounts:     transformedAccounts,
+	}
+}
+
+func (u UserServiceImpl) UpdateBaseCurrency(user dao.User, baseCurrencyRequest dto.BaseCurrencyRequest) (int, interface{}) {
+	recordError := u.userRepository.UpdateBaseCurrency(user, strings.ToUpper(baseCurrencyRequest.BaseCurrency))
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred in the update of the base currency."}
+	}
+
+	return http.StatusOK, gin.H{"message": "Base currency successfully updated."}
+}
+
+// BalanceHistory returns the income, expense and closing balance of every day,
+// week or month of the period, one series per currency. Without dates it covers
+// the last twelve months, weeks or thirty days.
+func (u UserServiceImpl) BalanceHistory(user dao.User, historyFilter dto.BalanceHistoryFilter) (int, interface{}) {
+	interval := historyFilter.Interval
+	if interval == "" {
+		interval = dto.IntervalMonth
+	}
+	to := time.Now().In(time.UTC)
+	if historyFilter.To != nil {
+		to = historyFilter.To.In(time.UTC)
+	}
+	from := balanceHistoryStart(to, interval)
+	if historyFilter.From != nil {
+		from = historyFilter.From.In(time.UTC)
+	}
+	if from.After(to) {
+		return http.StatusUnprocessableEntity, gin.H{"error": "Invalid period."}
+	}
+	if balanceHistoryPoints(from, to, interval) > MAX_BALANCE_HISTORY_POINTS {
+		return http.StatusUnprocessableEntity, gin.H{"error": "The period has too many intervals."}
+	}
+
+	rows, recordError := u.reportingRepository.FindBalanceHistory(user, from, to, interval)
+	if recordError != nil {
+		return http.StatusUnprocessableEntity, gin.H{"error": "An error occurred while listing the balance history."}
+	}
+
+	series := []dto.BalanceHistorySeries{}
+	for _, row := range rows {
+		if len(series) == 0 || series[len(series)-1].Currency != row.Currency {
+			series = append(series, dto.BalanceHistorySeries{Currency: row.Currency, Points: []dto.BalanceHistoryPoint{}})
 		}
+		currencySeries := &series[len(series)-1]
+		currencySeries.Points = append(currencySeries.Points, dto.BalanceHistoryPoint{
+			Date:    row.Bucket.Format(DATE_LAYOUT),
+			Income:  row.Income,
+			Expense: row.Expense,
+			Balance: row.Balance,
+		})
+	}
+
+	return http.StatusOK, dto.BalanceHistoryResponse{
+		Interval: interval,
+		From:     from.Format(DATE_LAYOUT),
+		To:       to.Format(DATE_LAYOUT),
+		Series:   series,
 	}
+}
 
-	return http.StatusOK, gin.H{"total_balance": fmt.Sprintf("%.2f", balance)}
+func balanceHistoryStart(to time.Time, interval string) time.Time {
+	switch interval {
+	case dto.IntervalDay:
+		return to.AddDate(0, 0, -29)
+	case dto.IntervalWeek:
+		return to.AddDate(0, 0, -7*11)
+	}
+	return time.Date(to.Year(), to.Month()-11, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// balanceHistoryPoints counts the intervals between the dates, at most one more
+// than the history will have.
+func balanceHistoryPoints(from time.Time, to time.Time, interval string) int {
+	switch interval {
+	case dto.IntervalDay:
+		return int(to.Sub(from).Hours()/24) + 2
+	case dto.IntervalWeek:
+		return int(to.Sub(from).Hours()/(24*7)) + 2
+	}
+	return (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+}
+
+func userBaseCurrency(user dao.User) string {
+	if user.BaseCurrency == "" {
+		return DEFAULT_CURRENCY
+	}
+	return user.BaseCurrency
 }
 
-func UserServiceInit(userRepository repository.UserRepository, auth auth.Auth, operationRepository repository.OperationRepository) *UserServiceImpl {
+func UserServiceInit(userRepository repository.UserRepository, auth auth.Auth, operationRepository repository.OperationRepository, exchangeRateRepository repository.ExchangeRateRepository, accountRepository repository.AccountRepository, reportingRepository repository.ReportingRepository) *UserServiceImpl {
 	return &UserServiceImpl{
-		userRepository:      userRepository,
-		auth:                auth,
-		operationRepository: operationRepository,
+		userRepository:         userRepository,
+		auth:                   auth,
+		operationRepository:    operationRepository,
+		exchangeRateRepository: exchangeRateRepository,
+		accountRepository:      accountRepository,
+		reportingRepository:    reportingRepository,
 	}
 }
@@ -3,6 +3,8 @@ package services
 import (
 	dao "GoGin-API-CuentasClaras/dao"
 	dto "GoGin-API-CuentasClaras/dto"
+	"GoGin-API-CuentasClaras/money"
+	"GoGin-API-CuentasClaras/repository"
 	testhelpers "GoGin-API-CuentasClaras/test_helpers"
 	"errors"
 	"net/http"
@@ -32,6 +34,13 @@ func (m *MockUserRepository) FindUserById(id int) (dao.User, error) {
 	}
 }
 
+func (m *MockUserRepository) UpdateBaseCurrency(user dao.User, baseCurrency string) error {
+	if baseCurrency == "EUR" {
+		return errors.New("Base currency not updated.")
+	}
+	return nil
+}
+
 func (m *MockUserRepository) FindUserByEmail(email string) (dao.User, error) {
 	if email == "invalid.user@example.com" {
 		return dao.User{}, errors.New("User not found.")
@@ -65,13 +74,14 @@ type MockOperationRepositoryUser struct{}
 
 func (u MockOperationRepositoryUser) FindOperationsByUser(user dao.User) ([]dao.Operation, error) {
 	date, _ := time.Parse(time.RFC3339, "2023-10-23T21:33:03.73297-03:00")
+	walletAccountID, dollarsAccountID := 1, 2
 
 	if user.ID == 1 {
 		operations := []dao.Operation{}
 		user.Operations = append(operations, dao.Operation{
 			ID:     1,
 			Type:   "income",
-			Amount: 100.5,
+			Amount: money.FromFloat(100.5),
 			Date:   date,
 			Category: dao.Category{
 				Name:  "Work",
@@ -80,14 +90,60 @@ func (u MockOperationRepositoryUser) FindOperationsByUser(user dao.User) ([]dao.
 		})
 	} else if user.ID == 2 {
 		user.Operations = []dao.Operation{}
+	} else if user.ID == 3 {
+		user.Operations = []dao.Operation{
+			{ID: 2, Type: "income", Amount: money.FromFloat(1000), Currency: "ARS", Date: date, AccountID: &walletAccountID},
+			{ID: 3, Type: "income", Amount: money.FromFloat(10), Currency: "USD", Date: date, AccountID: &dollarsAccountID},
+			{ID: 4, Type: "expense", Amount: money.FromFloat(2.5), Currency: "USD", Date: date, AccountID: &walletAccountID},
+		}
+	} else if user.ID == 4 {
+		user.Operations = []dao.Operation{
+			{ID: 5, Type: "income", Amount: money.FromFloat(10), Currency: "EUR", Date: date},
+		}
 	}
 	return user.Operations, nil
 }
 
+func (u MockOperationRepositoryUser) FindOperationsByFilter(user dao.User, filter dto.OperationFilter) ([]dao.Operation, string, error) {
+	return []dao.Operation{}, "", nil
+}
+
 func (u MockOperationRepositoryUser) FindOperationByUserAndId(user dao.User, operationID int) (dao.Operation, error) {
 	return dao.Operation{}, nil
 }
 
+func (u MockOperationRepositoryUser) FindOperationsBetween(user dao.User, from time.Time, to time.Time) ([]dao.Operation, error) {
+	return []dao.Operation{}, nil
+}
+
+func (u MockOperationRepositoryUser) FindOperationsByExternalIDs(user dao.User, externalIDs []string) ([]dao.Operation, error) {
+	return []dao.Operation{}, nil
+}
+
+func (u MockOperationRepositoryUser) StreamOperationsByFilter(user dao.User, filter dto.OperationFilter, callback func(dto.OperationExportRow) error) error {
+	return nil
+}
+
+func (u MockOperationRepositoryUser) SearchOperations(user dao.User, search dto.OperationSearchRequest) ([]dto.OperationSearchRow, error) {
+	return []dto.OperationSearchRow{}, nil
+}
+
+func (u MockOperationRepositoryUser) FindDuplicateOperations(user dao.User, filter dto.OperationDuplicateFilter) ([]dto.OperationDuplicateRow, error) {
+	return []dto.OperationDuplicateRow{}, nil
+}
+
+func (u MockOperationRepositoryUser) MoveAttachments(from dao.Operation, to dao.Operation) error {
+	return nil
+}
+
+func (u MockOperationRepositoryUser) Transaction(callback func(repositories repository.OperationRepositories) error) error {
+	return callback(repository.OperationRepositories{Operations: u})
+}
+
+func (u MockOperationRepositoryUser) SaveAll(operations []dao.Operation) ([]dao.Operation, error) {
+	return operations, nil
+}
+
 func (u MockOperationRepositoryUser) Save(operation *dao.Operation) (dao.Operation, error) {
 	return dao.Operation{}, nil
 }
@@ -100,11 +156,87 @@ func (u MockOperationRepositoryUser) Delete(operation *dao.Operation) (dao.Opera
 	return dao.Operation{}, nil
 }
 
+type MockExchangeRateRepository struct{}
+
+func (u MockExchangeRateRepository) FindExchangeRatesByUser(user dao.User) ([]dao.ExchangeRate, error) {
+	return []dao.ExchangeRate{}, nil
+}
+
+func (u MockExchangeRateRepository) FindExchangeRateByUserAndId(user dao.User, exchangeRateID int) (dao.ExchangeRate, error) {
+	if exchangeRateID == 2 {
+		return dao.ExchangeRate{}, errors.New("Exchange rate not found.")
+	}
+	return dao.ExchangeRate{ID: exchangeRateID}, nil
+}
+
+func (u MockExchangeRateRepository) FindRateOnDate(user dao.User, fromCurrency string, toCurrency string, date time.Time) (dao.ExchangeRate, error) {
+	if fromCurrency == "USD" && toCurrency == "ARS" {
+		return dao.ExchangeRate{FromCurrency: "USD", ToCurrency: "ARS", Rate: 350, Date: date}, nil
+	}
+	return dao.ExchangeRate{}, errors.New("Exchange rate not found.")
+}
+
+func (u MockExchangeRateRepository) SaveAll(exchangeRates []dao.ExchangeRate) error {
+	for _, exchangeRate := range exchangeRates {
+		if exchangeRate.Rate == 999 {
+			return errors.New("Exchange rate not saved.")
+		}
+	}
+	return nil
+}
+
+func (u MockExchangeRateRepository) Delete(exchangeRate *dao.ExchangeRate) (dao.ExchangeRate, error) {
+	if exchangeRate.ID == 3 {
+		return dao.ExchangeRate{}, errors.New("Exchange rate not deleted.")
+	}
+	return *exchangeRate, nil
+}
+
+type MockReportingRepository struct{}
+
+func (m *MockReportingRepository) FindBalanceHistory(user dao.User, from time.Time, to time.Time, interval string) ([]dto.BalanceHistoryRow, error) {
+	if user.ID == 3 {
+		return nil, errors.New("Balance history not found.")
+	}
+	if user.ID == 2 {
+		return []dto.BalanceHistoryRow{}, nil
+	}
+	september, _ := time.Parse(time.RFC3339, "2023-09-01T00:00:00Z")
+	october, _ := time.Parse(time.RFC3339, "2023-10-01T00:00:00Z")
+	return []dto.BalanceHistoryRow{
+		{Bucket: september, Currency: "ARS", Income: money.FromFloat(1000), Expense: money.FromFloat(250), Balance: money.FromFloat(750)},
+		{Bucket: october, Currency: "ARS", Income: money.FromFloat(1200.5), Expense: money.FromFloat(100), Balance: money.FromFloat(1850.5)},
+		{Bucket: september, Currency: "USD", Income: money.FromFloat(0), Expense: money.FromFloat(0), Balance: money.FromFloat(10)},
+		{Bucket: october, Currency: "USD", Income: money.FromFloat(0), Expense: money.FromFloat(2.5), Balance: money.FromFloat(7.5)},
+	}, nil
+}
+
+func (m *MockReportingRepository) FindCategoryTotals(user dao.User, filter dto.CategoryReportFilter) ([]dto.CategoryReportRow, error) {
+	if user.ID == 3 {
+		return nil, errors.New("Category totals not found.")
+	}
+	if user.ID == 2 {
+		return []dto.CategoryReportRow{}, nil
+	}
+	workID, customID := 1, 2
+	if filter.Type == "income" {
+		return []dto.CategoryReportRow{
+			{Currency: "ARS", CategoryID: &workID, Name: "Work", Color: "#fdg123", IsDefault: true, Total: money.FromFloat(1200.5), Count: 1},
+		}, nil
+	}
+	return []dto.CategoryReportRow{
+		{Currency: "ARS", CategoryID: &workID, Name: "Work", Color: "#fdg123", IsDefault: true, Total: money.FromFloat(3000), Count: 2},
+		{Currency: "ARS", CategoryID: nil, Total: money.FromFloat(1000), Count: 1},
+		{Currency: "ARS", CategoryID: &customID, Name: "Custom", Color: "#6495ed", Total: money.FromFloat(500), Count: 1},
+		{Currency: "USD", CategoryID: &workID, Name: "Work", Color: "#fdg123", IsDefault: true, Total: money.FromFloat(10), Count: 1},
+	}, nil
+}
+
 func TestUserServiceImpl_RegisterUser(t *testing.T) {
 	userRepository := &MockUserRepository{}
 	auth := &MockAuth{}
 	operationRepository := &MockOperationRepositoryUser{}
-	userService := UserServiceInit(userRepository, auth, operationRepository)
+	userService := UserServiceInit(userRepository, auth, operationRepository, &MockExchangeRateRepository{}, &MockAccountRepository{}, &MockReportingRepository{})
 	serviceUri := "/api/users"
 
 	var tests = []testhelpers.TestStructure{
@@ -144,7 +276,7 @@ func TestUserServiceImpl_LoginUser(t *testing.T) {
 	userRepository := &MockUserRepository{}
 	auth := &MockAuth{}
 	operationRepository := &MockOperationRepositoryUser{}
-	userService := UserServiceInit(userRepository, auth, operationRepository)
+	userService := UserServiceInit(userRepository, auth, operationRepository, &MockExchangeRateRepository{}, &MockAccountRepository{}, &MockReportingRepository{})
 	serviceUri := "/api/users/login"
 
 	var tests = []testhelpers.TestStructure{
@@ -184,7 +316,7 @@ func TestUserServiceImpl_CurrentUser(t *testing.T) {
 	userRepository := &MockUserRepository{}
 	auth := &MockAuth{}
 	operationRepository := &MockOperationRepositoryUser{}
-	userService := UserServiceInit(userRepository, auth, operationRepository)
+	userService := UserServiceInit(userRepository, auth, operationRepository, &MockExchangeRateRepository{}, &MockAccountRepository{}, &MockReportingRepository{})
 
 	var tests = []testhelpers.TestInterfaceStructure{
 		{
@@ -207,31 +339,127 @@ func TestUserServiceImpl_BalanceUser(t *testing.T) {
 	userRepository := &MockUserRepository{}
 	auth := &MockAuth{}
 	operationRepository := &MockOperationRepositoryUser{}
-	userService := UserServiceInit(userRepository, auth, operationRepository)
+	userService := UserServiceInit(userRepository, auth, operationRepository, &MockExchangeRateRepository{}, &MockAccountRepository{}, &MockReportingRepository{})
+	date, _ := time.Parse(time.RFC3339, "2023-10-24T00:00:00Z")
 
 	var tests = []testhelpers.TestInterfaceStructure{
 		{
 			Name:         "when the request is successful",
-			Params:       "",
+			Params:       dao.User{ID: 1},
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "{\"total_balance\":\"100.50\"}",
+			ExpectedBody: "{\"total_balance\":\"100.50\",\"base_currency\":\"ARS\",\"date\":\"2023-10-24\",\"balances\":[{\"currency\":\"ARS\",\"balance\":\"100.50\"}],\"accounts\":[]}",
 		},
 		{
 			Name:         "when the user has no registered operations",
-			Params:       "",
+			Params:       dao.User{ID: 2},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"total_balance\":\"0.00\",\"base_currency\":\"ARS\",\"date\":\"2023-10-24\",\"balances\":[],\"accounts\":[]}",
+		},
+		{
+			Name:         "when the user has operations in several currencies and accounts",
+			Params:       dao.User{ID: 3, BaseCurrency: "ARS"},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"total_balance\":\"4125.00\",\"base_currency\":\"ARS\",\"date\":\"2023-10-24\",\"balances\":[{\"currency\":\"ARS\",\"balance\":\"1500.00\"},{\"currency\":\"USD\",\"balance\":\"7.50\"}],\"accounts\":[{\"id\":1,\"name\":\"Wallet\",\"currency\":\"ARS\",\"balance\":\"625.00\"},{\"id\":2,\"name\":\"Dollars\",\"currency\":\"USD\",\"balance\":\"10.00\"}]}",
+		},
+		{
+			Name:         "when the base currency uses the inverse rate",
+			Params:       dao.User{ID: 3, BaseCurrency: "USD"},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"total_balance\":\"11.79\",\"base_currency\":\"USD\",\"date\":\"2023-10-24\",\"balances\":[{\"currency\":\"ARS\",\"balance\":\"1500.00\"},{\"currency\":\"USD\",\"balance\":\"7.50\"}],\"accounts\":[{\"id\":1,\"name\":\"Wallet\",\"currency\":\"ARS\",\"balance\":\"625.00\"},{\"id\":2,\"name\":\"Dollars\",\"currency\":\"USD\",\"balance\":\"10.00\"}]}",
+		},
+		{
+			Name:         "when an exchange rate is missing",
+			Params:       dao.User{ID: 4},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Missing exchange rate from EUR to ARS.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := userService.BalanceUser(tt.Params.(dao.User), date)
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestUserServiceImpl_UpdateBaseCurrency(t *testing.T) {
+	userService := UserServiceInit(&MockUserRepository{}, &MockAuth{}, &MockOperationRepositoryUser{}, &MockExchangeRateRepository{}, &MockAccountRepository{}, &MockReportingRepository{})
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the request is successful",
+			Params:       dto.BaseCurrencyRequest{BaseCurrency: "usd"},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"message\":\"Base currency successfully updated.\"}",
+		},
+		{
+			Name:         "when there is an error in the update",
+			Params:       dto.BaseCurrencyRequest{BaseCurrency: "EUR"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred in the update of the base currency.\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			code, response := userService.UpdateBaseCurrency(dao.User{ID: 1}, tt.Params.(dto.BaseCurrencyRequest))
+
+			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
+		})
+	}
+}
+
+func TestUserServiceImpl_BalanceHistory(t *testing.T) {
+	userService := UserServiceInit(&MockUserRepository{}, &MockAuth{}, &MockOperationRepositoryUser{}, &MockExchangeRateRepository{}, &MockAccountRepository{}, &MockReportingRepository{})
+	from, _ := time.Parse(time.RFC3339, "2023-09-01T00:00:00Z")
+	to, _ := time.Parse(time.RFC3339, "2023-10-31T00:00:00Z")
+	tooEarly, _ := time.Parse(time.RFC3339, "2022-01-01T00:00:00Z")
+
+	var tests = []testhelpers.TestInterfaceStructure{
+		{
+			Name:         "when the request is successful",
+			Params:       dto.BalanceHistoryFilter{From: &from, To: &to, Interval: "month"},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "{\"interval\":\"month\",\"from\":\"2023-09-01\",\"to\":\"2023-10-31\",\"series\":[" +
+				"{\"currency\":\"ARS\",\"points\":[{\"date\":\"2023-09-01\",\"income\":\"1000.00\",\"expense\":\"250.00\",\"balance\":\"750.00\"},{\"date\":\"2023-10-01\",\"income\":\"1200.50\",\"expense\":\"100.00\",\"balance\":\"1850.50\"}]}," +
+				"{\"currency\":\"USD\",\"points\":[{\"date\":\"2023-09-01\",\"income\":\"0.00\",\"expense\":\"0.00\",\"balance\":\"10.00\"},{\"date\":\"2023-10-01\",\"income\":\"0.00\",\"expense\":\"2.50\",\"balance\":\"7.50\"}]}]}",
+		},
+		{
+			Name:         "when the user has no operations",
+			Params:       dto.BalanceHistoryFilter{From: &from, To: &to},
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: "{\"total_balance\":\"0.00\"}",
+			ExpectedBody: "{\"interval\":\"month\",\"from\":\"2023-09-01\",\"to\":\"2023-10-31\",\"series\":[]}",
+		},
+		{
+			Name:         "when the period starts after it ends",
+			Params:       dto.BalanceHistoryFilter{From: &to, To: &from},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"Invalid period.\"}",
+		},
+		{
+			Name:         "when the period has too many intervals",
+			Params:       dto.BalanceHistoryFilter{From: &tooEarly, To: &to, Interval: "day"},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"The period has too many intervals.\"}",
+		},
+		{
+			Name:         "when there is an error listing the history",
+			Params:       dto.BalanceHistoryFilter{From: &from, To: &to},
+			ExpectedCode: http.StatusUnprocessableEntity,
+			ExpectedBody: "{\"error\":\"An error occurred while listing the balance history.\"}",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
 			user := dao.User{ID: 1}
 
-			if tt.Name == "when the user has no registered operations" {
-				user = dao.User{ID: 2}
+			if tt.Name == "when the user has no operations" {
+				user.ID = 2
+			} else if tt.Name == "when there is an error listing the history" {
+				user.ID = 3
 			}
 
-			code, response := userService.BalanceUser(user)
+			code, response := userService.BalanceHistory(user, tt.Params.(dto.BalanceHistoryFilter))
 
 			testhelpers.AssertExpectedCodeAndResponseServiceDto(t, tt, code, response)
 		})
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const DEFAULT_STORAGE_DIR string = "attachments"
+
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// Storage keeps the content of uploaded files under slash separated keys.
+type Storage interface {
+	Save(key string, content io.Reader) (int64, error)
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// LocalStorage writes the files below a directory of the local filesystem.
+type LocalStorage struct {
+	dir string
+}
+
+func (u LocalStorage) Save(key string, content io.Reader) (int64, error) {
+	path, err := u.path(key)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	written, err := io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return 0, err
+	}
+	return written, nil
+}
+
+func (u LocalStorage) Open(key string) (io.ReadCloser, error) {
+	path, err := u.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// Delete removes the file of the key, a missing file is not an error.
+func (u LocalStorage) Delete(key string) error {
+	path, err := u.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (u LocalStorage) path(key string) (string, error) {
+	cleanKey := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(cleanKey) || cleanKey == ".." || strings.HasPrefix(cleanKey, ".."+string(filepath.Separator)) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(u.dir, cleanKey), nil
+}
+
+func LocalStorageInit() *LocalStorage {
+	dir := os.Getenv("ATTACHMENTS_DIR")
+	if dir == "" {
+		dir = DEFAULT_STORAGE_DIR
+	}
+	return &LocalStorage{
+		dir: dir,
+	}
+}
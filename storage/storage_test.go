@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	localStorage := LocalStorage{dir: t.TempDir()}
+
+	written, err := localStorage.Save("1/2/receipt", strings.NewReader("receipt"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), written)
+
+	_, err = localStorage.Save("1/2/receipt", strings.NewReader("another receipt"))
+	assert.ErrorIs(t, err, os.ErrExist)
+
+	file, err := localStorage.Open("1/2/receipt")
+	assert.NoError(t, err)
+	content, _ := io.ReadAll(file)
+	file.Close()
+	assert.Equal(t, "receipt", string(content))
+
+	assert.NoError(t, localStorage.Delete("1/2/receipt"))
+	assert.NoError(t, localStorage.Delete("1/2/receipt"))
+	_, err = os.Stat(filepath.Join(localStorage.dir, "1", "2", "receipt"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLocalStorage_InvalidKey(t *testing.T) {
+	localStorage := LocalStorage{dir: t.TempDir()}
+
+	for _, key := range []string{"", "../receipt", "1/../../receipt", "/etc/passwd"} {
+		_, err := localStorage.Save(key, strings.NewReader("receipt"))
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
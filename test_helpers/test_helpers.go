@@ -47,6 +47,14 @@ func MockPutRequest(request_body string, uri string) (*gin.Context, *httptest.Re
 	return ctx, responseRecorder
 }
 
+func MockPatchRequest(request_body string, content_type string, uri string) (*gin.Context, *httptest.ResponseRecorder) {
+	responseRecorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(responseRecorder)
+	ctx.Request = httptest.NewRequest("PATCH", uri, strings.NewReader(request_body))
+	ctx.Request.Header.Set("Content-Type", content_type)
+	return ctx, responseRecorder
+}
+
 func MockDeleteRequest(uri string) (*gin.Context, *httptest.ResponseRecorder) {
 	responseRecorder := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(responseRecorder)